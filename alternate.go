@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"html"
+	"html/template"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// XDefault is the hreflang of the alternate link used for unmatched languages
+const XDefault = "x-default"
+
+// Alternate is a version of a page in another language
+type Alternate struct {
+	// Hreflang is the language as BCP 47 tag or XDefault
+	Hreflang string
+	// URL is the location of the page in the language
+	URL string
+}
+
+// Alternates are the versions of a page in all available languages
+type Alternates []Alternate
+
+// Alternates returns the versions of a page in all loaded languages and the x-default
+// version referring to the default language. The URL of a language is built by urlFor,
+// e.g. using PathPrefix. Private use languages are not listed, being synthetic.
+func (trl Translations) Alternates(urlFor func(Language) string) Alternates {
+	var alternates Alternates
+	for _, lang := range trl.orderedLanguages() {
+		if lang.PrivateUse() {
+			continue
+		}
+		alternates = append(alternates, Alternate{Hreflang: lang.Tag(), URL: urlFor(lang)})
+	}
+
+	if len(alternates) > 0 {
+		alternates = append(alternates, Alternate{Hreflang: XDefault, URL: urlFor(trl.defaultLanguage)})
+	}
+	return alternates
+}
+
+// HTML returns the alternate links to be placed in the head of a page,
+// e.g. <link rel="alternate" hreflang="de" href="https://example.com/de/">
+func (alternates Alternates) HTML() template.HTML {
+	var b strings.Builder
+	for i, alternate := range alternates {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(`<link rel="alternate" hreflang="`)
+		b.WriteString(html.EscapeString(alternate.Hreflang))
+		b.WriteString(`" href="`)
+		b.WriteString(html.EscapeString(alternate.URL))
+		b.WriteString(`">`)
+	}
+	return template.HTML(b.String())
+}
+
+// SitemapLink is an alternate link of a sitemap url entry, encoding
+// to <xhtml:link rel="alternate" hreflang="de" href="https://example.com/de/"/>
+type SitemapLink struct {
+	XMLName  xml.Name `xml:"xhtml:link"`
+	Rel      string   `xml:"rel,attr"`
+	Hreflang string   `xml:"hreflang,attr"`
+	Href     string   `xml:"href,attr"`
+}
+
+// SitemapLinks returns the alternate links to be encoded within a sitemap url entry.
+// The sitemap must declare the namespace xmlns:xhtml="http://www.w3.org/1999/xhtml".
+func (alternates Alternates) SitemapLinks() []SitemapLink {
+	links := make([]SitemapLink, 0, len(alternates))
+	for _, alternate := range alternates {
+		links = append(links, SitemapLink{Rel: "alternate", Hreflang: alternate.Hreflang, Href: alternate.URL})
+	}
+	return links
+}
+
+// PathPrefix returns a function building the URL of a language by prefixing the path
+// of the canonical URL with the language, e.g. "https://example.com/de/pricing" for
+// "https://example.com/pricing". The canonical URL is returned as is if it is invalid.
+func PathPrefix(canonical string) func(Language) string {
+	return func(lang Language) string {
+		u, err := url.Parse(canonical)
+		if err != nil {
+			return canonical
+		}
+
+		trailing := strings.HasSuffix(u.Path, "/")
+		u.Path = path.Join("/", string(lang), u.Path)
+		if trailing && !strings.HasSuffix(u.Path, "/") {
+			u.Path += "/"
+		}
+		return u.String()
+	}
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAlternates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":       &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de-at.json":    &fstest.MapFile{Data: []byte(`{"a": "servus"}`)},
+		"x-pseudo.json": &fstest.MapFile{Data: []byte(`{"a": "[ħéľľö]"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alternates := translations.Alternates(PathPrefix("https://example.com/pricing?plan=pro"))
+	expected := Alternates{
+		{Hreflang: "en", URL: "https://example.com/en/pricing?plan=pro"},
+		{Hreflang: "de-AT", URL: "https://example.com/de-at/pricing?plan=pro"},
+		{Hreflang: XDefault, URL: "https://example.com/en/pricing?plan=pro"},
+	}
+	if !reflect.DeepEqual(alternates, expected) {
+		t.Fatalf("expected %v, got %v", expected, alternates)
+	}
+
+	html := `<link rel="alternate" hreflang="en" href="https://example.com/en/pricing?plan=pro">` + "\n" +
+		`<link rel="alternate" hreflang="de-AT" href="https://example.com/de-at/pricing?plan=pro">` + "\n" +
+		`<link rel="alternate" hreflang="x-default" href="https://example.com/en/pricing?plan=pro">`
+	if got := alternates.HTML(); string(got) != html {
+		t.Fatalf("expected %q, got %q", html, got)
+	}
+
+	sitemap, err := xml.Marshal(alternates[:1].SitemapLinks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<xhtml:link rel="alternate" hreflang="en" href="https://example.com/en/pricing?plan=pro"></xhtml:link>`; string(sitemap) != expected {
+		t.Fatalf("expected %q, got %q", expected, sitemap)
+	}
+}
+
+func TestPathPrefix(t *testing.T) {
+	fn := func(canonical string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := PathPrefix(canonical)("de"); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("root", fn("https://example.com/", "https://example.com/de/"))
+	t.Run("no path", fn("https://example.com", "https://example.com/de"))
+	t.Run("path", fn("https://example.com/a/b", "https://example.com/de/a/b"))
+	t.Run("trailing slash", fn("https://example.com/a/", "https://example.com/de/a/"))
+	t.Run("relative", fn("/a", "/de/a"))
+}
@@ -0,0 +1,162 @@
+package i18n
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gzipSuffix is the suffix of gzip compressed language files, e.g. "de.json.gz"
+const gzipSuffix = ".gz"
+
+// TarballFS reads the tar archive, e.g. a ".tar.gz" bundle of language files, into a
+// file system holding its regular files in memory, to be loaded using WithFS. Gzip
+// compressed archives are decompressed. Paths of the archive are taken relative to its root.
+// Files exceeding the maximum file size of the limits once decompressed are rejected.
+func TarballFS(r io.Reader, limits Limits) (fs.FS, error) {
+	buffered := bufio.NewReader(r)
+	if magic, _ := buffered.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tarball: %v", err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = buffered
+	}
+
+	archive := tarball{".": {name: ".", mode: fs.ModeDir | 0555}}
+	entries := tar.NewReader(r)
+	for {
+		header, err := entries.Next()
+		if err == io.EOF {
+			return archive, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tarball: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		if !fs.ValidPath(name) {
+			return nil, fmt.Errorf("invalid tarball: invalid path %q", header.Name)
+		}
+		// the reader of an entry never returns more than its size
+		if limits.MaxFileSize > 0 && header.Size > limits.MaxFileSize {
+			return nil, fmt.Errorf("invalid tarball: file %q exceeds maximum size of %d bytes", name, limits.MaxFileSize)
+		}
+		data, err := io.ReadAll(entries)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tarball: %v", err)
+		}
+		archive.add(name, data, header.ModTime)
+	}
+}
+
+// tarball is a read-only file system of the entries of a tar archive by their path
+type tarball map[string]*tarballEntry
+
+// add adds the file and its parent directories, later entries replacing earlier ones
+func (t tarball) add(name string, data []byte, modified time.Time) {
+	if entry, ok := t[name]; ok {
+		entry.data, entry.modified = data, modified
+		return
+	}
+
+	t[name] = &tarballEntry{name: name, data: data, mode: 0444, modified: modified}
+	for dir := path.Dir(name); ; dir = path.Dir(dir) {
+		parent, ok := t[dir]
+		if !ok {
+			parent = &tarballEntry{name: dir, mode: fs.ModeDir | 0555}
+			t[dir] = parent
+		}
+		parent.children = append(parent.children, name)
+		if ok {
+			return
+		}
+		name = dir
+	}
+}
+
+// Open opens the file or directory of the name
+func (t tarball) Open(name string) (fs.File, error) {
+	entry, ok := t[name]
+	if !fs.ValidPath(name) || !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &tarballFile{tarballEntry: entry, fsys: t, r: bytes.NewReader(entry.data)}, nil
+}
+
+// tarballEntry is a file or directory of a tarball, describing itself as fs.FileInfo and fs.DirEntry
+type tarballEntry struct {
+	name     string
+	data     []byte
+	mode     fs.FileMode
+	modified time.Time
+	// children are the paths of the entries of a directory
+	children []string
+}
+
+func (e *tarballEntry) Name() string               { return path.Base(e.name) }
+func (e *tarballEntry) Size() int64                { return int64(len(e.data)) }
+func (e *tarballEntry) Mode() fs.FileMode          { return e.mode }
+func (e *tarballEntry) ModTime() time.Time         { return e.modified }
+func (e *tarballEntry) IsDir() bool                { return e.mode.IsDir() }
+func (e *tarballEntry) Sys() interface{}           { return nil }
+func (e *tarballEntry) Type() fs.FileMode          { return e.mode.Type() }
+func (e *tarballEntry) Info() (fs.FileInfo, error) { return e, nil }
+
+// tarballFile is an opened entry of a tarball
+type tarballFile struct {
+	*tarballEntry
+	fsys tarball
+	r    *bytes.Reader
+	// read counts the entries of a directory already read
+	read int
+}
+
+func (f *tarballFile) Stat() (fs.FileInfo, error) { return f.tarballEntry, nil }
+func (f *tarballFile) Close() error               { return nil }
+
+func (f *tarballFile) Read(p []byte) (int, error) {
+	if f.IsDir() {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: errors.New("is a directory")}
+	}
+	return f.r.Read(p)
+}
+
+// ReadDir reads the entries of a directory in the order of their names
+func (f *tarballFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !f.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: f.name, Err: errors.New("not a directory")}
+	}
+
+	children := append([]string(nil), f.children...)
+	sort.Strings(children)
+	children = children[f.read:]
+	if n > 0 && len(children) == 0 {
+		return nil, io.EOF
+	}
+	if n > 0 && n < len(children) {
+		children = children[:n]
+	}
+	f.read += len(children)
+
+	entries := make([]fs.DirEntry, len(children))
+	for i, child := range children {
+		entries[i] = f.fsys[child]
+	}
+	return entries, nil
+}
@@ -0,0 +1,119 @@
+package i18n
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// gzipped compresses the data using gzip
+func gzipped(t *testing.T, data []byte) []byte {
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	if _, err := gz.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+// tarred archives the files in the given order
+func tarred(t *testing.T, files ...string) []byte {
+	var b bytes.Buffer
+	archive := tar.NewWriter(&b)
+	for i := 0; i < len(files); i += 2 {
+		header := &tar.Header{Name: files[i], Mode: 0644, Size: int64(len(files[i+1])), ModTime: time.Unix(1600000000, 0)}
+		if err := archive.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := archive.Write([]byte(files[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestCompressedFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"greeting": "Hello"}`)},
+		"de.json.gz": &fstest.MapFile{Data: gzipped(t, []byte(`{"greeting": "Hallo"}`))},
+		"fr.yml.gz":  &fstest.MapFile{Data: gzipped(t, []byte("fr:\n  greeting: Bonjour\n"))},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for lang, expected := range map[string]string{"de": "Hallo", "fr": "Bonjour"} {
+		if got, err := translations.GenerateTranslate(lang)("greeting"); err != nil || string(got) != expected {
+			t.Fatalf("expected %q, got %q: %v", expected, got, err)
+		}
+	}
+
+	issues := Validate(fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"greeting": "Hello"}`)},
+		"de.json.gz": &fstest.MapFile{Data: []byte(`{"greeting": "Hallo"}`)},
+	}, "en")
+	if len(issues) != 1 || issues[0].Message != "gzip: invalid header" {
+		t.Fatalf("expected issue of invalid gzip file, got %v", issues)
+	}
+
+	limited := Validate(fstest.MapFS{
+		"en.json.gz": &fstest.MapFile{Data: gzipped(t, []byte(`{"greeting": "`+string(bytes.Repeat([]byte("a"), 4096))+`"}`))},
+	}, "en", WithLimits(Limits{MaxFileSize: 1024}))
+	if len(limited) == 0 || limited[0].Message != "file exceeds maximum size" {
+		t.Fatalf("expected decompressed size to be limited, got %v", limited)
+	}
+}
+
+func TestTarballFS(t *testing.T) {
+	bundle := tarred(t,
+		"./en.json", `{"greeting": "Hello", "$include": "shared/legal.json"}`,
+		"de.json", `{"greeting": "Hallo", "$include": "shared/legal.json"}`,
+		"shared/legal.json", `{"imprint": "Nimbusec GmbH"}`,
+	)
+
+	fn := func(data []byte) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys, err := TarballFS(bytes.NewReader(data), Limits{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := fstest.TestFS(fsys, "en.json", "de.json", "shared/legal.json"); err != nil {
+				t.Fatal(err)
+			}
+
+			translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := translations.GenerateTranslate("de")("imprint"); err != nil || got != "Nimbusec GmbH" {
+				t.Fatalf("expected included translation, got %q: %v", got, err)
+			}
+		}
+	}
+
+	t.Run("tar", fn(bundle))
+	t.Run("tar.gz", fn(gzipped(t, bundle)))
+
+	if _, err := TarballFS(bytes.NewReader(tarred(t, "../en.json", "{}")), Limits{}); err == nil {
+		t.Fatal("expected error for path outside of the archive")
+	}
+	if _, err := TarballFS(bytes.NewReader([]byte("no tarball")), Limits{}); err == nil {
+		t.Fatal("expected error for invalid tarball")
+	}
+
+	bomb := gzipped(t, tarred(t, "en.json", `{"greeting": "`+string(bytes.Repeat([]byte("a"), 4096))+`"}`))
+	if _, err := TarballFS(bytes.NewReader(bomb), Limits{MaxFileSize: 1024}); err == nil || !strings.Contains(err.Error(), `file "en.json" exceeds maximum size of 1024 bytes`) {
+		t.Fatalf("expected decompressed size to be limited, got %v", err)
+	}
+}
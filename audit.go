@@ -0,0 +1,61 @@
+package i18n
+
+import (
+	"context"
+	"time"
+)
+
+// AuditEntry records an edit of a translation made through Catalog.Set or Catalog.Delete
+type AuditEntry struct {
+	// Actor is the one making the edit as carried by the context, see NewActorContext
+	Actor string
+	// Time is the time of the edit
+	Time time.Time
+	Edit Edit
+	// Previous is the message of the key before the edit, empty if it was not translated
+	Previous string
+	// Existed reports whether the key was translated in the language before the edit
+	Existed bool
+}
+
+// AuditSink records the edits made through a catalog, e.g. for compliance reviews of copy
+// edits. See WithAuditSink.
+type AuditSink interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
+
+// AuditSinkFunc is a function implementing AuditSink
+type AuditSinkFunc func(ctx context.Context, entry AuditEntry) error
+
+// Record calls f
+func (f AuditSinkFunc) Record(ctx context.Context, entry AuditEntry) error {
+	return f(ctx, entry)
+}
+
+// actorContextKey is the key of the actor within a context
+type actorContextKey struct{}
+
+// NewActorContext returns a copy of ctx carrying the actor making edits, e.g. the name
+// of the user of a copy editor, as recorded by the audit sink
+func NewActorContext(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// ActorFromContext returns the actor carried by ctx, reporting whether any is set
+func ActorFromContext(ctx context.Context) (string, bool) {
+	actor, ok := ctx.Value(actorContextKey{}).(string)
+	return actor, ok
+}
+
+// audit records the edit of the current translations to the audit sink
+func (trl Translations) audit(ctx context.Context, edit Edit) error {
+	previous, existed := trl.translations[edit.Language][edit.Key]
+	actor, _ := ActorFromContext(ctx)
+	return trl.auditSink.Record(ctx, AuditEntry{
+		Actor:    actor,
+		Time:     time.Now(),
+		Edit:     edit,
+		Previous: previous.Message,
+		Existed:  existed,
+	})
+}
@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"testing/fstest"
+)
+
+func TestAuditSink(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"title": "Title", "legacy": "Legacy"}`)},
+	}
+
+	var entries []AuditEntry
+	var failure error
+	sink := AuditSinkFunc(func(ctx context.Context, entry AuditEntry) error {
+		if failure != nil {
+			return failure
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithAuditSink(sink)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	ctx := NewActorContext(context.Background(), "alice")
+	if err := catalog.Set(ctx, "en", "title", "Heading"); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Set(context.Background(), "en", "subtitle", "Subheading"); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Delete(ctx, "en", "legacy"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []AuditEntry{
+		{Actor: "alice", Edit: Edit{Language: "en", Key: "title", Message: "Heading"}, Previous: "Title", Existed: true},
+		{Edit: Edit{Language: "en", Key: "subtitle", Message: "Subheading"}},
+		{Actor: "alice", Edit: Edit{Language: "en", Key: "legacy", Deleted: true}, Previous: "Legacy", Existed: true},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Time.IsZero() {
+			t.Fatalf("expected time of entry %d to be recorded", i)
+		}
+		entry.Time = expected[i].Time
+		if entry != expected[i] {
+			t.Fatalf("expected entry %+v, got %+v", expected[i], entry)
+		}
+	}
+
+	failure = errors.New("unavailable")
+	if err := catalog.Set(ctx, "en", "title", "Unaudited"); err == nil {
+		t.Fatal("expected edit to fail if it can not be recorded")
+	}
+	if got, _ := catalog.GenerateTranslate("en")("title"); got != "Heading" {
+		t.Fatalf("expected unrecorded edit to be rejected, got %q", got)
+	}
+}
@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxBundleSize limits the size of bundles and signatures downloaded
+const maxBundleSize = 64 << 20
+
+// LoadBundle verifies the Ed25519 signature of the zip archive bundle containing language
+// files and loads the language files using the options of the translations. The signature
+// is either raw or base64 encoded. Nothing is loaded if the signature is not valid.
+func (trl Translations) LoadBundle(bundle []byte, signature []byte, publicKey ed25519.PublicKey) (Translations, error) {
+	if err := verifyBundle(bundle, signature, publicKey); err != nil {
+		return Translations{}, err
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(bundle), int64(len(bundle)))
+	if err != nil {
+		return Translations{}, fmt.Errorf("invalid bundle: %v", err)
+	}
+
+	trl.fsys = archive
+	return trl.Load()
+}
+
+// verifyBundle verifies the signature of the bundle
+func verifyBundle(bundle []byte, signature []byte, publicKey ed25519.PublicKey) error {
+	if len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("invalid public key for verifying bundle")
+	}
+
+	if len(signature) != ed25519.SignatureSize {
+		decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature)))
+		if err != nil {
+			return fmt.Errorf("invalid bundle signature: %v", err)
+		}
+		signature = decoded
+	}
+
+	if len(signature) != ed25519.SignatureSize || !ed25519.Verify(publicKey, bundle, signature) {
+		return errors.New("invalid bundle signature")
+	}
+	return nil
+}
+
+// RemoteBundle is a signed bundle of language files published on an HTTP endpoint
+type RemoteBundle struct {
+	// URL is the location of the zip archive containing the language files
+	URL string
+	// SignatureURL is the location of the signature, URL with suffix ".sig" if empty
+	SignatureURL string
+	// PublicKey is the key verifying the signature
+	PublicKey ed25519.PublicKey
+
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+// fetch downloads the bundle and its signature
+func (r RemoteBundle) fetch(ctx context.Context) ([]byte, []byte, error) {
+	signatureURL := r.SignatureURL
+	if signatureURL == "" {
+		signatureURL = r.URL + ".sig"
+	}
+
+	bundle, err := r.get(ctx, r.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	signature, err := r.get(ctx, signatureURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bundle, signature, nil
+}
+
+// get downloads the resource at url
+func (r RemoteBundle) get(ctx context.Context, url string) ([]byte, error) {
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %q, status %d", url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBundleSize {
+		return nil, fmt.Errorf("failed to download %q, exceeds maximum size of %d bytes", url, maxBundleSize)
+	}
+	return data, nil
+}
+
+// UpdateFrom downloads the remote bundle, verifies its signature and swaps in its
+// translations loaded with the options of the current translations. The current
+// translations are kept if any step fails.
+func (c *Catalog) UpdateFrom(ctx context.Context, remote RemoteBundle) error {
+	bundle, signature, err := remote.fetch(ctx)
+	if err == nil {
+		err = c.update(func(current Translations) (Translations, error) {
+			return current.LoadBundle(bundle, signature, remote.PublicKey)
+		})
+	}
+	c.health.record(err, time.Now())
+	return err
+}
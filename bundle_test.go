@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+func newBundle(t *testing.T, files map[string]string) []byte {
+	var b bytes.Buffer
+	archive := zip.NewWriter(&b)
+	for name, content := range files {
+		f, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestLoadBundle(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, _ := ed25519.GenerateKey(nil)
+
+	bundle := newBundle(t, map[string]string{"en.json": `{"a": "hello"}`, "de.json": `{"a": "hallo"}`})
+	signature := ed25519.Sign(privateKey, bundle)
+	base := New(WithDefaultLanguage("en"))
+
+	fn := func(bundle []byte, signature []byte, publicKey ed25519.PublicKey, valid bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			translations, err := base.LoadBundle(bundle, signature, publicKey)
+			if !valid {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := translations.GenerateTranslate("de")("a"); err != nil || got != "hallo" {
+				t.Fatalf("unexpected translation %q: %v", got, err)
+			}
+		}
+	}
+
+	tampered := append([]byte{}, bundle...)
+	tampered[len(tampered)/2] ^= 0xff
+
+	t.Run("raw signature", fn(bundle, signature, publicKey, true))
+	t.Run("base64 signature", fn(bundle, []byte(base64.StdEncoding.EncodeToString(signature)+"\n"), publicKey, true))
+	t.Run("tampered bundle", fn(tampered, signature, publicKey, false))
+	t.Run("other key", fn(bundle, signature, otherKey, false))
+	t.Run("invalid signature", fn(bundle, []byte("invalid"), publicKey, false))
+	t.Run("invalid key", fn(bundle, signature, publicKey[:8], false))
+}
+
+func TestUpdateFrom(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := newBundle(t, map[string]string{"en.json": `{"a": "updated"}`})
+	files := map[string][]byte{
+		"/bundle.zip":     bundle,
+		"/bundle.zip.sig": ed25519.Sign(privateKey, bundle),
+		"/forged.zip":     newBundle(t, map[string]string{"en.json": `{"a": "forged"}`}),
+		"/forged.zip.sig": ed25519.Sign(privateKey, bundle),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(data)
+	}))
+	defer server.Close()
+
+	initial, err := New(WithDefaultLanguage("en"), WithFS(fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "initial"}`)}})).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog := NewCatalog(initial)
+	translate := catalog.GenerateTranslate("en")
+
+	fn := func(path string, expected string, valid bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			err := catalog.UpdateFrom(context.Background(), RemoteBundle{URL: server.URL + path, PublicKey: publicKey})
+			if valid != (err == nil) {
+				t.Fatalf("expected validity %v, got %v", valid, err)
+			}
+			if got, err := translate("a"); err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("forged", fn("/forged.zip", "initial", false))
+	t.Run("missing", fn("/missing.zip", "initial", false))
+	t.Run("signed", fn("/bundle.zip", "updated", true))
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// ByteUnits selects the units sizes are formatted in
+type ByteUnits int
+
+const (
+	// DecimalBytes formats sizes in powers of 1000, e.g. "1.5 MB"
+	DecimalBytes ByteUnits = iota
+	// BinaryBytes formats sizes in powers of 1024, e.g. "1.5 MiB"
+	BinaryBytes
+)
+
+// byteUnits are the unit symbols in ascending order
+var byteUnits = map[ByteUnits][]string{
+	DecimalBytes: {"B", "kB", "MB", "GB", "TB", "PB", "EB"},
+	BinaryBytes:  {"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"},
+}
+
+// octetUnits are the unit symbols of languages counting in octets, e.g. French
+var octetUnits = map[ByteUnits][]string{
+	DecimalBytes: {"o", "ko", "Mo", "Go", "To", "Po", "Eo"},
+	BinaryBytes:  {"o", "Kio", "Mio", "Gio", "Tio", "Pio", "Eio"},
+}
+
+// FormatBytes formats the size of n bytes in the largest unit not exceeding it, e.g.
+// "1.5 MB" in English, "1,5 MB" in German or "1,5 Mo" in French. Sizes below 10 units
+// are formatted with a single fractional digit, omitting a fraction of zero. The number
+// and the unit are separated by a no-break space.
+func FormatBytes(lang Language, n int64, units ByteUnits) string {
+	base := 1000.0
+	if units == BinaryBytes {
+		base = 1024
+	}
+
+	table := byteUnits
+	if lang.Base() == "fr" {
+		table = octetUnits
+	}
+	symbols, ok := table[units]
+	if !ok {
+		symbols = table[DecimalBytes]
+	}
+
+	size := math.Abs(float64(n))
+	unit := 0
+	for size >= base && unit < len(symbols)-1 {
+		size /= base
+		unit++
+	}
+
+	decimals := 0
+	if unit > 0 && size < 10 {
+		decimals = 1
+	}
+	if math.Round(size*math.Pow10(decimals)) >= base*math.Pow10(decimals) && unit < len(symbols)-1 {
+		// rounding carries over to the next unit, e.g. 999.95 kB to 1 MB
+		size /= base
+		unit++
+		decimals = 1
+	}
+
+	number := strconv.FormatFloat(size, 'f', decimals, 64)
+	if decimals > 0 && number[len(number)-1] == '0' {
+		number = number[:len(number)-2]
+	}
+	if n < 0 {
+		number = "-" + number
+	}
+	return localizeNumber(lang, number) + " " + symbols[unit]
+}
+
+// formatBytes formats numeric parameter values as decimal byte sizes
+func formatBytes(lang Language, value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return FormatBytes(lang, v.Int(), DecimalBytes)
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return FormatBytes(lang, int64(v.Uint()), DecimalBytes)
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return FormatBytes(lang, int64(v.Float()), DecimalBytes)
+	}
+	return formatValue(value)
+}
@@ -0,0 +1,41 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestFormatBytes(t *testing.T) {
+	fn := func(lang Language, n int64, units ByteUnits, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FormatBytes(lang, n, units); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("bytes", fn("en", 512, DecimalBytes, "512\u00a0B"))
+	t.Run("decimal", fn("en", 1500000, DecimalBytes, "1.5\u00a0MB"))
+	t.Run("german", fn("de", 1500000, DecimalBytes, "1,5\u00a0MB"))
+	t.Run("french", fn("fr", 1500000, DecimalBytes, "1,5\u00a0Mo"))
+	t.Run("binary", fn("en", 1536, BinaryBytes, "1.5\u00a0KiB"))
+	t.Run("zero fraction", fn("en", 2000, DecimalBytes, "2\u00a0kB"))
+	t.Run("large", fn("en", 123456789, DecimalBytes, "123\u00a0MB"))
+	t.Run("carry", fn("en", 999950, DecimalBytes, "1\u00a0MB"))
+	t.Run("negative", fn("de", -2500, DecimalBytes, "-2,5\u00a0kB"))
+	t.Run("exabytes", fn("en", 1<<62, BinaryBytes, "4\u00a0EiB"))
+}
+
+func TestBytesFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"de.json": &fstest.MapFile{Data: []byte(`{"usage": "{{used, bytes}} belegt"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("de"), WithEscapeFunc(nil)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := translations.GenerateTranslate("de")("usage", "used", uint64(2500000000)); err != nil || got != "2,5\u00a0GB belegt" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+}
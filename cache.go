@@ -0,0 +1,145 @@
+package i18n
+
+import (
+	"container/list"
+	"html/template"
+	"reflect"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// renderKey identifies a rendered translation by its language, key
+// and an exact encoding of the parameters it was rendered with
+type renderKey struct {
+	lang   Language
+	key    Key
+	params string
+}
+
+// newRenderKey creates the cache key for a translate call.
+// Each parameter is encoded with its dynamic type and exact value,
+// so "5", 5 and 5.0 result in distinct keys. It returns false if a
+// parameter is of a type that cannot be encoded exactly, in which case
+// the translation must not be cached.
+func newRenderKey(lang Language, key Key, params []interface{}) (renderKey, bool) {
+	var b []byte
+	for _, param := range params {
+		var value string
+		switch v := param.(type) {
+		case string:
+			value = v
+		case bool:
+			value = strconv.FormatBool(v)
+		case int:
+			value = strconv.FormatInt(int64(v), 10)
+		case int8:
+			value = strconv.FormatInt(int64(v), 10)
+		case int16:
+			value = strconv.FormatInt(int64(v), 10)
+		case int32:
+			value = strconv.FormatInt(int64(v), 10)
+		case int64:
+			value = strconv.FormatInt(v, 10)
+		case uint:
+			value = strconv.FormatUint(uint64(v), 10)
+		case uint8:
+			value = strconv.FormatUint(uint64(v), 10)
+		case uint16:
+			value = strconv.FormatUint(uint64(v), 10)
+		case uint32:
+			value = strconv.FormatUint(uint64(v), 10)
+		case uint64:
+			value = strconv.FormatUint(v, 10)
+		case float32:
+			value = strconv.FormatFloat(float64(v), 'g', -1, 32)
+		case float64:
+			value = strconv.FormatFloat(v, 'g', -1, 64)
+		case time.Time:
+			value = v.Format(time.RFC3339Nano) + " " + v.Location().String()
+		default:
+			return renderKey{}, false
+		}
+
+		b = appendKeyPart(b, reflect.TypeOf(param).String())
+		b = appendKeyPart(b, value)
+	}
+
+	return renderKey{
+		lang:   lang,
+		key:    key,
+		params: string(b),
+	}, true
+}
+
+// appendKeyPart appends the length prefixed part to b,
+// keeping parts unambiguous whatever bytes they contain
+func appendKeyPart(b []byte, part string) []byte {
+	b = strconv.AppendInt(b, int64(len(part)), 10)
+	b = append(b, ':')
+	return append(b, part...)
+}
+
+// renderCache is a bounded cache of rendered translations
+// evicting the least recently used entry once full.
+// It is safe for concurrent use.
+type renderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[renderKey]*list.Element
+}
+
+type renderEntry struct {
+	key   renderKey
+	value template.HTML
+}
+
+func newRenderCache(size int) *renderCache {
+	return &renderCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[renderKey]*list.Element, size),
+	}
+}
+
+// get returns the cached value for key, marking it as recently used
+func (c *renderCache) get(key renderKey) (template.HTML, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	c.order.MoveToFront(element)
+	return element.Value.(*renderEntry).value, true
+}
+
+// add stores the value for key, evicting the least recently used entry if required
+func (c *renderCache) add(key renderKey, value template.HTML) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*renderEntry).value = value
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&renderEntry{key: key, value: value})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*renderEntry).key)
+	}
+}
+
+// len returns the number of cached entries
+func (c *renderCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestRenderCache(t *testing.T) {
+	cache := newRenderCache(2)
+
+	a, _ := newRenderKey("en", "a", nil)
+	b, _ := newRenderKey("en", "b", []interface{}{"name", "bob"})
+	c, _ := newRenderKey("en", "b", []interface{}{"name", "alice"})
+
+	cache.add(a, "a")
+	cache.add(b, "b")
+
+	// touch a so that b becomes the least recently used entry
+	if value, ok := cache.get(a); !ok || value != "a" {
+		t.Fatalf("expected cached a, got %q", value)
+	}
+
+	cache.add(c, "c")
+	if l := cache.len(); l != 2 {
+		t.Fatalf("expected 2 entries, got %d", l)
+	}
+	if _, ok := cache.get(b); ok {
+		t.Fatal("expected b to be evicted")
+	}
+	if _, ok := cache.get(a); !ok {
+		t.Fatal("expected a to be cached")
+	}
+	if value, ok := cache.get(c); !ok || value != "c" {
+		t.Fatalf("expected cached c, got %q", value)
+	}
+}
+
+func TestRenderKey(t *testing.T) {
+	fn := func(a, b []interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			ka, ok := newRenderKey("en", "key", a)
+			if !ok {
+				t.Fatalf("expected %v to be cacheable", a)
+			}
+			kb, ok := newRenderKey("en", "key", b)
+			if !ok {
+				t.Fatalf("expected %v to be cacheable", b)
+			}
+			if ka == kb {
+				t.Fatalf("expected distinct keys for %v and %v", a, b)
+			}
+		}
+	}
+
+	t.Run("string and int", fn([]interface{}{"n", "5"}, []interface{}{"n", 5}))
+	t.Run("int and float", fn([]interface{}{"n", 5}, []interface{}{"n", 5.0}))
+	t.Run("int and int64", fn([]interface{}{"n", 5}, []interface{}{"n", int64(5)}))
+	t.Run("separator in value", fn([]interface{}{"a", "x\x00b", "y"}, []interface{}{"a", "x", "b", "y"}))
+	t.Run("name and value", fn([]interface{}{"a", "b"}, []interface{}{"ab", ""}))
+
+	if _, ok := newRenderKey("en", "key", []interface{}{"n", &struct{}{}}); ok {
+		t.Fatal("expected pointer parameter not to be cacheable")
+	}
+}
+
+func TestCachedTranslate(t *testing.T) {
+	translations, err := NewTranslations(Count+"first", "en", WithCache(10)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	translate := translations.GenerateDefaultTranslate()
+
+	for _, name := range []string{"alice", "bob", "alice"} {
+		got, err := translate("whoami", "whoami", name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := "you are " + name; string(got) != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+
+	if l := translations.cache.len(); l != 2 {
+		t.Fatalf("expected 2 cached translations, got %d", l)
+	}
+
+	// parameters of different types must not share a cached rendering
+	number, err := translate("whoami", "whoami", 1234.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text, err := translate("whoami", "whoami", "1234.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number == text {
+		t.Fatalf("expected float and string parameters to render differently, got %q", number)
+	}
+	if l := translations.cache.len(); l != 4 {
+		t.Fatalf("expected 4 cached translations, got %d", l)
+	}
+
+	// failed translations must not be cached
+	if _, err := translate("expired", "count", 1); err == nil {
+		t.Fatal("expected error for missing parameter")
+	}
+	if l := translations.cache.len(); l != 4 {
+		t.Fatalf("expected 4 cached translations, got %d", l)
+	}
+}
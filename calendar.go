@@ -0,0 +1,342 @@
+package i18n
+
+import "time"
+
+// Calendar is a calendar system dates may be formatted in
+type Calendar string
+
+// Supported calendars
+const (
+	Gregorian Calendar = "gregorian"
+	// Buddhist counts years since the death of Buddha, e.g. 2564 BE for 2021
+	Buddhist Calendar = "buddhist"
+	// Japanese counts years within the eras of the emperors, e.g. Reiwa 3 for 2021
+	Japanese Calendar = "japanese"
+	// Islamic is the arithmetical (tabular) Islamic calendar, which may differ from
+	// observational calendars like Umm al-Qura by a day
+	Islamic Calendar = "islamic"
+	// Hebrew is the arithmetical Hebrew calendar
+	Hebrew Calendar = "hebrew"
+)
+
+// defaultCalendars are the calendars languages commonly use instead of the gregorian calendar
+var defaultCalendars = map[Language]Calendar{
+	"th": Buddhist,
+}
+
+// DefaultCalendar returns the calendar commonly used for dates in the language,
+// e.g. the buddhist calendar for Thai. It is the gregorian calendar for most languages.
+func DefaultCalendar(lang Language) Calendar {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if calendar, ok := defaultCalendars[candidate]; ok {
+			return calendar
+		}
+	}
+	return Gregorian
+}
+
+// valid reports whether the calendar is known
+func (c Calendar) valid() bool {
+	switch c {
+	case Gregorian, Buddhist, Japanese, Islamic, Hebrew:
+		return true
+	}
+	return false
+}
+
+// calendarDate is a date within a calendar
+type calendarDate struct {
+	era   string
+	year  int
+	month int
+	day   int
+	// leap reports whether the year contains a leap month as Hebrew leap years do
+	leap bool
+}
+
+// date converts the date of t into the calendar, naming the era by a key of eraNames
+func (c Calendar) date(t time.Time) calendarDate {
+	y, m, d := t.Date()
+	date := calendarDate{year: y, month: int(m), day: d}
+
+	switch c {
+	case Buddhist:
+		date.era, date.year = "be", y+543
+	case Japanese:
+		// eras start at civil dates, compared with the date of t in its location
+		for _, era := range japaneseEras {
+			if y > era.year || y == era.year && (int(m) > era.month || int(m) == era.month && d >= era.day) {
+				date.era, date.year = era.name, y-era.year+1
+				break
+			}
+		}
+	case Islamic:
+		date.era = "ah"
+		date.year, date.month, date.day = islamicFromFixed(fixedFromGregorian(y, int(m), d))
+	case Hebrew:
+		date.year, date.month, date.day = hebrewFromFixed(fixedFromGregorian(y, int(m), d))
+		date.leap = hebrewLeapYear(date.year)
+	}
+	return date
+}
+
+// japaneseEra is an era of the japanese calendar starting at the civil date
+type japaneseEra struct {
+	name             string
+	year, month, day int
+}
+
+// japaneseEras are the modern eras, latest first. Dates before the Meiji era are
+// formatted with gregorian years.
+var japaneseEras = []japaneseEra{
+	{"reiwa", 2019, 5, 1},
+	{"heisei", 1989, 1, 8},
+	{"showa", 1926, 12, 25},
+	{"taisho", 1912, 7, 30},
+	{"meiji", 1868, 1, 1},
+}
+
+// The conversions follow the arithmetic of Reingold and Dershowitz, Calendrical
+// Calculations, counting days as fixed dates with day 1 being January 1 of year 1.
+
+// floorDiv divides rounding towards negative infinity
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// floorMod returns the remainder of floorDiv
+func floorMod(a, b int) int {
+	return a - b*floorDiv(a, b)
+}
+
+// fixedFromGregorian returns the fixed date of a gregorian date
+func fixedFromGregorian(year, month, day int) int {
+	fixed := 365*(year-1) + floorDiv(year-1, 4) - floorDiv(year-1, 100) + floorDiv(year-1, 400) + floorDiv(367*month-362, 12) + day
+	if month > 2 {
+		if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+			fixed--
+		} else {
+			fixed -= 2
+		}
+	}
+	return fixed
+}
+
+// islamicEpoch is the fixed date of July 16, 622 (julian), the first day of the Islamic calendar
+const islamicEpoch = 227015
+
+// fixedFromIslamic returns the fixed date of an Islamic date
+func fixedFromIslamic(year, month, day int) int {
+	return day + 29*(month-1) + floorDiv(6*month-1, 11) + (year-1)*354 + floorDiv(3+11*year, 30) + islamicEpoch - 1
+}
+
+// islamicFromFixed returns the Islamic date of a fixed date
+func islamicFromFixed(fixed int) (year, month, day int) {
+	year = floorDiv(30*(fixed-islamicEpoch)+10646, 10631)
+	prior := fixed - fixedFromIslamic(year, 1, 1)
+	month = floorDiv(11*prior+330, 325)
+	day = fixed - fixedFromIslamic(year, month, 1) + 1
+	return year, month, day
+}
+
+// hebrewEpoch is the fixed date of October 7, 3761 BCE (julian), the first day of the Hebrew calendar
+const hebrewEpoch = -1373427
+
+// Hebrew months are numbered starting at Nisan, the year starting with Tishri
+const (
+	nisan      = 1
+	iyyar      = 2
+	tammuz     = 4
+	elul       = 6
+	tishri     = 7
+	marheshvan = 8
+	kislev     = 9
+	tevet      = 10
+	adar       = 12
+	adarII     = 13
+)
+
+// hebrewLeapYear reports whether the year contains the leap month Adar II
+func hebrewLeapYear(year int) bool {
+	return floorMod(7*year+1, 19) < 7
+}
+
+// lastMonthOfHebrewYear returns the number of the last month of the year
+func lastMonthOfHebrewYear(year int) int {
+	if hebrewLeapYear(year) {
+		return adarII
+	}
+	return adar
+}
+
+// hebrewElapsedDays returns the number of days elapsed from the epoch to the molad of Tishri of the year
+func hebrewElapsedDays(year int) int {
+	months := floorDiv(235*year-234, 19)
+	parts := 12084 + 13753*months
+	days := 29*months + floorDiv(parts, 25920)
+	if floorMod(3*(days+1), 7) < 3 {
+		return days + 1
+	}
+	return days
+}
+
+// hebrewYearLengthCorrection delays the new year to prevent impossible year lengths
+func hebrewYearLengthCorrection(year int) int {
+	previous, current, next := hebrewElapsedDays(year-1), hebrewElapsedDays(year), hebrewElapsedDays(year+1)
+	switch {
+	case next-current == 356:
+		return 2
+	case current-previous == 382:
+		return 1
+	}
+	return 0
+}
+
+// hebrewNewYear returns the fixed date of the first day of Tishri of the year
+func hebrewNewYear(year int) int {
+	return hebrewEpoch + hebrewElapsedDays(year) + hebrewYearLengthCorrection(year)
+}
+
+// lastDayOfHebrewMonth returns the number of days of the month within the year
+func lastDayOfHebrewMonth(month, year int) int {
+	days := hebrewNewYear(year+1) - hebrewNewYear(year)
+	switch {
+	case month == iyyar, month == tammuz, month == elul, month == tevet, month == adarII,
+		month == adar && !hebrewLeapYear(year),
+		month == marheshvan && days != 355 && days != 385,
+		month == kislev && (days == 353 || days == 383):
+		return 29
+	}
+	return 30
+}
+
+// fixedFromHebrew returns the fixed date of a Hebrew date
+func fixedFromHebrew(year, month, day int) int {
+	fixed := hebrewNewYear(year) + day - 1
+	if month < tishri {
+		for m := tishri; m <= lastMonthOfHebrewYear(year); m++ {
+			fixed += lastDayOfHebrewMonth(m, year)
+		}
+		for m := nisan; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(m, year)
+		}
+	} else {
+		for m := tishri; m < month; m++ {
+			fixed += lastDayOfHebrewMonth(m, year)
+		}
+	}
+	return fixed
+}
+
+// hebrewFromFixed returns the Hebrew date of a fixed date
+func hebrewFromFixed(fixed int) (year, month, day int) {
+	// the average year length is 35975351/98496 days
+	year = floorDiv((fixed-hebrewEpoch)*98496, 35975351)
+	for hebrewNewYear(year+1) <= fixed {
+		year++
+	}
+
+	month = tishri
+	if fixed < fixedFromHebrew(year, nisan, 1) {
+		for fixed > fixedFromHebrew(year, month, lastDayOfHebrewMonth(month, year)) {
+			month++
+		}
+	} else {
+		month = nisan
+		for fixed > fixedFromHebrew(year, month, lastDayOfHebrewMonth(month, year)) {
+			month++
+		}
+	}
+	day = fixed - fixedFromHebrew(year, month, 1) + 1
+	return year, month, day
+}
+
+// calendarPatterns are the date patterns of the calendars in the wide and short style
+// per language, formatting languages lacking patterns like English. The era is referred
+// to by {G}, gregorian patterns being taken of dateTimePatternsByLanguage.
+var calendarPatterns = map[Calendar]map[Language][2]string{
+	Buddhist: {
+		"en": {"{MMMM} {d}, {y} {G}", "{M}/{d}/{y} {G}"},
+		"th": {"{d} {MMMM} {G} {y}", "{d}/{M}/{y}"},
+	},
+	Japanese: {
+		"en": {"{MMMM} {d}, {y} {G}", "{M}/{d}/{y} {G}"},
+		"ja": {"{G}{y}年{M}月{d}日", "{G}{y}/{M}/{d}"},
+	},
+	Islamic: {
+		"ar": {"{d} {MMMM} {y} {G}", "{d}/{M}/{y} {G}"},
+		"en": {"{MMMM} {d}, {y} {G}", "{M}/{d}/{y} {G}"},
+	},
+	Hebrew: {
+		"en": {"{d} {MMMM} {y}", "{d} {MMMM} {y}"},
+		"he": {"{d} ב{MMMM} {y}", "{d} ב{MMMM} {y}"},
+	},
+}
+
+// eraNames are the names of the eras per language, falling back to English
+var eraNames = map[Language]map[string]string{
+	"ar": {"ah": "هـ"},
+	"en": {
+		"be": "BE", "ah": "AH",
+		"reiwa": "Reiwa", "heisei": "Heisei", "showa": "Shōwa", "taisho": "Taishō", "meiji": "Meiji",
+	},
+	"ja": {"reiwa": "令和", "heisei": "平成", "showa": "昭和", "taisho": "大正", "meiji": "明治"},
+	"th": {"be": "พ.ศ."},
+}
+
+// calendarMonthNames are the wide names of the months of calendars not sharing the gregorian
+// months per language, falling back to English. Hebrew months are listed starting at Nisan,
+// followed by Adar II and Adar I, the first Adar of leap years.
+var calendarMonthNames = map[Calendar]map[Language][]string{
+	Islamic: {
+		"ar": {"محرم", "صفر", "ربيع الأول", "ربيع الآخر", "جمادى الأولى", "جمادى الآخرة", "رجب", "شعبان", "رمضان", "شوال", "ذو القعدة", "ذو الحجة"},
+		"en": {"Muharram", "Safar", "Rabiʻ I", "Rabiʻ II", "Jumada I", "Jumada II", "Rajab", "Shaʻban", "Ramadan", "Shawwal", "Dhuʻl-Qiʻdah", "Dhuʻl-Hijjah"},
+	},
+	Hebrew: {
+		"en": {"Nisan", "Iyar", "Sivan", "Tamuz", "Av", "Elul", "Tishri", "Heshvan", "Kislev", "Tevet", "Shevat", "Adar", "Adar II", "Adar I"},
+		"he": {"ניסן", "אייר", "סיוון", "תמוז", "אב", "אלול", "תשרי", "חשוון", "כסלו", "טבת", "שבט", "אדר", "אדר ב׳", "אדר א׳"},
+	},
+}
+
+// calendarDatePattern returns the date pattern of the calendar in the language
+func calendarDatePattern(lang Language, calendar Calendar, style Style) string {
+	patterns, ok := calendarPatterns[calendar]
+	if !ok {
+		return dateTimePatternsOf(lang).date[styleIndex(style)]
+	}
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if pattern, ok := patterns[candidate]; ok {
+			return pattern[styleIndex(style)]
+		}
+	}
+	return patterns["en"][styleIndex(style)]
+}
+
+// eraName returns the name of the era of the date in the language
+func eraName(lang Language, date calendarDate) string {
+	if name, ok := eraNames[lang.Base()][date.era]; ok {
+		return name
+	}
+	return eraNames["en"][date.era]
+}
+
+// monthName returns the wide name of the month of the date in the language
+func monthName(lang Language, calendar Calendar, date calendarDate) string {
+	names, ok := calendarMonthNames[calendar]
+	if !ok {
+		return namesOf(monthNames, lang).wide[date.month-1]
+	}
+
+	month, ok := names[lang.Base()]
+	if !ok {
+		month = names["en"]
+	}
+	if calendar == Hebrew && date.leap && date.month == adar {
+		return month[len(month)-1]
+	}
+	return month[date.month-1]
+}
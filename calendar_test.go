@@ -0,0 +1,118 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFormatCalendarDate(t *testing.T) {
+	fn := func(lang Language, date string, style Style, calendar Calendar, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			ts, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FormatCalendarDate(lang, ts, style, calendar); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("gregorian", fn("en", "2021-03-04", Wide, Gregorian, "March 4, 2021"))
+	t.Run("buddhist", fn("en", "2021-03-04", Wide, Buddhist, "March 4, 2564 BE"))
+	t.Run("thai buddhist", fn("th", "2021-03-04", Short, Buddhist, "4/3/2564"))
+	t.Run("japanese", fn("ja", "2021-03-04", Wide, Japanese, "令和3年3月4日"))
+	t.Run("japanese heisei", fn("en", "2019-04-30", Wide, Japanese, "April 30, 31 Heisei"))
+	t.Run("japanese reiwa", fn("ja", "2019-05-01", Short, Japanese, "令和1/5/1"))
+	t.Run("islamic", fn("en", "2021-03-04", Wide, Islamic, "Rajab 20, 1442 AH"))
+	t.Run("islamic epoch", fn("en", "0622-07-19", Wide, Islamic, "Muharram 1, 1 AH"))
+	t.Run("arabic islamic", fn("ar", "2021-03-04", Wide, Islamic, "20 رجب 1442 هـ"))
+	t.Run("hebrew", fn("en", "2021-03-04", Wide, Hebrew, "20 Adar 5781"))
+	t.Run("hebrew new year", fn("en", "2021-09-07", Wide, Hebrew, "1 Tishri 5782"))
+	t.Run("hebrew leap year", fn("en", "2022-02-04", Wide, Hebrew, "3 Adar I 5782"))
+	t.Run("hebrew leap month", fn("en", "2022-03-04", Wide, Hebrew, "1 Adar II 5782"))
+	t.Run("hebrew in hebrew", fn("he", "2021-03-04", Wide, Hebrew, "20 באדר 5781"))
+	t.Run("fallback pattern", fn("de", "2021-03-04", Wide, Islamic, "Rajab 20, 1442 AH"))
+}
+
+func TestJapaneseEraBoundaries(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		tokyo = time.FixedZone("JST", 9*60*60)
+	}
+
+	fn := func(ts time.Time, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FormatCalendarDate("ja", ts, Wide, Japanese); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	// the eras start at midnight in Japan, which is the previous day in UTC
+	t.Run("reiwa", fn(time.Date(2019, time.May, 1, 0, 30, 0, 0, tokyo), "令和1年5月1日"))
+	t.Run("end of heisei", fn(time.Date(2019, time.April, 30, 23, 30, 0, 0, tokyo), "平成31年4月30日"))
+	t.Run("heisei", fn(time.Date(1989, time.January, 8, 0, 30, 0, 0, tokyo), "平成1年1月8日"))
+	t.Run("end of showa", fn(time.Date(1989, time.January, 7, 23, 30, 0, 0, tokyo), "昭和64年1月7日"))
+}
+
+func TestCalendarConversions(t *testing.T) {
+	// round trips along four centuries of days
+	start := fixedFromGregorian(1900, 1, 1)
+	for fixed := start; fixed < start+146097; fixed += 13 {
+		if y, m, d := islamicFromFixed(fixed); fixedFromIslamic(y, m, d) != fixed {
+			t.Fatalf("islamic date %d-%d-%d of %d converts back to %d", y, m, d, fixed, fixedFromIslamic(y, m, d))
+		}
+		if y, m, d := hebrewFromFixed(fixed); fixedFromHebrew(y, m, d) != fixed {
+			t.Fatalf("hebrew date %d-%d-%d of %d converts back to %d", y, m, d, fixed, fixedFromHebrew(y, m, d))
+		}
+	}
+}
+
+func TestDefaultCalendar(t *testing.T) {
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.UTC)
+
+	if got := DefaultCalendar("th-th"); got != Buddhist {
+		t.Fatalf("expected buddhist calendar for thai, got %q", got)
+	}
+	if got := DefaultCalendar("en"); got != Gregorian {
+		t.Fatalf("expected gregorian calendar for english, got %q", got)
+	}
+	if got, expected := FormatDate("th", ts, Wide), "4 มีนาคม พ.ศ. 2564"; got != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+}
+
+func TestCalendarFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"default": "Due {{ts, date(style=wide)}}",
+			"japanese": "Due {{ts, date(style=wide; calendar=japanese)}}",
+			"islamic": "Due {{ts, datetime(calendar=islamic; zone=UTC)}}"
+		}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.UTC)
+
+	fn := func(key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("en")(key, "ts", ts)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("default", fn("default", "Due March 4, 2021"))
+	t.Run("japanese", fn("japanese", "Due March 4, 3 Reiwa"))
+	t.Run("islamic", fn("islamic", "Due 7/20/1442 AH, 3:04 PM"))
+
+	issues := Validate(fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "{{ts, time(calendar=hebrew)}}"}`)}}, "en")
+	if len(issues) != 1 {
+		t.Fatalf("expected issue for calendar of time format, got %v", issues)
+	}
+}
@@ -0,0 +1,159 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// caser converts the case of letters following the rules of a language
+type caser struct {
+	// special are the case mappings deviating from the unicode defaults, e.g. the Turkish dotted İ
+	special unicode.SpecialCase
+	// digraphIJ reports whether "ij" is capitalized as a single letter as in Dutch, e.g. "IJsland"
+	digraphIJ bool
+}
+
+// newCaser returns the caser of the language
+func newCaser(lang Language) caser {
+	switch lang.Base() {
+	case "tr", "az":
+		return caser{special: unicode.TurkishCase}
+	case "nl":
+		return caser{digraphIJ: true}
+	}
+	return caser{}
+}
+
+func (c caser) upper(r rune) rune {
+	if c.special != nil {
+		return c.special.ToUpper(r)
+	}
+	return unicode.ToUpper(r)
+}
+
+func (c caser) lower(r rune) rune {
+	if c.special != nil {
+		return c.special.ToLower(r)
+	}
+	return unicode.ToLower(r)
+}
+
+func (c caser) title(r rune) rune {
+	if c.special != nil {
+		return c.special.ToTitle(r)
+	}
+	return unicode.ToTitle(r)
+}
+
+// capitalize writes the title case of the letter at the start of s to b, returning
+// the number of bytes consumed. The Dutch digraph "ij" is capitalized as a whole.
+func (c caser) capitalize(b *strings.Builder, s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if c.digraphIJ && (r == 'i' || r == 'I') && len(s) > 1 && (s[1] == 'j' || s[1] == 'J') {
+		b.WriteString("IJ")
+		return 2
+	}
+	if r == 'ß' {
+		// ß has no single title case letter
+		b.WriteString("Ss")
+		return size
+	}
+	b.WriteRune(c.title(r))
+	return size
+}
+
+// Upper returns s with all letters mapped to their upper case within the language,
+// e.g. "i" to "İ" in Turkish and "ß" to "SS"
+func Upper(lang Language, s string) string {
+	c := newCaser(lang)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r == 'ß' {
+			b.WriteString("SS")
+			continue
+		}
+		b.WriteRune(c.upper(r))
+	}
+	return b.String()
+}
+
+// Lower returns s with all letters mapped to their lower case within the language,
+// e.g. "I" to "ı" in Turkish. A capital sigma ending a word becomes the final sigma "ς".
+func Lower(lang Language, s string) string {
+	c := newCaser(lang)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	var previous rune
+	for i, r := range s {
+		if r == 'Σ' && unicode.IsLetter(previous) {
+			next, _ := utf8.DecodeRuneInString(s[i+len("Σ"):])
+			if !unicode.IsLetter(next) {
+				b.WriteRune('ς')
+				previous = r
+				continue
+			}
+		}
+		b.WriteRune(c.lower(r))
+		previous = r
+	}
+	return b.String()
+}
+
+// Title returns s with the first letter of every word mapped to its title case within the
+// language, leaving the remaining letters unchanged. Unlike strings.Title, letters following
+// an apostrophe do not start a word, e.g. "l'été" becomes "L'été", the Turkish "istanbul"
+// becomes "İstanbul" and the Dutch "ijsland" becomes "IJsland".
+func Title(lang Language, s string) string {
+	c := newCaser(lang)
+
+	var b strings.Builder
+	b.Grow(len(s))
+	var previous rune
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if unicode.IsLetter(r) && startsWord(previous) {
+			size = c.capitalize(&b, s[i:])
+		} else {
+			b.WriteString(s[i : i+size])
+		}
+		previous, _ = utf8.DecodeLastRuneInString(s[i : i+size])
+		i += size
+	}
+	return b.String()
+}
+
+// Sentence returns s in sentence case within the language, mapping the first letter to
+// its title case and all other letters to their lower case, e.g. "IJSSEL RIVER" becomes
+// "IJssel river" in Dutch.
+func Sentence(lang Language, s string) string {
+	s = Lower(lang, s)
+
+	i := strings.IndexFunc(s, unicode.IsLetter)
+	if i == -1 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	b.WriteString(s[:i])
+	i += newCaser(lang).capitalize(&b, s[i:])
+	b.WriteString(s[i:])
+	return b.String()
+}
+
+// startsWord reports whether a letter following the rune starts a word
+func startsWord(previous rune) bool {
+	switch {
+	case previous == 0:
+		return true
+	case previous == '\'' || previous == '’':
+		return false
+	case unicode.IsLetter(previous), unicode.IsMark(previous), unicode.IsDigit(previous):
+		return false
+	}
+	return true
+}
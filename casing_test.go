@@ -0,0 +1,30 @@
+package i18n
+
+import "testing"
+
+func TestCasing(t *testing.T) {
+	fn := func(casing func(Language, string) string, lang Language, s string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := casing(lang, s); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("upper", fn(Upper, "en", "straße", "STRASSE"))
+	t.Run("upper turkish", fn(Upper, "tr", "istanbul", "İSTANBUL"))
+	t.Run("upper azerbaijani", fn(Upper, "az-latn", "bakı şəhəri", "BAKI ŞƏHƏRİ"))
+	t.Run("lower", fn(Lower, "en", "ISTANBUL", "istanbul"))
+	t.Run("lower turkish", fn(Lower, "tr", "ISPARTA İZMİR", "ısparta izmir"))
+	t.Run("lower final sigma", fn(Lower, "el", "ΟΔΥΣΣΕΥΣ ΣΑΣ", "οδυσσευς σας"))
+	t.Run("title", fn(Title, "en", "the lord of the rings", "The Lord Of The Rings"))
+	t.Run("title apostrophe", fn(Title, "fr", "l'été d'azur", "L'été D'azur"))
+	t.Run("title turkish", fn(Title, "tr", "iyi günler", "İyi Günler"))
+	t.Run("title dutch", fn(Title, "nl", "het ijsselmeer", "Het IJsselmeer"))
+	t.Run("title digraph", fn(Title, "hr", "ǆungla", "ǅungla"))
+	t.Run("title retains", fn(Title, "en", "USA today", "USA Today"))
+	t.Run("sentence", fn(Sentence, "en", "HELLO WORLD", "Hello world"))
+	t.Run("sentence dutch", fn(Sentence, "nl-be", "IJSSEL RIVIER", "IJssel rivier"))
+	t.Run("sentence punctuation", fn(Sentence, "es", "¿QUÉ TAL?", "¿Qué tal?"))
+	t.Run("empty", fn(Sentence, "en", "", ""))
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"html/template"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Catalog holds the current translations of an application, allowing to swap them at
+// runtime, e.g. for updating translations without a deploy. Translating through the
+// catalog is safe for concurrent use while the translations are replaced.
+type Catalog struct {
+	current atomic.Value
+
+	// mu serializes updates of the translations
+	mu sync.Mutex
+
+	// source provides the translations upon refreshing
+	source Source
+
+	// stop and done signal the refresher to stop and the refresher having stopped
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+
+	// health tracks the outcome of loading the translations
+	health catalogHealth
+}
+
+// NewCatalog creates a catalog holding the loaded translations. The catalog refreshes
+// the translations in the background if a refresh interval is configured, until closed.
+// See WithRefreshInterval.
+func NewCatalog(trl Translations) *Catalog {
+	c := &Catalog{source: trl.refreshSource}
+	c.current.Store(trl)
+	c.health.record(nil, time.Now())
+
+	if trl.refreshInterval > 0 {
+		c.stop = make(chan struct{})
+		c.done = make(chan struct{})
+		go c.refresh(trl.refreshInterval)
+	}
+	return c
+}
+
+// Translations returns the current translations
+func (c *Catalog) Translations() Translations {
+	return c.current.Load().(Translations)
+}
+
+// Swap replaces the current translations
+func (c *Catalog) Swap(trl Translations) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.current.Store(trl)
+	c.health.record(nil, time.Now())
+}
+
+// update replaces the current translations by the result of load unless it fails
+func (c *Catalog) update(load func(current Translations) (Translations, error)) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	trl, err := load(c.Translations())
+	if err != nil {
+		return err
+	}
+	c.current.Store(trl)
+	return nil
+}
+
+// GenerateTranslate returns a translate function for a specific language
+// always translating with the current translations
+func (c *Catalog) GenerateTranslate(targetLang string) func(k string, params ...interface{}) (template.HTML, error) {
+	return func(k string, params ...interface{}) (template.HTML, error) {
+		return c.Translations().GenerateTranslate(targetLang)(k, params...)
+	}
+}
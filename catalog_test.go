@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalog(t *testing.T) {
+	load := func(message string) Translations {
+		fsys := fstest.MapFS{
+			"en.json": &fstest.MapFile{Data: []byte(`{"a": "` + message + `"}`)},
+		}
+		translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return translations
+	}
+
+	catalog := NewCatalog(load("hello"))
+	translate := catalog.GenerateTranslate("en")
+	if got, err := translate("a"); err != nil || got != "hello" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+
+	updated := load("hi")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got, err := translate("a"); err != nil || (got != "hello" && got != "hi") {
+				t.Errorf("unexpected translation %q: %v", got, err)
+			}
+		}()
+	}
+	catalog.Swap(updated)
+	wg.Wait()
+
+	if got, err := translate("a"); err != nil || got != "hi" {
+		t.Fatalf("unexpected translation after swap %q: %v", got, err)
+	}
+
+	err := catalog.update(func(Translations) (Translations, error) { return Translations{}, errors.New("failed") })
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if got, err := translate("a"); err != nil || got != "hi" {
+		t.Fatalf("expected translations to be kept on failure, got %q: %v", got, err)
+	}
+}
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// HourCycle is the cycle of hours times are formatted in
+type HourCycle int
+
+const (
+	// Hour24 counts hours from 0 to 23, e.g. "15:04"
+	Hour24 HourCycle = iota
+	// Hour12 counts hours from 1 to 12 followed by the period of the day, e.g. "3:04 PM"
+	Hour12
+)
+
+// hour12Regions prefer the 12 hour cycle following CLDR
+var hour12Regions = map[string]bool{
+	"ae": true, "au": true, "bd": true, "bh": true, "ca": true, "co": true, "dz": true, "eg": true,
+	"hk": true, "in": true, "iq": true, "jo": true, "kr": true, "kw": true, "ly": true, "my": true,
+	"nz": true, "om": true, "ph": true, "pk": true, "qa": true, "sa": true, "sd": true, "sy": true,
+	"tw": true, "us": true, "ye": true,
+}
+
+// preferredHourCycles are the hour cycles of languages deviating from their region
+var preferredHourCycles = map[Language]HourCycle{
+	"fr-ca": Hour24,
+}
+
+// PreferredHourCycle returns the hour cycle preferred in the region of the language, e.g.
+// Hour12 for "en-us" and Hour24 for "de" or "en-gb". Regions are assumed like FirstDayOfWeek
+// does. Languages without region follow the cycle of their time patterns.
+func PreferredHourCycle(lang Language) HourCycle {
+	for _, candidate := range languageCandidates(lang) {
+		if cycle, ok := preferredHourCycles[candidate]; ok {
+			return cycle
+		}
+	}
+
+	switch r := region(lang); {
+	case hour12Regions[r]:
+		return Hour12
+	case r != "":
+		return Hour24
+	case strings.Contains(dateTimePatternsOf(lang).time[1], "{h}"):
+		return Hour12
+	}
+	return Hour24
+}
+
+// dayPeriod holds the names of the periods of 12 hour clocks of a language
+type dayPeriod struct {
+	am string
+	pm string
+	// prefix is put before the hour if the period precedes the time, e.g. "{a} "
+	prefix string
+}
+
+// of returns the period of the time
+func (p dayPeriod) of(t time.Time) string {
+	if t.Hour() < 12 {
+		return p.am
+	}
+	return p.pm
+}
+
+// defaultDayPeriod are the periods of languages not listed within dayPeriods
+var defaultDayPeriod = dayPeriod{am: "AM", pm: "PM"}
+
+// dayPeriods lists the periods following CLDR
+var dayPeriods = map[Language]dayPeriod{
+	"ar": {am: "ص", pm: "م"},
+	"es": {am: "a.\u00a0m.", pm: "p.\u00a0m."},
+	"ja": {am: "午前", pm: "午後", prefix: "{a}"},
+	"ko": {am: "오전", pm: "오후", prefix: "{a} "},
+	"nl": {am: "a.m.", pm: "p.m."},
+	"zh": {am: "上午", pm: "下午", prefix: "{a}"},
+}
+
+// dayPeriodOf returns the day periods of the language
+func dayPeriodOf(lang Language) dayPeriod {
+	if period, ok := dayPeriods[lang.Base()]; ok {
+		return period
+	}
+	return defaultDayPeriod
+}
+
+// timePattern returns the time pattern of the language converted into the hour cycle
+func timePattern(lang Language, style Style, cycle HourCycle) string {
+	return convertHourCycle(lang, dateTimePatternsOf(lang).time[styleIndex(style)], cycle)
+}
+
+// convertHourCycle converts the time pattern of the language into the hour cycle
+func convertHourCycle(lang Language, pattern string, cycle HourCycle) string {
+	twelve := strings.Contains(pattern, "{h}")
+
+	switch {
+	case cycle == Hour24 && twelve:
+		return strings.NewReplacer(" {a}", "", "{a} ", "", "{a}", "", "{h}", "{HH}").Replace(pattern)
+
+	case cycle == Hour12 && !twelve:
+		pattern = strings.NewReplacer("{HH}", "{h}", "{H}", "{h}").Replace(pattern)
+		if prefix := dayPeriodOf(lang).prefix; prefix != "" {
+			return strings.Replace(pattern, "{h}", prefix+"{h}", 1)
+		}
+		last := "{mm}"
+		if strings.Contains(pattern, "{ss}") {
+			last = "{ss}"
+		}
+		return strings.Replace(pattern, last, last+" {a}", 1)
+	}
+	return pattern
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestPreferredHourCycle(t *testing.T) {
+	fn := func(lang Language, expected HourCycle) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := PreferredHourCycle(lang); got != expected {
+				t.Fatalf("expected %d, got %d", expected, got)
+			}
+		}
+	}
+
+	t.Run("united states", fn("en-us", Hour12))
+	t.Run("likely region", fn("en", Hour12))
+	t.Run("united kingdom", fn("en-gb", Hour24))
+	t.Run("german", fn("de", Hour24))
+	t.Run("canadian english", fn("en-ca", Hour12))
+	t.Run("canadian french", fn("fr-ca", Hour24))
+	t.Run("korean", fn("ko", Hour12))
+	t.Run("unknown", fn("xx", Hour24))
+}
+
+func TestFormatTimeCycle(t *testing.T) {
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	fn := func(lang Language, style Style, cycle HourCycle, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FormatTimeCycle(lang, ts, style, cycle); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english 12", fn("en", Short, Hour12, "3:04 PM"))
+	t.Run("english 24", fn("en", Short, Hour24, "15:04"))
+	t.Run("english wide 24", fn("en", Wide, Hour24, "15:04:05 CET"))
+	t.Run("german 12", fn("de", Short, Hour12, "3:04 PM"))
+	t.Run("german wide 12", fn("de", Wide, Hour12, "3:04:05 PM CET"))
+	t.Run("spanish 12", fn("es", Short, Hour12, "3:04 p.\u00a0m."))
+	t.Run("japanese 12", fn("ja", Short, Hour12, "午後3:04"))
+	t.Run("korean", fn("ko", Short, Hour12, "오후 3:04"))
+
+	if got := FormatTime("en-us", ts, Short); got != "3:04 PM" {
+		t.Fatalf("expected preferred cycle of en-us, got %q", got)
+	}
+	if got := FormatTime("de", ts, Short); got != "15:04" {
+		t.Fatalf("expected preferred cycle of de, got %q", got)
+	}
+}
+
+func TestHourCycleFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"time": "Starts {{ts, time(hours=24)}}",
+			"datetime": "Starts {{ts, datetime(hours=24)}}"
+		}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"time": "Beginn {{ts, time(hours=12)}}"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.UTC)
+
+	fn := func(lang, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "ts", ts)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("english time", fn("en", "time", "Starts 15:04"))
+	t.Run("english datetime", fn("en", "datetime", "Starts 3/4/21, 15:04"))
+	t.Run("german time", fn("de", "time", "Beginn 3:04 PM"))
+
+	issues := Validate(fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "{{ts, date(hours=12)}}"}`)}}, "en")
+	if len(issues) != 1 {
+		t.Fatalf("expected issue for hour cycle of date format, got %v", issues)
+	}
+}
@@ -0,0 +1,247 @@
+package i18n
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// diacritics lists the letters with diacritics by the letter they are based on. Letters
+// are primarily sorted by their base letter and secondarily by their position within the list.
+var diacritics = map[rune]string{
+	'a': "áàâǎăãåäāąạ",
+	'c': "ćĉčċç",
+	'd': "ďđ",
+	'e': "éèêěĕẽėëēęẹ",
+	'g': "ğĝġģ",
+	'h': "ĥħ",
+	'i': "íìîǐĭĩïıīįị",
+	'j': "ĵ",
+	'k': "ķ",
+	'l': "ĺľļłŀ",
+	'n': "ńňñņ",
+	'o': "óòôǒŏõöőøōọ",
+	'r': "ŕřŗ",
+	's': "śŝšşș",
+	't': "ťţțŧ",
+	'u': "úùûǔŭũůüűūųụ",
+	'w': "ŵ",
+	'y': "ýŷÿ",
+	'z': "źžż",
+	'α': "ά",
+	'ε': "έ",
+	'η': "ή",
+	'ι': "ίϊΐ",
+	'ο': "ό",
+	'υ': "ύϋΰ",
+	'ω': "ώ",
+	'е': "ё",
+	'и': "й",
+}
+
+// expansions are the letters primarily sorted like a sequence of letters
+var expansions = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ĳ': "ij",
+	'ς': "σ",
+}
+
+// folded maps the letters with diacritics to their base letter and secondary weight
+var folded = func() map[rune]foldedLetter {
+	folded := make(map[rune]foldedLetter)
+	for base, letters := range diacritics {
+		secondary := uint32(1)
+		for _, letter := range letters {
+			folded[letter] = foldedLetter{base: base, secondary: secondary}
+			secondary++
+		}
+	}
+	return folded
+}()
+
+// foldedLetter is a letter with diacritics
+type foldedLetter struct {
+	base      rune
+	secondary uint32
+}
+
+// tailorings lists the letters or contractions of a language sorted as separate letters
+// following the given letter rather than as variants of their base letter
+var tailorings = map[Language]map[rune][]string{
+	"cs": {'c': {"č"}, 'h': {"ch"}, 'r': {"ř"}, 's': {"š"}, 'z': {"ž"}},
+	"da": {'z': {"æ", "ø", "å"}},
+	"es": {'n': {"ñ"}},
+	"fi": {'z': {"å", "ä", "ö"}},
+	"nb": {'z': {"æ", "ø", "å"}},
+	"nn": {'z': {"æ", "ø", "å"}},
+	"pl": {'a': {"ą"}, 'c': {"ć"}, 'e': {"ę"}, 'l': {"ł"}, 'n': {"ń"}, 'o': {"ó"}, 's': {"ś"}, 'z': {"ź", "ż"}},
+	"sk": {'a': {"ä"}, 'c': {"č"}, 'h': {"ch"}, 'o': {"ô"}, 'r': {"ř"}, 's': {"š"}, 'z': {"ž"}},
+	"sv": {'z': {"å", "ä", "ö"}},
+	"tr": {'c': {"ç"}, 'g': {"ğ"}, 'h': {"ı"}, 'o': {"ö"}, 's': {"ş"}, 'u': {"ü"}},
+}
+
+// Collator compares strings following the collation rules of a language, e.g. sorting
+// "ä" like "a" in German but after "z" in Swedish. Letters are compared ignoring their
+// diacritics and case first, followed by their diacritics and finally their case. Scripts
+// without rules, e.g. CJK, are sorted by code point.
+type Collator struct {
+	caser caser
+	// tailored maps the tailored letters and contractions to their primary weight
+	tailored map[string]uint32
+	// contractions reports whether any tailored letter consists of multiple letters
+	contractions bool
+}
+
+// NewCollator returns the collator of the language
+func NewCollator(lang Language) Collator {
+	c := Collator{caser: newCaser(lang)}
+
+	tailoring, ok := tailorings[lang.Base()]
+	if !ok {
+		return c
+	}
+
+	c.tailored = make(map[string]uint32)
+	for letter, following := range tailoring {
+		for i, tailored := range following {
+			c.tailored[tailored] = primaryWeight(letter) + uint32(i) + 1
+			c.contractions = c.contractions || utf8.RuneCountInString(tailored) > 1
+		}
+	}
+	return c
+}
+
+// primaryWeight returns the primary weight of a letter, leaving room for tailored
+// letters sorted between the letter and its successor
+func primaryWeight(r rune) uint32 {
+	return uint32(r) << 2
+}
+
+// collationKey holds the weights of a string per comparison level
+type collationKey struct {
+	primary   []uint32
+	secondary []uint32
+	tertiary  []uint32
+}
+
+// key computes the collation key of s
+func (c Collator) key(s string) collationKey {
+	var k collationKey
+	lower := make([]rune, 0, len(s))
+	for _, r := range s {
+		l := c.caser.lower(r)
+		lower = append(lower, l)
+
+		upper := uint32(0)
+		if l != r {
+			upper = 1
+		}
+		k.tertiary = append(k.tertiary, upper)
+	}
+
+	for i := 0; i < len(lower); i++ {
+		if c.tailored != nil {
+			if c.contractions && i+1 < len(lower) {
+				if weight, ok := c.tailored[string(lower[i:i+2])]; ok {
+					k.primary = append(k.primary, weight)
+					k.secondary = append(k.secondary, 0)
+					i++
+					continue
+				}
+			}
+			if weight, ok := c.tailored[string(lower[i])]; ok {
+				k.primary = append(k.primary, weight)
+				k.secondary = append(k.secondary, 0)
+				continue
+			}
+		}
+
+		r := lower[i]
+		if expansion, ok := expansions[r]; ok {
+			for _, e := range expansion {
+				k.primary = append(k.primary, primaryWeight(e))
+				k.secondary = append(k.secondary, 1)
+			}
+			continue
+		}
+
+		secondary := uint32(0)
+		if f, ok := folded[r]; ok {
+			r, secondary = f.base, f.secondary
+		}
+		k.primary = append(k.primary, primaryWeight(r))
+		k.secondary = append(k.secondary, secondary)
+	}
+	return k
+}
+
+// compare compares the collation keys level by level
+func (k collationKey) compare(other collationKey) int {
+	if n := compareWeights(k.primary, other.primary); n != 0 {
+		return n
+	}
+	if n := compareWeights(k.secondary, other.secondary); n != 0 {
+		return n
+	}
+	return compareWeights(k.tertiary, other.tertiary)
+}
+
+// compareWeights compares the weights lexicographically
+func compareWeights(a, b []uint32) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+// Compare returns a negative number if a sorts before b, a positive number
+// if a sorts after b and zero if both strings are equal
+func (c Collator) Compare(a, b string) int {
+	if n := c.key(a).compare(c.key(b)); n != 0 {
+		return n
+	}
+	return strings.Compare(a, b)
+}
+
+// collated sorts strings by precomputed collation keys
+type collated struct {
+	strings []string
+	keys    []collationKey
+}
+
+// Collated returns a sort.Interface sorting s following the collation rules of the language
+func Collated(lang Language, s []string) sort.Interface {
+	c := NewCollator(lang)
+	keys := make([]collationKey, len(s))
+	for i := range s {
+		keys[i] = c.key(s[i])
+	}
+	return collated{strings: s, keys: keys}
+}
+
+func (c collated) Len() int { return len(c.strings) }
+
+func (c collated) Less(i, j int) bool {
+	if n := c.keys[i].compare(c.keys[j]); n != 0 {
+		return n < 0
+	}
+	return c.strings[i] < c.strings[j]
+}
+
+func (c collated) Swap(i, j int) {
+	c.strings[i], c.strings[j] = c.strings[j], c.strings[i]
+	c.keys[i], c.keys[j] = c.keys[j], c.keys[i]
+}
+
+// SortStrings sorts s in place following the collation rules of the language,
+// e.g. for ordering translated country names
+func SortStrings(lang Language, s []string) {
+	sort.Sort(Collated(lang, s))
+}
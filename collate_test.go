@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSortStrings(t *testing.T) {
+	fn := func(lang Language, s []string, expected []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			SortStrings(lang, s)
+			if !reflect.DeepEqual(s, expected) {
+				t.Fatalf("expected %q, got %q", expected, s)
+			}
+		}
+	}
+
+	t.Run("german", fn("de", []string{"Zypern", "Österreich", "Oman", "Ägypten", "Albanien"},
+		[]string{"Ägypten", "Albanien", "Oman", "Österreich", "Zypern"}))
+	t.Run("swedish", fn("sv", []string{"Österrike", "Oman", "Zypern", "Ägypten", "Albanien"},
+		[]string{"Albanien", "Oman", "Zypern", "Ägypten", "Österrike"}))
+	t.Run("danish", fn("da", []string{"Åland", "Østrig", "Zambia", "Ægypten"},
+		[]string{"Zambia", "Ægypten", "Østrig", "Åland"}))
+	t.Run("spanish", fn("es", []string{"Ñandú", "Nutria", "Oso"},
+		[]string{"Nutria", "Ñandú", "Oso"}))
+	t.Run("czech contraction", fn("cs", []string{"Chorvatsko", "Itálie", "Honduras", "Česko", "Dánsko"},
+		[]string{"Česko", "Dánsko", "Honduras", "Chorvatsko", "Itálie"}))
+	t.Run("turkish", fn("tr", []string{"İzmir", "Iğdır", "Hatay"},
+		[]string{"Hatay", "Iğdır", "İzmir"}))
+	t.Run("accents", fn("fr", []string{"pêche", "péché", "pèche", "peche"},
+		[]string{"peche", "péché", "pèche", "pêche"}))
+	t.Run("case", fn("en", []string{"Apple", "apple", "banana", "Banana"},
+		[]string{"apple", "Apple", "banana", "Banana"}))
+	t.Run("expansion", fn("de", []string{"Strasse", "Straße", "Strasze"},
+		[]string{"Strasse", "Straße", "Strasze"}))
+	t.Run("cjk", fn("ja", []string{"東京", "大阪", "京都"},
+		[]string{"京都", "大阪", "東京"}))
+}
+
+func TestCollator(t *testing.T) {
+	c := NewCollator("de")
+	if c.Compare("Äpfel", "Apfel") <= 0 || c.Compare("Apfel", "Birne") >= 0 || c.Compare("a", "a") != 0 {
+		t.Fatal("unexpected comparison")
+	}
+
+	s := []string{"b", "ä", "a"}
+	sort.Sort(Collated("de", s))
+	if !reflect.DeepEqual(s, []string{"a", "ä", "b"}) {
+		t.Fatalf("unexpected order %q", s)
+	}
+}
@@ -0,0 +1,248 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// configOptions are the options of configuration files by their name
+var configOptions = map[string]func(dir string, value yamlValue) (Option, error){
+	"directory": func(dir string, value yamlValue) (Option, error) {
+		directory, err := configScalar(value)
+		if err == nil && !filepath.IsAbs(directory) {
+			directory = filepath.Join(dir, directory)
+		}
+		return WithDirectory(directory), err
+	},
+	"defaultLanguage": func(dir string, value yamlValue) (Option, error) {
+		lang, err := configScalar(value)
+		return WithDefaultLanguage(lang), err
+	},
+	"fallbackChain": func(dir string, value yamlValue) (Option, error) {
+		languages, err := configSequence(value)
+		return WithFallbackChain(languages...), err
+	},
+	"fallbacks": func(dir string, value yamlValue) (Option, error) {
+		if value.kind != yamlMapping {
+			return nil, errors.New("must map languages to their fallbacks")
+		}
+		var options []Option
+		for _, entry := range value.entries {
+			fallbacks, err := configSequence(entry.value)
+			if err != nil {
+				return nil, fmt.Errorf("of %q %v", entry.key, err)
+			}
+			options = append(options, WithFallbacks(entry.key, fallbacks...))
+		}
+		return combinedOption(options), nil
+	},
+	"languageAliases": func(dir string, value yamlValue) (Option, error) {
+		aliases, err := configMapping(value)
+		return WithLanguageAliases(aliases), err
+	},
+	"threeLetterCodes": configFlag(WithThreeLetterCodes),
+	"prerender":        configFlag(WithPrerender),
+	"printfMessages":   configFlag(WithPrintfMessages),
+	"cache": func(dir string, value yamlValue) (Option, error) {
+		size, err := configInt(value)
+		return WithCache(int(size)), err
+	},
+	"tenantDirectory": func(dir string, value yamlValue) (Option, error) {
+		tenants, err := configScalar(value)
+		return WithTenantDirectory(tenants), err
+	},
+	"manifest": func(dir string, value yamlValue) (Option, error) {
+		manifest, err := configScalar(value)
+		return WithManifest(manifest), err
+	},
+	"refreshInterval": func(dir string, value yamlValue) (Option, error) {
+		interval, err := configDuration(value)
+		return WithRefreshInterval(interval), err
+	},
+	"maxStaleness": func(dir string, value yamlValue) (Option, error) {
+		age, err := configDuration(value)
+		return WithMaxStaleness(age), err
+	},
+	"keyStats": func(dir string, value yamlValue) (Option, error) {
+		s, err := configScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		sampling, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("must be a fraction, got %q", s)
+		}
+		return WithKeyStats(sampling), nil
+	},
+	"limits": func(dir string, value yamlValue) (Option, error) {
+		if value.kind != yamlMapping {
+			return nil, errors.New("must be a mapping")
+		}
+		var limits Limits
+		for _, entry := range value.entries {
+			n, err := configInt(entry.value)
+			if err != nil {
+				return nil, fmt.Errorf("limit %q %v", entry.key, err)
+			}
+			switch entry.key {
+			case "maxFileSize":
+				limits.MaxFileSize = n
+			case "maxDepth":
+				limits.MaxDepth = int(n)
+			case "maxKeyLength":
+				limits.MaxKeyLength = int(n)
+			case "maxIntermediates":
+				limits.MaxIntermediates = int(n)
+			default:
+				return nil, fmt.Errorf("unknown limit %q", entry.key)
+			}
+		}
+		return WithLimits(limits), nil
+	},
+}
+
+// LoadConfig loads the translations configured by the YAML file of the name, e.g. "i18n.yaml",
+// such that services configure the translations uniformly:
+//
+//	directory: translations
+//	defaultLanguage: en
+//	fallbackChain: [en]
+//	fallbacks:
+//	  de-at: [de]
+//	cache: 1000
+//	refreshInterval: 5m
+//	limits:
+//	  maxFileSize: 1048576
+//
+// Options are named as the functions setting them without "With", durations are formatted
+// as by time.ParseDuration and the directory is relative to the file. The options passed,
+// e.g. hooks not expressible in YAML, are applied after the configured ones.
+func LoadConfig(name string, options ...Option) (Translations, error) {
+	configured, err := readConfig(name)
+	if err != nil {
+		return Translations{}, err
+	}
+	return New(append(configured, options...)...).Load()
+}
+
+// readConfig reads the options of the configuration file
+func readConfig(name string) ([]Option, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %v", err)
+	}
+
+	p := yamlParser{lines: strings.Split(string(data), "\n")}
+	entries, err := p.mapping(0)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config %q: %v", name, err)
+	}
+
+	// the directory defaults to the one of the file
+	options := []Option{WithDirectory(filepath.Dir(name))}
+	for _, entry := range entries {
+		option, ok := configOptions[entry.key]
+		if !ok {
+			return nil, fmt.Errorf("invalid config %q: unknown option %q", name, entry.key)
+		}
+		o, err := option(filepath.Dir(name), entry.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config %q: option %q %v", name, entry.key, err)
+		}
+		options = append(options, o)
+	}
+	return options, nil
+}
+
+// combinedOption applies the options in order
+func combinedOption(options []Option) Option {
+	return func(trl *Translations) {
+		for _, option := range options {
+			option(trl)
+		}
+	}
+}
+
+// configFlag adapts an option without arguments enabled by the boolean value
+func configFlag(option func() Option) func(dir string, value yamlValue) (Option, error) {
+	return func(dir string, value yamlValue) (Option, error) {
+		s, err := configScalar(value)
+		if err != nil {
+			return nil, err
+		}
+		enabled, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, fmt.Errorf("must be true or false, got %q", s)
+		}
+		if !enabled {
+			return func(*Translations) {}, nil
+		}
+		return option(), nil
+	}
+}
+
+// configScalar returns the scalar of the value
+func configScalar(value yamlValue) (string, error) {
+	if value.kind != yamlScalar {
+		return "", errors.New("must be a scalar")
+	}
+	return value.scalar, nil
+}
+
+// configInt returns the integer of the value
+func configInt(value yamlValue) (int64, error) {
+	s, err := configScalar(value)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("must be an integer, got %q", s)
+	}
+	return n, nil
+}
+
+// configDuration returns the duration of the value, e.g. "5m"
+func configDuration(value yamlValue) (time.Duration, error) {
+	s, err := configScalar(value)
+	if err != nil {
+		return 0, err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("must be a duration, got %q", s)
+	}
+	return d, nil
+}
+
+// configSequence returns the items of the sequence, a scalar being a sequence of one item
+func configSequence(value yamlValue) ([]string, error) {
+	switch value.kind {
+	case yamlSequence:
+		return value.items, nil
+	case yamlScalar:
+		return []string{value.scalar}, nil
+	}
+	return nil, errors.New("must be a sequence")
+}
+
+// configMapping returns the scalars of the mapping by their keys
+func configMapping(value yamlValue) (map[string]string, error) {
+	if value.kind != yamlMapping {
+		return nil, errors.New("must be a mapping")
+	}
+	mapping := make(map[string]string, len(value.entries))
+	for _, entry := range value.entries {
+		s, err := configScalar(entry.value)
+		if err != nil {
+			return nil, fmt.Errorf("%q %v", entry.key, err)
+		}
+		mapping[entry.key] = s
+	}
+	return mapping, nil
+}
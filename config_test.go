@@ -0,0 +1,124 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"i18n.yaml": `# translations of the service
+directory: translations
+defaultLanguage: en
+fallbackChain: [en]
+fallbacks:
+  de-at:
+    - de
+languageAliases:
+  iw: he
+cache: 100
+prerender: true
+refreshInterval: 5m
+maxStaleness: 1h
+limits:
+  maxDepth: 4
+`,
+		"translations/en.json": `{"a": "hello", "b": "bye"}`,
+		"translations/de.json": `{"a": "hallo"}`,
+		"translations/he.json": `{"a": "shalom"}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	translations, err := LoadConfig(filepath.Join(dir, "i18n.yaml"), WithKeyStats(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if translations.defaultLanguage != "en" || translations.cacheSize != 100 || !translations.prerender ||
+		translations.refreshInterval != 5*time.Minute || translations.maxStaleness != time.Hour ||
+		translations.limits.MaxDepth != 4 || translations.keyCounter == nil {
+		t.Fatalf("unexpected configuration %+v", translations)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("fallbacks", fn("de-at", "a", "hallo"))
+	t.Run("fallback chain", fn("de", "b", "bye"))
+	t.Run("alias", fn("iw", "a", "shalom"))
+}
+
+func TestReadConfig(t *testing.T) {
+	fn := func(config string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "i18n.yaml")
+			if err := os.WriteFile(name, []byte(config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := readConfig(name)
+			if expected == "" && err != nil {
+				t.Fatal(err)
+			}
+			if expected != "" && (err == nil || !strings.Contains(err.Error(), expected)) {
+				t.Fatalf("expected error %q, got %v", expected, err)
+			}
+		}
+	}
+
+	t.Run("valid", fn("defaultLanguage: de\nthreeLetterCodes: false\nfallbackChain:\n  - en\n  - 'de'\n", ""))
+	t.Run("unknown option", fn("detectors: [header]\n", `unknown option "detectors"`))
+	t.Run("invalid integer", fn("cache: many\n", `option "cache" must be an integer, got "many"`))
+	t.Run("invalid duration", fn("refreshInterval: often\n", `option "refreshInterval" must be a duration`))
+	t.Run("invalid flag", fn("prerender: sometimes\n", `option "prerender" must be true or false`))
+	t.Run("invalid fallbacks", fn("fallbacks:\n  de:\n    x: y\n", `option "fallbacks" of "de" must be a sequence`))
+	t.Run("unknown limit", fn("limits:\n  maxFiles: 3\n", `unknown limit "maxFiles"`))
+	t.Run("invalid yaml", fn("fallbacks: {de: en}\n", "flow mappings are not supported"))
+
+	if _, err := readConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Fatal("expected error for missing config")
+	}
+}
+
+func TestYAMLSequences(t *testing.T) {
+	fn := func(document string, expected []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			p := yamlParser{lines: strings.Split(document, "\n")}
+			entries, err := p.mapping(0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := entries[0].value.items
+			if strings.Join(got, "|") != strings.Join(expected, "|") || len(got) != len(expected) {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("flow", fn(`a: [en, "de, at", 'fr']`, []string{"en", "de, at", "fr"}))
+	t.Run("empty flow", fn(`a: []`, nil))
+	t.Run("block", fn("a:\n  - en # first\n  - \"de\"\nb: c", []string{"en", "de"}))
+	t.Run("unindented block", fn("a:\n- en\n- de\nb: c", []string{"en", "de"}))
+	t.Run("nested items skipped", fn("a:\n  - name: x\n    y: z\n  - en\n  - - nested\n", []string{"en"}))
+}
@@ -0,0 +1,127 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// ConstantsKey is the key of the top-level member of a language file declaring constants
+	// referenced by messages, e.g. "$constants": {"productName": "Acme"}
+	ConstantsKey = "$constants"
+	// ConstantPrefix and ConstantSuffix enclose the name of a constant referenced
+	// within a message, e.g. "Welcome to @{productName}"
+	ConstantPrefix = "@{"
+	ConstantSuffix = "}"
+)
+
+// declareConstants adds constants declared by the file, reporting invalid names
+func (d *decoder) declareConstants(constants map[string]string) {
+	for name, value := range constants {
+		if name == "" || strings.Contains(name, ConstantSuffix) {
+			d.report("", fmt.Sprintf("invalid constant name %q", name))
+			continue
+		}
+		d.constants[name] = value
+	}
+}
+
+// mergeConstants adds the constants of a file to those of lang.
+// Multiple files of the same language must not declare the same constant.
+func (l *loader) mergeConstants(filePath string, lang Language, constants map[string]string) {
+	if len(constants) == 0 {
+		return
+	}
+	if l.constants[lang] == nil {
+		l.constants[lang] = make(map[string]string, len(constants))
+	}
+
+	for _, name := range sortedNames(constants) {
+		if _, ok := l.constants[lang][name]; ok {
+			l.report(filePath, lang, "", fmt.Sprintf("duplicate constant %q", name))
+			continue
+		}
+		l.constants[lang][name] = constants[name]
+	}
+}
+
+// resolveConstants replaces the constants referenced by the messages of every language.
+// Constants are looked up in the language and the languages it falls back to, ending with
+// the default language, preferring those loaded over the fallback constants at each step.
+// Messages referencing unknown constants are reported.
+func (l *loader) resolveConstants(fallback map[Language]map[string]string) {
+	for _, lang := range sortedLanguages(l.translations) {
+		candidates := append(languageCandidates(lang), l.trl.defaultLanguage)
+		lookup := func(name string) (string, bool) {
+			for _, candidate := range candidates {
+				for _, constants := range []map[Language]map[string]string{l.constants, fallback} {
+					if value, ok := constants[candidate][name]; ok {
+						return value, true
+					}
+				}
+			}
+			return "", false
+		}
+
+		store := l.translations[lang]
+		for _, key := range sortedKeys(store) {
+			if !strings.Contains(store[key].Message, ConstantPrefix) {
+				continue
+			}
+
+			message, err := substituteConstants(store[key].Message, lookup)
+			if err != nil {
+				l.report(l.origins[lang][key], lang, key, err.Error())
+				continue
+			}
+			intermediates, segments, err := parseIntermediates(message)
+			if err != nil {
+				l.report(l.origins[lang][key], lang, key, err.Error())
+				continue
+			}
+			store[key] = Translation{
+				Message:       l.interned.intern(message),
+				Intermediates: intermediates,
+				segments:      segments,
+			}
+		}
+	}
+}
+
+// substituteConstants replaces the constants referenced by the message with their values
+func substituteConstants(message string, lookup func(name string) (string, bool)) (string, error) {
+	var b strings.Builder
+	for {
+		i := strings.Index(message, ConstantPrefix)
+		if i == -1 {
+			break
+		}
+		end := strings.Index(message[i:], ConstantSuffix)
+		if end == -1 {
+			break
+		}
+
+		name := message[i+len(ConstantPrefix) : i+end]
+		value, ok := lookup(name)
+		if !ok {
+			return "", fmt.Errorf("unknown constant %q", name)
+		}
+		b.WriteString(message[:i])
+		b.WriteString(value)
+		message = message[i+end+len(ConstantSuffix):]
+	}
+	b.WriteString(message)
+	return b.String(), nil
+}
+
+// sortedNames returns the names of the constants in ascending order
+func sortedNames(constants map[string]string) []string {
+	names := make([]string, 0, len(constants))
+	for name := range constants {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
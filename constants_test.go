@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestConstants(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"$constants": {"productName": "Acme Monitor", "supportEmail": "help@acme.example"},
+			"welcome": "Welcome to @{productName}, {{name}}!",
+			"contact": "Write to @{supportEmail}"
+		}`)},
+		"de.yml":            &fstest.MapFile{Data: []byte("de:\n  $constants:\n    productName: Acme Wächter\n  welcome: Willkommen bei @{productName}, {{name}}!\n  contact: Schreib an @{supportEmail}\n")},
+		"de-at.json":        &fstest.MapFile{Data: []byte(`{"welcome": "Servus bei @{productName}"}`)},
+		"fr.json":           &fstest.MapFile{Data: []byte(`{"$include": "shared/brand.json", "welcome": "Bienvenue sur @{productName}"}`)},
+		"shared/brand.json": &fstest.MapFile{Data: []byte(`{"$constants": {"productName": "Acme Moniteur"}}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "name", "Anna")
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("default language", fn("en", "welcome", "Welcome to Acme Monitor, Anna!"))
+	t.Run("language", fn("de", "welcome", "Willkommen bei Acme Wächter, Anna!"))
+	t.Run("fallback to default language", fn("de", "contact", "Schreib an help@acme.example"))
+	t.Run("regional language", fn("de-at", "welcome", "Servus bei Acme Wächter"))
+	t.Run("included", fn("fr", "welcome", "Bienvenue sur Acme Moniteur"))
+
+	validate := func(files fstest.MapFS, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(files, "en")
+			if len(issues) != 1 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("unknown", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "@{brand}"}`)},
+	}, `unknown constant "brand"`))
+	t.Run("nested", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": {"$constants": {"brand": "Acme"}, "b": "c"}}`)},
+	}, "invalid constants, must be declared at the top level"))
+	t.Run("invalid type", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"$constants": {"brand": 1}, "a": "b"}`)},
+	}, "invalid constants, must be an object of strings"))
+	t.Run("duplicate", validate(fstest.MapFS{
+		"en.json":     &fstest.MapFile{Data: []byte(`{"$constants": {"brand": "Acme"}, "a": "b"}`)},
+		"app/en.json": &fstest.MapFile{Data: []byte(`{"$constants": {"brand": "Acme"}}`)},
+	}, `duplicate constant "brand"`))
+}
@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+)
+
+// languageContextKey is the key of the language within a context
+type languageContextKey struct{}
+
+// NewContext returns a copy of ctx carrying the language
+func NewContext(ctx context.Context, lang Language) context.Context {
+	return context.WithValue(ctx, languageContextKey{}, lang)
+}
+
+// LanguageFromContext returns the language carried by ctx, reporting whether any is set
+func LanguageFromContext(ctx context.Context) (Language, bool) {
+	lang, ok := ctx.Value(languageContextKey{}).(Language)
+	return lang, ok
+}
+
+// contextLanguage returns the language carried by ctx, falling back to the default language
+func (trl Translations) contextLanguage(ctx context.Context) Language {
+	if lang, ok := LanguageFromContext(ctx); ok {
+		return lang
+	}
+	return trl.defaultLanguage
+}
+
+// Middleware stores the language of each request as negotiated by RequestLanguage
+// in the request context, to be retrieved using LanguageFromContext
+func (trl Translations) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(NewContext(r.Context(), trl.RequestLanguage(r))))
+	})
+}
@@ -0,0 +1,31 @@
+package i18n
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestContext(t *testing.T) {
+	if _, ok := LanguageFromContext(context.Background()); ok {
+		t.Fatal("expected no language")
+	}
+	if lang, ok := LanguageFromContext(NewContext(context.Background(), "de")); !ok || lang != "de" {
+		t.Fatalf("unexpected language %q", lang)
+	}
+
+	translations := newErrorTranslations(t)
+
+	var got Language
+	handler := translations.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = LanguageFromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "de-CH, en;q=0.5")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if got != "de" {
+		t.Fatalf("expected %q, got %q", "de", got)
+	}
+}
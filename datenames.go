@@ -0,0 +1,158 @@
+package i18n
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// localizedNames are the names of the months or weekdays of a language per style.
+// Narrow names default to the initials of the wide names.
+type localizedNames struct {
+	wide        []string
+	abbreviated []string
+	narrow      []string
+}
+
+// monthNames are the names of the months per language following CLDR
+var monthNames = map[Language]localizedNames{
+	"de": {
+		wide:        []string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		abbreviated: []string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+	},
+	"en": {
+		wide:        []string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		abbreviated: []string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+	},
+	"es": {
+		wide:        []string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		abbreviated: []string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+	},
+	"fr": {
+		wide:        []string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		abbreviated: []string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+	},
+	"it": {
+		wide:        []string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		abbreviated: []string{"gen", "feb", "mar", "apr", "mag", "giu", "lug", "ago", "set", "ott", "nov", "dic"},
+	},
+	"ja": {
+		wide:        []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		abbreviated: []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		narrow:      []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+	},
+	"nl": {
+		wide:        []string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		abbreviated: []string{"jan", "feb", "mrt", "apr", "mei", "jun", "jul", "aug", "sep", "okt", "nov", "dec"},
+	},
+	"pt": {
+		wide:        []string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		abbreviated: []string{"jan.", "fev.", "mar.", "abr.", "mai.", "jun.", "jul.", "ago.", "set.", "out.", "nov.", "dez."},
+	},
+	"th": {
+		wide:        []string{"มกราคม", "กุมภาพันธ์", "มีนาคม", "เมษายน", "พฤษภาคม", "มิถุนายน", "กรกฎาคม", "สิงหาคม", "กันยายน", "ตุลาคม", "พฤศจิกายน", "ธันวาคม"},
+		abbreviated: []string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."},
+		narrow:      []string{"ม.ค.", "ก.พ.", "มี.ค.", "เม.ย.", "พ.ค.", "มิ.ย.", "ก.ค.", "ส.ค.", "ก.ย.", "ต.ค.", "พ.ย.", "ธ.ค."},
+	},
+	"zh": {
+		wide:        []string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		abbreviated: []string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		narrow:      []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"},
+	},
+}
+
+// weekdayNames are the names of the weekdays starting at Sunday per language following CLDR
+var weekdayNames = map[Language]localizedNames{
+	"de": {
+		wide:        []string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		abbreviated: []string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+	},
+	"en": {
+		wide:        []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		abbreviated: []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	},
+	"es": {
+		wide:        []string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		abbreviated: []string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		narrow:      []string{"D", "L", "M", "X", "J", "V", "S"},
+	},
+	"fr": {
+		wide:        []string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		abbreviated: []string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+	},
+	"it": {
+		wide:        []string{"domenica", "lunedì", "martedì", "mercoledì", "giovedì", "venerdì", "sabato"},
+		abbreviated: []string{"dom", "lun", "mar", "mer", "gio", "ven", "sab"},
+	},
+	"ja": {
+		wide:        []string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		abbreviated: []string{"日", "月", "火", "水", "木", "金", "土"},
+	},
+	"nl": {
+		wide:        []string{"zondag", "maandag", "dinsdag", "woensdag", "donderdag", "vrijdag", "zaterdag"},
+		abbreviated: []string{"zo", "ma", "di", "wo", "do", "vr", "za"},
+	},
+	"pt": {
+		wide:        []string{"domingo", "segunda-feira", "terça-feira", "quarta-feira", "quinta-feira", "sexta-feira", "sábado"},
+		abbreviated: []string{"dom.", "seg.", "ter.", "qua.", "qui.", "sex.", "sáb."},
+	},
+	"th": {
+		wide:        []string{"วันอาทิตย์", "วันจันทร์", "วันอังคาร", "วันพุธ", "วันพฤหัสบดี", "วันศุกร์", "วันเสาร์"},
+		abbreviated: []string{"อา.", "จ.", "อ.", "พ.", "พฤ.", "ศ.", "ส."},
+		narrow:      []string{"อา", "จ", "อ", "พ", "พฤ", "ศ", "ส"},
+	},
+	"zh": {
+		wide:        []string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		abbreviated: []string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		narrow:      []string{"日", "一", "二", "三", "四", "五", "六"},
+	},
+}
+
+// MonthNames returns the names of the months from January to December in the language,
+// e.g. "January", "Jan" or "J" in English. Languages lacking names fall back to English.
+func MonthNames(lang Language, style Style) []string {
+	return namesOf(monthNames, lang).style(style)
+}
+
+// WeekdayNames returns the names of the weekdays in the language starting at Sunday,
+// indexed by time.Weekday, e.g. "Sunday", "Sun" or "S" in English. Languages lacking
+// names fall back to English.
+func WeekdayNames(lang Language, style Style) []string {
+	return namesOf(weekdayNames, lang).style(style)
+}
+
+// namesOf returns the names of the language
+func namesOf(table map[Language]localizedNames, lang Language) localizedNames {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if names, ok := table[candidate]; ok {
+			return names
+		}
+	}
+	return table["en"]
+}
+
+// style returns a copy of the names of the style
+func (names localizedNames) style(style Style) []string {
+	var selected []string
+	switch style {
+	case Wide:
+		selected = names.wide
+	case Short:
+		selected = names.abbreviated
+	default:
+		if names.narrow == nil {
+			return initials(names.wide)
+		}
+		selected = names.narrow
+	}
+	return append([]string(nil), selected...)
+}
+
+// initials returns the upper cased first letters of the names
+func initials(names []string) []string {
+	initials := make([]string, len(names))
+	for i, name := range names {
+		r, _ := utf8.DecodeRuneInString(name)
+		initials[i] = string(unicode.ToUpper(r))
+	}
+	return initials
+}
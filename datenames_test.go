@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMonthNames(t *testing.T) {
+	fn := func(lang Language, style Style, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			names := MonthNames(lang, style)
+			if len(names) != 12 {
+				t.Fatalf("expected 12 months, got %d", len(names))
+			}
+			if got := strings.Join(names, " "); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", Wide, "January February March April May June July August September October November December"))
+	t.Run("german abbreviated", fn("de-at", Short, "Jan. Feb. März Apr. Mai Juni Juli Aug. Sept. Okt. Nov. Dez."))
+	t.Run("italian narrow", fn("it", Narrow, "G F M A M G L A S O N D"))
+	t.Run("japanese narrow", fn("ja", Narrow, "1 2 3 4 5 6 7 8 9 10 11 12"))
+	t.Run("fallback", fn("xx", Short, "Jan Feb Mar Apr May Jun Jul Aug Sep Oct Nov Dec"))
+}
+
+func TestWeekdayNames(t *testing.T) {
+	fn := func(lang Language, style Style, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := strings.Join(WeekdayNames(lang, style), " "); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", Wide, "Sunday Monday Tuesday Wednesday Thursday Friday Saturday"))
+	t.Run("french abbreviated", fn("fr", Short, "dim. lun. mar. mer. jeu. ven. sam."))
+	t.Run("german narrow", fn("de", Narrow, "S M D M D F S"))
+	t.Run("spanish narrow", fn("es", Narrow, "D L M X J V S"))
+	t.Run("chinese narrow", fn("zh-tw", Narrow, "日 一 二 三 四 五 六"))
+
+	if got := WeekdayNames("pt", Wide)[time.Monday]; got != "segunda-feira" {
+		t.Fatalf("expected names indexed by weekday, got %q", got)
+	}
+
+	names := WeekdayNames("en", Wide)
+	names[0] = "changed"
+	if got := WeekdayNames("en", Wide)[0]; got != "Sunday" {
+		t.Fatalf("expected names to be copied, got %q", got)
+	}
+}
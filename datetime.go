@@ -0,0 +1,337 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dateTimePatterns are the patterns of dates and times of a language in the wide and short
+// style, narrow being formatted like short. Patterns refer to the fields of the time by
+// placeholders: {y} and {yy} for the year, {M}, {MM}, {MMM} and {MMMM} for the month,
+// {d} and {dd} for the day, {E} and {EEEE} for the weekday, {H}, {HH} and {h} for the hour,
+// {mm} for minutes, {ss} for seconds, {a} for the period of 12 hour clocks and {z} for the
+// zone. Combined patterns refer to {date} and {time}.
+type dateTimePatterns struct {
+	date     [2]string
+	time     [2]string
+	dateTime [2]string
+}
+
+// isoPatterns format dates and times of languages lacking patterns in ISO 8601 notation
+var isoPatterns = dateTimePatterns{
+	date:     [2]string{"{y}-{MM}-{dd}", "{y}-{MM}-{dd}"},
+	time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+	dateTime: [2]string{"{date} {time}", "{date} {time}"},
+}
+
+// dateTimePatternsByLanguage lists the patterns following the CLDR long and short formats
+var dateTimePatternsByLanguage = map[Language]dateTimePatterns{
+	"de": {
+		date:     [2]string{"{d}. {MMMM} {y}", "{dd}.{MM}.{yy}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} um {time}", "{date}, {time}"},
+	},
+	"en": {
+		date:     [2]string{"{MMMM} {d}, {y}", "{M}/{d}/{yy}"},
+		time:     [2]string{"{h}:{mm}:{ss} {a} {z}", "{h}:{mm} {a}"},
+		dateTime: [2]string{"{date} at {time}", "{date}, {time}"},
+	},
+	"en-gb": {
+		date:     [2]string{"{d} {MMMM} {y}", "{dd}/{MM}/{y}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} at {time}", "{date}, {time}"},
+	},
+	"es": {
+		date:     [2]string{"{d} de {MMMM} de {y}", "{d}/{M}/{yy}"},
+		time:     [2]string{"{H}:{mm}:{ss} ({z})", "{H}:{mm}"},
+		dateTime: [2]string{"{date}, {time}", "{date}, {time}"},
+	},
+	"fr": {
+		date:     [2]string{"{d} {MMMM} {y}", "{dd}/{MM}/{y}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} à {time}", "{date} {time}"},
+	},
+	"it": {
+		date:     [2]string{"{d} {MMMM} {y}", "{dd}/{MM}/{yy}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} alle ore {time}", "{date}, {time}"},
+	},
+	"ja": {
+		date:     [2]string{"{y}年{M}月{d}日", "{y}/{MM}/{dd}"},
+		time:     [2]string{"{H}:{mm}:{ss} {z}", "{H}:{mm}"},
+		dateTime: [2]string{"{date} {time}", "{date} {time}"},
+	},
+	"nl": {
+		date:     [2]string{"{d} {MMMM} {y}", "{dd}-{MM}-{y}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} om {time}", "{date} {time}"},
+	},
+	"pt": {
+		date:     [2]string{"{d} de {MMMM} de {y}", "{dd}/{MM}/{y}"},
+		time:     [2]string{"{HH}:{mm}:{ss} {z}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} às {time}", "{date} {time}"},
+	},
+	"zh": {
+		date:     [2]string{"{y}年{M}月{d}日", "{y}/{M}/{d}"},
+		time:     [2]string{"{z} {HH}:{mm}:{ss}", "{HH}:{mm}"},
+		dateTime: [2]string{"{date} {time}", "{date} {time}"},
+	},
+}
+
+// dateTimePatternsOf returns the patterns of the language
+func dateTimePatternsOf(lang Language) dateTimePatterns {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if patterns, ok := dateTimePatternsByLanguage[candidate]; ok {
+			return patterns
+		}
+	}
+	return isoPatterns
+}
+
+// styleIndex returns the index of the patterns of the style
+func styleIndex(style Style) int {
+	if style == Wide {
+		return 0
+	}
+	return 1
+}
+
+// FormatDate formats the date of t in the language and its default calendar, e.g.
+// "January 2, 2006" or "1/2/06" in English
+func FormatDate(lang Language, t time.Time, style Style) string {
+	return FormatCalendarDate(lang, t, style, DefaultCalendar(lang))
+}
+
+// FormatCalendarDate formats the date of t in the language and calendar, e.g.
+// "January 2, 18 Heisei" in English in the japanese calendar
+func FormatCalendarDate(lang Language, t time.Time, style Style, calendar Calendar) string {
+	return renderDateTime(lang, calendar, t, calendarDatePattern(lang, calendar, style))
+}
+
+// FormatTime formats the time of t in the language and its preferred hour cycle, e.g.
+// "3:04:05 PM MST" or "3:04 PM" in English. The zone is included in the wide style only.
+// Convert t by t.In to format it in another zone.
+func FormatTime(lang Language, t time.Time, style Style) string {
+	return FormatTimeCycle(lang, t, style, PreferredHourCycle(lang))
+}
+
+// FormatTimeCycle formats the time of t in the language and hour cycle, e.g. "15:04" in
+// English in the 24 hour cycle
+func FormatTimeCycle(lang Language, t time.Time, style Style, cycle HourCycle) string {
+	return renderDateTime(lang, Gregorian, t, timePattern(lang, style, cycle))
+}
+
+// FormatDateTime formats the date and time of t in the language and its default calendar, e.g.
+// "January 2, 2006 at 3:04:05 PM MST" or "1/2/06, 3:04 PM" in English
+func FormatDateTime(lang Language, t time.Time, style Style) string {
+	return FormatCalendarDateTime(lang, t, style, DefaultCalendar(lang))
+}
+
+// FormatCalendarDateTime formats the date and time of t in the language and calendar
+func FormatCalendarDateTime(lang Language, t time.Time, style Style, calendar Calendar) string {
+	return formatDateTime(lang, t, timeSettings{style: style, calendar: calendar, cycle: PreferredHourCycle(lang)})
+}
+
+// timeSettings select how dates and times are formatted
+type timeSettings struct {
+	style    Style
+	calendar Calendar
+	cycle    HourCycle
+}
+
+// formatDate formats the date of t, dates being independent of hour cycles
+func formatDate(lang Language, t time.Time, settings timeSettings) string {
+	return FormatCalendarDate(lang, t, settings.style, settings.calendar)
+}
+
+// formatTime formats the time of t, times being independent of calendars
+func formatTime(lang Language, t time.Time, settings timeSettings) string {
+	return FormatTimeCycle(lang, t, settings.style, settings.cycle)
+}
+
+// formatDateTime formats the date and time of t
+func formatDateTime(lang Language, t time.Time, settings timeSettings) string {
+	patterns := dateTimePatternsOf(lang)
+	i := styleIndex(settings.style)
+	pattern := strings.NewReplacer(
+		"{date}", calendarDatePattern(lang, settings.calendar, settings.style),
+		"{time}", timePattern(lang, settings.style, settings.cycle),
+	).Replace(patterns.dateTime[i])
+	return renderDateTime(lang, settings.calendar, t, pattern)
+}
+
+// renderDateTime replaces the placeholders of the pattern by the fields of t in the calendar
+func renderDateTime(lang Language, calendar Calendar, t time.Time, pattern string) string {
+	date := calendar.date(t)
+
+	var b strings.Builder
+	for {
+		i := strings.Index(pattern, "{")
+		j := strings.Index(pattern, "}")
+		if i == -1 || j < i {
+			b.WriteString(pattern)
+			return b.String()
+		}
+		b.WriteString(pattern[:i])
+		b.WriteString(dateTimeField(lang, calendar, t, date, pattern[i+1:j]))
+		pattern = pattern[j+1:]
+	}
+}
+
+// dateTimeField formats a field of t, taking the fields of the date of the calendar
+func dateTimeField(lang Language, calendar Calendar, t time.Time, date calendarDate, field string) string {
+	switch field {
+	case "G":
+		return eraName(lang, date)
+	case "y":
+		return strconv.Itoa(date.year)
+	case "yy":
+		return fmt.Sprintf("%02d", date.year%100)
+	case "M":
+		return strconv.Itoa(date.month)
+	case "MM":
+		return fmt.Sprintf("%02d", date.month)
+	case "MMM":
+		if _, ok := calendarMonthNames[calendar]; !ok {
+			return namesOf(monthNames, lang).abbreviated[date.month-1]
+		}
+		return monthName(lang, calendar, date)
+	case "MMMM":
+		return monthName(lang, calendar, date)
+	case "E":
+		return namesOf(weekdayNames, lang).abbreviated[t.Weekday()]
+	case "EEEE":
+		return namesOf(weekdayNames, lang).wide[t.Weekday()]
+	case "d":
+		return strconv.Itoa(date.day)
+	case "dd":
+		return fmt.Sprintf("%02d", date.day)
+	case "H":
+		return strconv.Itoa(t.Hour())
+	case "HH":
+		return fmt.Sprintf("%02d", t.Hour())
+	case "h":
+		if h := t.Hour() % 12; h != 0 {
+			return strconv.Itoa(h)
+		}
+		return "12"
+	case "mm":
+		return fmt.Sprintf("%02d", t.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", t.Second())
+	case "a":
+		return dayPeriodOf(lang).of(t)
+	case "z":
+		zone, _ := t.Zone()
+		return zone
+	}
+	return "{" + field + "}"
+}
+
+// Options of the date and time formats: the style ("wide", "short" or "narrow") or a
+// skeleton (e.g. "yMMMd", see FormatSkeleton), the zone to format the time in, the
+// calendar of dates and the hour cycle of times ("12" or "24")
+var (
+	timeOptions     = []string{"style", "skeleton", "zone", "hours"}
+	dateOptions     = []string{"style", "skeleton", "zone", "calendar"}
+	dateTimeOptions = []string{"style", "skeleton", "zone", "calendar", "hours"}
+)
+
+// hourCycles are the hour cycles by their names within format options
+var hourCycles = map[string]HourCycle{
+	"12": Hour12,
+	"24": Hour24,
+}
+
+// styles are the styles by their names within format options
+var styles = map[string]Style{
+	"wide":   Wide,
+	"short":  Short,
+	"narrow": Narrow,
+}
+
+// timeFormat adapts a function formatting times to a format. The zone option either names
+// a zone, e.g. "Europe/Vienna", or a parameter holding a *time.Location or a zone name,
+// e.g. {{ts, datetime(zone=tz)}} with the parameter "tz". Times are formatted in the short
+// style, the default calendar and the preferred hour cycle of the language by default.
+func timeFormat(format func(lang Language, t time.Time, settings timeSettings) string) formatFunc {
+	return func(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error) {
+		t, ok := value.(time.Time)
+		if !ok {
+			return "", fmt.Errorf("expected time, got %T", value)
+		}
+
+		settings := timeSettings{style: Short, calendar: DefaultCalendar(lang), cycle: PreferredHourCycle(lang)}
+		if name, ok := options["style"]; ok {
+			if settings.style, ok = styles[name]; !ok {
+				return "", fmt.Errorf("unknown style %q", name)
+			}
+		}
+		if name, ok := options["calendar"]; ok {
+			if settings.calendar = Calendar(name); !settings.calendar.valid() {
+				return "", fmt.Errorf("unknown calendar %q", name)
+			}
+		}
+		if name, ok := options["hours"]; ok {
+			if settings.cycle, ok = hourCycles[name]; !ok {
+				return "", fmt.Errorf("unknown hour cycle %q", name)
+			}
+		}
+
+		if zone, ok := options["zone"]; ok {
+			loc, err := resolveZone(zone, params)
+			if err != nil {
+				return "", err
+			}
+			t = t.In(loc)
+		}
+
+		if skeleton, ok := options["skeleton"]; ok {
+			pattern, err := skeletonPattern(lang, skeleton, settings.cycle)
+			if err != nil {
+				return "", err
+			}
+			return renderDateTime(lang, Gregorian, t, pattern), nil
+		}
+		return format(lang, t, settings), nil
+	}
+}
+
+// resolveZone resolves the zone option into a location
+func resolveZone(zone string, params intermediateLookup) (*time.Location, error) {
+	if value, ok := params.get(Intermediate(zone)); ok {
+		switch v := value.(type) {
+		case *time.Location:
+			if v == nil {
+				return nil, errors.New("zone must not be nil")
+			}
+			return v, nil
+		case string:
+			zone = v
+		default:
+			return nil, fmt.Errorf("zone %q must be a *time.Location or a zone name, got %T", zone, value)
+		}
+	}
+	return loadZone(zone)
+}
+
+// zones caches the loaded locations by their name
+var zones sync.Map
+
+// loadZone loads the location of the zone name, caching it
+func loadZone(name string) (*time.Location, error) {
+	if loc, ok := zones.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown zone %q", name)
+	}
+	zones.Store(name, loc)
+	return loc, nil
+}
@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFormatDateTime(t *testing.T) {
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	fn := func(format func(Language, time.Time, Style) string, lang Language, style Style, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := format(lang, ts, style); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english date", fn(FormatDate, "en", Wide, "March 4, 2021"))
+	t.Run("english short date", fn(FormatDate, "en-us", Short, "3/4/21"))
+	t.Run("british date", fn(FormatDate, "en-gb", Short, "04/03/2021"))
+	t.Run("german date", fn(FormatDate, "de", Wide, "4. März 2021"))
+	t.Run("spanish date", fn(FormatDate, "es", Wide, "4 de marzo de 2021"))
+	t.Run("japanese date", fn(FormatDate, "ja", Wide, "2021年3月4日"))
+	t.Run("english time", fn(FormatTime, "en", Wide, "3:04:05 PM CET"))
+	t.Run("german time", fn(FormatTime, "de", Narrow, "15:04"))
+	t.Run("english datetime", fn(FormatDateTime, "en", Short, "3/4/21, 3:04 PM"))
+	t.Run("french datetime", fn(FormatDateTime, "fr", Wide, "4 mars 2021 à 15:04:05 CET"))
+	t.Run("iso", fn(FormatDateTime, "xx", Short, "2021-03-04 15:04"))
+}
+
+func TestDateTimeFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"local": "Starts {{ts, datetime}}",
+			"user": "Starts {{ts:time, datetime(zone=tz; style=wide)}}",
+			"fixed": "Starts {{ts, time(zone=UTC)}}"
+		}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		t.Skip("zone information not available:", err)
+	}
+	ts := time.Date(2021, time.March, 4, 14, 4, 5, 0, time.UTC)
+
+	fn := func(key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("en")(key, params...)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("default", fn("local", "Starts 3/4/21, 2:04 PM", "ts", ts))
+	t.Run("location", fn("user", "Starts March 4, 2021 at 3:04:05 PM CET", "ts", ts, "tz", vienna))
+	t.Run("zone name", fn("user", "Starts March 4, 2021 at 9:04:05 AM EST", "ts", ts, "tz", "America/New_York"))
+	t.Run("literal zone", fn("fixed", "Starts 2:04 PM", "ts", ts.In(vienna)))
+
+	if _, err := translations.GenerateTranslate("en")("user", "ts", ts, "tz", "Nowhere/City"); err == nil {
+		t.Fatal("expected error for unknown zone")
+	}
+	if _, err := translations.GenerateTranslate("en")("local", "ts", "today"); err == nil {
+		t.Fatal("expected error for value not being a time")
+	}
+
+	validate := func(message string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "` + message + `"}`)}}, "en")
+			if len(issues) != 1 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("unknown option", validate("{{ts, datetime(zones=tz)}}", `invalid format of intermediate "ts": unknown option "zones" of format "datetime"`))
+	t.Run("unclosed options", validate("{{ts, datetime(zone=tz}}", `invalid format of intermediate "ts": invalid format "datetime(zone=tz", options must end with )`))
+	t.Run("invalid option", validate("{{ts, datetime(zone)}}", `invalid format of intermediate "ts": invalid option "zone" of format "datetime", must be name=value`))
+}
@@ -0,0 +1,367 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"time"
+)
+
+// decoder flattens a JSON language file into a store while parsing it, such that
+// the file never has to be held in memory as a whole. It follows the i18next format
+// combining the key fragments of nested objects into a complete key string.
+// Invalid translations are collected as issues, only syntax errors abort the decoding.
+type decoder struct {
+	r        io.Reader
+	tokens   *json.Decoder
+	interned interner
+	limits   Limits
+
+	// normalize is applied to each complete key if set
+	normalize func(Key) Key
+
+	// lang is the language of the file, taken of its name unless declared by the file itself
+	// following the rules
+	lang  Language
+	rules languageRules
+
+	// fsys and path locate the file for resolving includes relative to it, including lists
+	// the files including it and included collects all files included
+	fsys      fs.FS
+	path      string
+	including []string
+	included  map[string]bool
+
+	// checksums are the hashes of the files listed by the manifest if configured,
+	// files not listed must not be included or extended
+	checksums map[string]string
+
+	// base is the decoder of the file extended by the file
+	base *decoder
+
+	store     Store
+	metadata  map[Key]Metadata
+	constants map[string]string
+	issues    []Issue
+
+	// sources tracks the key fragments each key was combined of
+	// for detecting colliding keys spelled differently
+	sources map[Key]string
+}
+
+// newDecoder creates a decoder reading from r. Keys and messages are interned using interned.
+func newDecoder(r io.Reader, interned interner) *decoder {
+	return &decoder{
+		r:         r,
+		tokens:    json.NewDecoder(r),
+		interned:  interned,
+		store:     make(Store),
+		metadata:  make(map[Key]Metadata),
+		constants: make(map[string]string),
+		sources:   make(map[Key]string),
+	}
+}
+
+// fileFormat decodes language files of an extension
+type fileFormat struct {
+	decode func(d *decoder) (Store, error)
+	// language derives the language of a file from its base name without extension,
+	// taking the name itself if nil
+	language func(name string, defaultLanguage Language) string
+	// declared reports whether files declare their language, overriding their name
+	declared bool
+}
+
+// fileFormats are the formats of language files by their extension
+var fileFormats = map[string]fileFormat{
+	".json": {decode: (*decoder).decode},
+	".resx": {decode: (*decoder).decodeResx, language: resxLanguage},
+	".ts":   {decode: (*decoder).decodeTS, language: tsLanguage, declared: true},
+	".yml":  {decode: (*decoder).decodeYAML, language: yamlLanguage, declared: true},
+	".yaml": {decode: (*decoder).decodeYAML, language: yamlLanguage, declared: true},
+}
+
+// report records an issue for the given key
+func (d *decoder) report(key Key, message string) {
+	d.issues = append(d.issues, Issue{Key: key, Message: message})
+}
+
+// decode parses the whole language file which must consist of a single object
+func (d *decoder) decode() (Store, error) {
+	var k Key
+	if err := d.document(k, "", 1); err != nil {
+		return nil, err
+	}
+	d.inherit()
+	return d.store, nil
+}
+
+// document flattens the single object of a file below the root key
+func (d *decoder) document(rootKey Key, source string, depth int) error {
+	token, err := d.tokens.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return errors.New("invalid translation file, must be an object")
+	}
+
+	if err := d.object(rootKey, source, depth); err != nil {
+		return err
+	}
+
+	if _, err := d.tokens.Token(); err != io.EOF {
+		return errors.New("invalid translation file, unexpected data after object")
+	}
+	return nil
+}
+
+// object flattens the members of an object whose opening delimiter was already consumed.
+// The source denotes the key fragments the root key was combined of, depth the nesting level.
+func (d *decoder) object(rootKey Key, source string, depth int) error {
+	if d.limits.MaxDepth > 0 && depth > d.limits.MaxDepth {
+		return fmt.Errorf("nesting exceeds maximum depth of %d with key %q", d.limits.MaxDepth, rootKey)
+	}
+
+	members := 0
+	for d.tokens.More() {
+		token, err := d.tokens.Token()
+		if err != nil {
+			return err
+		}
+
+		members++
+		key := token.(string)
+
+		// includes merge the object of another file into the object
+		if key == IncludeKey {
+			var name string
+			if err := d.tokens.Decode(&name); err != nil {
+				var typeErr *json.UnmarshalTypeError
+				if !errors.As(err, &typeErr) {
+					return err
+				}
+				d.report(rootKey, "invalid include, must be the path of a file")
+				continue
+			}
+			if err := d.include(rootKey, source, name, depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if key == ExtendsKey {
+			var name string
+			if err := d.tokens.Decode(&name); err != nil {
+				var typeErr *json.UnmarshalTypeError
+				if !errors.As(err, &typeErr) {
+					return err
+				}
+				d.report(rootKey, "invalid extension, must be the path of a file")
+				continue
+			}
+			if err := d.extend(rootKey, name, depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if key == ConstantsKey {
+			if err := d.constantsMember(rootKey, depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// metadata entries describe the sibling key named without the prefix
+		if strings.HasPrefix(key, MetadataPrefix) {
+			if err := d.metadataEntry(rootKey, strings.TrimPrefix(key, MetadataPrefix)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// append key fragment to root key
+		parentKey := rootKey
+		rootKey := d.key(rootKey, key)
+		source := source + "\x00" + key
+
+		token, err = d.tokens.Token()
+		if err != nil {
+			return err
+		}
+
+		if key == "" {
+			d.report(parentKey, "invalid key, should not be empty")
+			if err := d.skip(token); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if d.limits.MaxKeyLength > 0 && len(rootKey) > d.limits.MaxKeyLength {
+			d.report(parentKey, fmt.Sprintf("key exceeds maximum length of %d bytes", d.limits.MaxKeyLength))
+			if err := d.skip(token); err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch t := token.(type) {
+		case string:
+			d.message(rootKey, source, t)
+
+		case json.Delim:
+			if t != '{' {
+				d.report(rootKey, "invalid type array in translation file, only string or objects as values allowed")
+				if err := d.skip(token); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := d.object(rootKey, source, depth+1); err != nil {
+				return err
+			}
+
+		default:
+			d.report(rootKey, fmt.Sprintf("invalid type %T in translation file, only string or objects as values allowed", t))
+		}
+	}
+
+	// consume the closing delimiter
+	if _, err := d.tokens.Token(); err != nil {
+		return err
+	}
+
+	if members == 0 {
+		d.report(rootKey, "invalid translation, objects must not be empty")
+	}
+	return nil
+}
+
+// message adds the message of the key to the store, the source denoting the key fragments
+// the key was combined of. Invalid messages are reported.
+func (d *decoder) message(key Key, source string, message string) {
+	message = d.interned.intern(message)
+
+	if d.limits.MaxIntermediates > 0 && strings.Count(message, Prefix) > d.limits.MaxIntermediates {
+		d.report(key, fmt.Sprintf("message exceeds maximum of %d intermediates", d.limits.MaxIntermediates))
+		return
+	}
+
+	// parse the intermediates (if existing) of message string
+	// for fail-safety
+	intermediates, segments, err := parseIntermediates(message)
+	if err != nil {
+		d.report(key, err.Error())
+		return
+	}
+
+	// members repeated within the same object override each other as in encoding/json,
+	// but the same key must not result from different nesting levels or spellings
+	if other, ok := d.sources[key]; ok && other != source {
+		d.report(key, "key collision, defined multiple times with different nesting or spelling")
+		return
+	}
+	d.sources[key] = source
+
+	d.store[key] = Translation{
+		Message:       message,
+		Intermediates: intermediates,
+		segments:      segments,
+	}
+}
+
+// constantsMember decodes the constants declared by the top-level object
+func (d *decoder) constantsMember(rootKey Key, depth int) error {
+	var constants map[string]string
+	if err := d.tokens.Decode(&constants); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return err
+		}
+		d.report(rootKey, "invalid constants, must be an object of strings")
+		return nil
+	}
+
+	if depth != 1 {
+		d.report(rootKey, "invalid constants, must be declared at the top level")
+		return nil
+	}
+	d.declareConstants(constants)
+	return nil
+}
+
+// metadataEntry decodes the metadata of the key fragment below rootKey
+func (d *decoder) metadataEntry(rootKey Key, key string) error {
+	var metadata Metadata
+	if err := d.tokens.Decode(&metadata); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		var timeErr *time.ParseError
+		if !errors.As(err, &typeErr) && !errors.As(err, &timeErr) {
+			return err
+		}
+
+		d.report(rootKey.Append(key), fmt.Sprintf("invalid metadata, %v", err))
+		return nil
+	}
+
+	if key == "" {
+		d.report(rootKey, "invalid metadata key, should not be empty")
+		return nil
+	}
+	if metadata.MaxLength < 0 {
+		d.report(rootKey.Append(key), "invalid metadata, maximum length must not be negative")
+		return nil
+	}
+	for name, weight := range metadata.Variants {
+		if name == "" || weight <= 0 {
+			d.report(rootKey.Append(key), fmt.Sprintf("invalid metadata, variant %q must be named and weighted positively", name))
+			return nil
+		}
+	}
+
+	if metadata.Alias != "" && d.normalize != nil {
+		metadata.Alias = d.normalize(metadata.Alias)
+	}
+
+	d.metadata[d.key(rootKey, key)] = metadata
+	return nil
+}
+
+// key appends the fragment to the root key, returning the normalized and interned result
+func (d *decoder) key(rootKey Key, fragment string) Key {
+	key := rootKey.Append(fragment)
+	if d.normalize != nil {
+		key = d.normalize(key)
+	}
+	return Key(d.interned.intern(string(key)))
+}
+
+// skip consumes the remainder of the value starting with token
+func (d *decoder) skip(token json.Token) error {
+	depth := 0
+	for {
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+
+		var err error
+		token, err = d.tokens.Token()
+		if err != nil {
+			return err
+		}
+	}
+}
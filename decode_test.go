@@ -0,0 +1,45 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecoder(t *testing.T) {
+	fn := func(data string, expected int) func(t *testing.T) {
+		return func(t *testing.T) {
+			d := newDecoder(strings.NewReader(data), make(interner))
+			store, err := d.decode()
+			if err == nil && len(d.issues) > 0 {
+				err = d.issues[0]
+			}
+
+			if expected < 0 {
+				if err == nil {
+					t.Fatalf("expected error, got %v", store)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(store) != expected {
+				t.Fatalf("expected %d translations, got %d", expected, len(store))
+			}
+		}
+	}
+
+	t.Run("flat", fn(`{"a": "x", "b": "y"}`, 2))
+	t.Run("nested", fn(`{"a": {"b": {"c": "x"}, "d": "y"}}`, 2))
+	t.Run("duplicate", fn(`{"a": "x", "a": "y"}`, 1))
+	t.Run("repeated object", fn(`{"a": {"b": "x"}, "a": {"c": "y"}}`, 2))
+	t.Run("array", fn(`{"a": ["x"]}`, -1))
+	t.Run("null", fn(`{"a": null}`, -1))
+	t.Run("number", fn(`{"a": 1}`, -1))
+	t.Run("no object", fn(`["a"]`, -1))
+	t.Run("trailing data", fn(`{"a": "x"} {"b": "y"}`, -1))
+	t.Run("truncated", fn(`{"a": {"b": "x"}`, -1))
+	t.Run("empty object", fn(`{"a": {}}`, -1))
+	t.Run("empty", fn(``, -1))
+}
@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"sync/atomic"
+)
+
+// defaultTranslations holds a pointer to the translations set by SetDefault
+var defaultTranslations atomic.Value
+
+// SetDefault sets the translations used by the package-level shortcuts T and TContext for
+// applications having a single catalog. It is safe for concurrent use with translating,
+// allowing to replace the translations at runtime.
+func SetDefault(trl Translations) {
+	defaultTranslations.Store(&trl)
+}
+
+// Default returns the translations set by SetDefault, reporting whether any are set
+func Default() (Translations, bool) {
+	trl, _ := defaultTranslations.Load().(*Translations)
+	if trl == nil {
+		return Translations{}, false
+	}
+	return *trl, true
+}
+
+// T translates the key of the default translations into the language, interpolating
+// the parameters as the functions returned by GenerateTranslate
+func T(lang string, key string, params ...interface{}) (template.HTML, error) {
+	trl, ok := Default()
+	if !ok {
+		return "", errors.New("no default translations set, see SetDefault")
+	}
+	return trl.GenerateTranslate(lang)(key, params...)
+}
+
+// TContext translates the key of the default translations into the language carried by ctx,
+// falling back to the default language, see NewContext and Middleware
+func TContext(ctx context.Context, key string, params ...interface{}) (template.HTML, error) {
+	trl, ok := Default()
+	if !ok {
+		return "", errors.New("no default translations set, see SetDefault")
+	}
+	return trl.GenerateTranslate(string(trl.contextLanguage(ctx)))(key, params...)
+}
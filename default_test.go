@@ -0,0 +1,34 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDefault(t *testing.T) {
+	if _, err := T("en", "greeting"); err == nil {
+		t.Fatal("expected error without default translations")
+	}
+
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hello {{name}}"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hallo {{name}}"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	SetDefault(translations)
+	defer defaultTranslations.Store((*Translations)(nil))
+
+	if got, err := T("de", "greeting", "name", "Anna"); err != nil || got != "Hallo Anna" {
+		t.Fatalf("expected german translation, got %q: %v", got, err)
+	}
+	if got, err := TContext(NewContext(context.Background(), "de"), "greeting", "name", "Anna"); err != nil || got != "Hallo Anna" {
+		t.Fatalf("expected translation of the context language, got %q: %v", got, err)
+	}
+	if got, err := TContext(context.Background(), "greeting", "name", "Anna"); err != nil || got != "Hello Anna" {
+		t.Fatalf("expected translation of the default language, got %q: %v", got, err)
+	}
+}
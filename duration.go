@@ -0,0 +1,149 @@
+package i18n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Style selects the length of localized names and units
+type Style int
+
+const (
+	// Wide spells names and units out, e.g. "2 hours 5 minutes" or "January"
+	Wide Style = iota
+	// Short abbreviates names and units, e.g. "2 hr 5 min" or "Jan"
+	Short
+	// Narrow uses the shortest form of names and units, e.g. "2h 5m" or "J"
+	Narrow
+)
+
+// durationUnit indexes the units of durations
+type durationUnit int
+
+const (
+	days durationUnit = iota
+	hours
+	minutes
+	seconds
+	milliseconds
+	durationUnitCount
+)
+
+// durationLengths are the lengths of the units
+var durationLengths = [durationUnitCount]time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second, time.Millisecond}
+
+// durationNames are the names of the units of durations in a language
+type durationNames struct {
+	// one and other are the wide names for a single and for multiple units
+	one, other [durationUnitCount]string
+	short      [durationUnitCount]string
+	narrow     [durationUnitCount]string
+	// narrowSeparator separates the number from the narrow unit
+	narrowSeparator string
+	// singularZero reports whether zero units are named in singular as in French
+	singularZero bool
+}
+
+// durationNamesByLanguage lists the names of units following the CLDR.
+// Languages not listed format durations in English.
+var durationNamesByLanguage = map[Language]durationNames{
+	"de": {
+		one:             [...]string{"Tag", "Stunde", "Minute", "Sekunde", "Millisekunde"},
+		other:           [...]string{"Tage", "Stunden", "Minuten", "Sekunden", "Millisekunden"},
+		short:           [...]string{"Tg.", "Std.", "Min.", "Sek.", "ms"},
+		narrow:          [...]string{"T", "Std.", "Min.", "Sek.", "ms"},
+		narrowSeparator: noBreakSpace,
+	},
+	"en": {
+		one:    [...]string{"day", "hour", "minute", "second", "millisecond"},
+		other:  [...]string{"days", "hours", "minutes", "seconds", "milliseconds"},
+		short:  [...]string{"d", "hr", "min", "sec", "ms"},
+		narrow: [...]string{"d", "h", "m", "s", "ms"},
+	},
+	"es": {
+		one:             [...]string{"día", "hora", "minuto", "segundo", "milisegundo"},
+		other:           [...]string{"días", "horas", "minutos", "segundos", "milisegundos"},
+		short:           [...]string{"d", "h", "min", "s", "ms"},
+		narrow:          [...]string{"d", "h", "min", "s", "ms"},
+		narrowSeparator: noBreakSpace,
+	},
+	"fr": {
+		one:             [...]string{"jour", "heure", "minute", "seconde", "milliseconde"},
+		other:           [...]string{"jours", "heures", "minutes", "secondes", "millisecondes"},
+		short:           [...]string{"j", "h", "min", "s", "ms"},
+		narrow:          [...]string{"j", "h", "min", "s", "ms"},
+		narrowSeparator: noBreakSpace,
+		singularZero:    true,
+	},
+	"it": {
+		one:    [...]string{"giorno", "ora", "minuto", "secondo", "millisecondo"},
+		other:  [...]string{"giorni", "ore", "minuti", "secondi", "millisecondi"},
+		short:  [...]string{"g", "h", "min", "s", "ms"},
+		narrow: [...]string{"g", "h", "min", "s", "ms"},
+	},
+	"nl": {
+		one:    [...]string{"dag", "uur", "minuut", "seconde", "milliseconde"},
+		other:  [...]string{"dagen", "uur", "minuten", "seconden", "milliseconden"},
+		short:  [...]string{"d", "u", "min", "s", "ms"},
+		narrow: [...]string{"d", "u", "m", "s", "ms"},
+	},
+	"pt": {
+		one:    [...]string{"dia", "hora", "minuto", "segundo", "milissegundo"},
+		other:  [...]string{"dias", "horas", "minutos", "segundos", "milissegundos"},
+		short:  [...]string{"dia", "h", "min", "s", "ms"},
+		narrow: [...]string{"d", "h", "min", "s", "ms"},
+	},
+}
+
+// FormatDuration formats d in the units of the language and style, e.g. "2 hours, 5 minutes",
+// "2 hr 5 min" or "2h 5m" in English and "2 Std. 5 Min." in German. Units of zero are omitted,
+// durations below a second are formatted in milliseconds. Numbers and units are separated
+// by no-break spaces. Languages lacking unit names format durations in English.
+func FormatDuration(lang Language, d time.Duration, style Style) string {
+	names, ok := durationNamesByLanguage[lang.Base()]
+	if !ok {
+		names = durationNamesByLanguage["en"]
+	}
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	var parts []string
+	first, last := days, seconds
+	if d > 0 && d < time.Second {
+		first, last = milliseconds, milliseconds
+	}
+	for unit := first; unit <= last; unit++ {
+		n := int64(d / durationLengths[unit])
+		d -= time.Duration(n) * durationLengths[unit]
+		if n == 0 && !(unit == last && len(parts) == 0) {
+			continue
+		}
+		parts = append(parts, names.format(lang, n, unit, style))
+	}
+
+	separator := " "
+	if style == Wide {
+		separator = ", "
+	}
+	return sign + strings.Join(parts, separator)
+}
+
+// format formats n units in the style
+func (names durationNames) format(lang Language, n int64, unit durationUnit, style Style) string {
+	number := localizeNumber(lang, strconv.FormatInt(n, 10))
+	switch style {
+	case Short:
+		return number + noBreakSpace + names.short[unit]
+	case Narrow:
+		return number + names.narrowSeparator + names.narrow[unit]
+	}
+
+	if n == 1 || (n == 0 && names.singularZero) {
+		return number + noBreakSpace + names.one[unit]
+	}
+	return number + noBreakSpace + names.other[unit]
+}
@@ -0,0 +1,33 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	fn := func(lang Language, d time.Duration, style Style, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			// no-break spaces are written as regular spaces for readability
+			if got := strings.Replace(FormatDuration(lang, d, style), noBreakSpace, " ", -1); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	d := 2*time.Hour + 5*time.Minute
+	t.Run("wide", fn("en", d, Wide, "2 hours, 5 minutes"))
+	t.Run("short", fn("en", d, Short, "2 hr 5 min"))
+	t.Run("narrow", fn("en", d, Narrow, "2h 5m"))
+	t.Run("german wide", fn("de", d+time.Second, Wide, "2 Stunden, 5 Minuten, 1 Sekunde"))
+	t.Run("german short", fn("de-at", d, Short, "2 Std. 5 Min."))
+	t.Run("german narrow", fn("de", d, Narrow, "2 Std. 5 Min."))
+	t.Run("french", fn("fr", 0, Wide, "0 seconde"))
+	t.Run("days", fn("en", 50*time.Hour, Wide, "2 days, 2 hours"))
+	t.Run("milliseconds", fn("en", 250*time.Millisecond, Short, "250 ms"))
+	t.Run("zero", fn("en", 0, Wide, "0 seconds"))
+	t.Run("negative", fn("en", -90*time.Second, Narrow, "-1m 30s"))
+	t.Run("grouped", fn("de", 1500*24*time.Hour, Short, "1.500 Tg."))
+	t.Run("unknown", fn("ja", time.Minute, Short, "1 min"))
+}
@@ -0,0 +1,113 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+)
+
+const (
+	// EmailSubject is the key fragment of the subject of an email
+	EmailSubject = "subject"
+	// EmailText is the key fragment of the plain text body of an email
+	EmailText = "text"
+	// EmailHTML is the key fragment of the HTML body of an email
+	EmailHTML = "html"
+)
+
+// Email is a localized email consisting of a subject and a plain text and HTML body
+type Email struct {
+	Subject string
+	Text    string
+	HTML    template.HTML
+}
+
+// Email renders the email below the key prefix, e.g. "mail.welcome" consisting of
+// "mail.welcome.subject", "mail.welcome.text" and "mail.welcome.html", with the same parameters.
+// All parts are taken of the first language of the chain and fallback chain translating the
+// subject, never mixing languages. Either body may be missing, but not both. Parameters are
+// HTML escaped only within the HTML body. Parts are rendered like GenerateTranslate does,
+// printf-style if configured.
+func (trl Translations) Email(targetLang string, prefix string, params ...interface{}) (Email, error) {
+	if !trl.sampled() {
+		return trl.email(context.Background(), targetLang, prefix, params)
+	}
+
+	var email Email
+	err := trl.traced(context.Background(), "i18n.Email", func(ctx context.Context, span Span) error {
+		var err error
+		email, err = trl.email(ctx, targetLang, prefix, params)
+		return err
+	}, Attribute{Key: AttributeLanguage, Value: string(trl.resolveLanguage(targetLang))}, Attribute{Key: AttributeNamespace, Value: namespace(prefix)})
+	return email, err
+}
+
+// email renders the email below the key prefix as Email within the context
+func (trl Translations) email(ctx context.Context, targetLang string, prefix string, params []interface{}) (Email, error) {
+	chain := trl.languageChain(trl.resolveLanguage(targetLang))
+
+	base := trl.normalizeKey(Key(prefix))
+	subjectKey := trl.normalizeKey(base.Append(EmailSubject))
+	textKey := trl.normalizeKey(base.Append(EmailText))
+	htmlKey := trl.normalizeKey(base.Append(EmailHTML))
+
+	// the language translating the subject is resolved first, reporting the subject if missing
+	var single languageChain
+	for _, lang := range append(chain.languages, trl.fallbackChain...) {
+		if _, ok := trl.translations[lang][trl.canonical(subjectKey)]; ok {
+			single = languageChain{requested: chain.requested, languages: []Language{lang}}
+			break
+		}
+	}
+	if single.languages == nil {
+		_, _, err := trl.resolve(ctx, chain, subjectKey, "")
+		return Email{}, err
+	}
+
+	store := trl.translations[single.languages[0]]
+	_, hasText := store[trl.canonical(textKey)]
+	_, hasHTML := store[trl.canonical(htmlKey)]
+	if !hasText && !hasHTML {
+		return Email{}, fmt.Errorf("missing body of email %q, neither %q nor %q are translated", base, textKey, htmlKey)
+	}
+
+	var lookup intermediateLookup
+	if !trl.printf {
+		var err error
+		if lookup, err = createIntermediateLookup(params); err != nil {
+			return Email{}, err
+		}
+	}
+	render := func(key Key, escape func(string) string) (string, error) {
+		translation, _, err := trl.resolve(ctx, single, key, "")
+		if err != nil {
+			return "", err
+		}
+		if trl.keyCounter != nil {
+			trl.keyCounter.count(key)
+		}
+		if trl.printf {
+			return trl.sprintf(key, translation, params, escape)
+		}
+		return trl.interpolate(chain.requested, key, translation, lookup, escape)
+	}
+
+	var email Email
+	var err error
+	if email.Subject, err = render(subjectKey, nil); err != nil {
+		return Email{}, err
+	}
+	if hasText {
+		if email.Text, err = render(textKey, nil); err != nil {
+			return Email{}, err
+		}
+	}
+	if hasHTML {
+		rendered, err := render(htmlKey, trl.escape)
+		if err != nil {
+			return Email{}, err
+		}
+		email.HTML = template.HTML(rendered)
+	}
+	return email, nil
+}
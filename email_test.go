@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"html/template"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestEmail(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"mail": {
+			"welcome": {"subject": "Welcome {{name}}", "text": "Hello {{name}}", "html": "<p>Hello {{name}}</p>"},
+			"reset": {"subject": "Reset", "text": "Reset your password"},
+			"broken": {"subject": "Broken"}
+		}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"mail": {
+			"welcome": {"subject": "Willkommen {{name}}", "text": "Hallo {{name}}"}
+		}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, prefix string, expected Email) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.Email(lang, prefix, "name", "<Bob>")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("expected %+v, got %+v", expected, got)
+			}
+		}
+	}
+
+	t.Run("all parts", fn("en", "mail.welcome", Email{
+		Subject: "Welcome <Bob>",
+		Text:    "Hello <Bob>",
+		HTML:    template.HTML("<p>Hello &lt;Bob&gt;</p>"),
+	}))
+	t.Run("no html body", fn("en", "mail.reset", Email{Subject: "Reset", Text: "Reset your password"}))
+	t.Run("parts of the same language", fn("de", "mail.welcome", Email{Subject: "Willkommen <Bob>", Text: "Hallo <Bob>"}))
+	t.Run("fallback", fn("de", "mail.reset", Email{Subject: "Reset", Text: "Reset your password"}))
+
+	if _, err := translations.Email("en", "mail.broken"); err == nil {
+		t.Fatal("expected error for missing body")
+	}
+	if _, err := translations.Email("en", "mail.unknown"); err == nil {
+		t.Fatal("expected error for unknown email")
+	}
+	if _, err := translations.Email("en", "mail.welcome"); err == nil {
+		t.Fatal("expected error for missing parameter")
+	}
+}
+
+func TestEmailHooks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"mail": {"welcome": {"subject": "Welcome %s", "html": "<p>Hello %s</p>"}}}`)},
+	}
+
+	var reports []MissingKey
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithPrintfMessages(), WithKeyStats(1), WithMissingKeyHook(func(missing MissingKey) {
+		reports = append(reports, missing)
+	}, time.Hour)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := translations.Email("en", "mail.welcome", "<Bob>")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := (Email{Subject: "Welcome <Bob>", HTML: "<p>Hello &lt;Bob&gt;</p>"}); got != expected {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+	if hot := translations.HotKeys(-1); len(hot) != 2 {
+		t.Fatalf("expected both parts to be counted, got %v", hot)
+	}
+
+	if _, err := translations.Email("en", "mail.unknown"); err == nil {
+		t.Fatal("expected error for unknown email")
+	}
+	if expected := []MissingKey{{Language: "en", Key: "mail.unknown.subject"}}; !reflect.DeepEqual(reports, expected) {
+		t.Fatalf("expected %v, got %v", expected, reports)
+	}
+}
@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// byte order marks of the encodings translation files may be exported in
+var (
+	utf8BOM    = []byte{0xef, 0xbb, 0xbf}
+	utf16LEBOM = []byte{0xff, 0xfe}
+	utf16BEBOM = []byte{0xfe, 0xff}
+)
+
+// decodeUnicode returns a reader of the UTF-8 content of r, stripping byte order marks and
+// decoding UTF-16. UTF-16 lacking a byte order mark is detected by the zero byte accompanying
+// the leading ASCII character, e.g. the opening brace of JSON.
+func decodeUnicode(r io.Reader) (io.Reader, error) {
+	buffered := bufio.NewReader(r)
+	head, err := buffered.Peek(3)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(head, utf8BOM):
+		_, err := buffered.Discard(len(utf8BOM))
+		return buffered, err
+	case bytes.HasPrefix(head, utf16LEBOM):
+		order = binary.LittleEndian
+		_, err = buffered.Discard(len(utf16LEBOM))
+	case bytes.HasPrefix(head, utf16BEBOM):
+		order = binary.BigEndian
+		_, err = buffered.Discard(len(utf16BEBOM))
+	case len(head) >= 2 && head[0] != 0 && head[0] < utf8.RuneSelf && head[1] == 0:
+		order = binary.LittleEndian
+	case len(head) >= 2 && head[0] == 0 && head[1] != 0 && head[1] < utf8.RuneSelf:
+		order = binary.BigEndian
+	default:
+		return buffered, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(buffered)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUTF16(data, order)
+}
+
+// decodeUTF16 converts UTF-16 data of the byte order into UTF-8
+func decodeUTF16(data []byte, order binary.ByteOrder) (io.Reader, error) {
+	if len(data)%2 != 0 {
+		return nil, errors.New("invalid UTF-16 encoding, odd number of bytes")
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+
+	var b bytes.Buffer
+	b.Grow(len(units))
+	for _, r := range utf16.Decode(units) {
+		b.WriteRune(r)
+	}
+	return &b, nil
+}
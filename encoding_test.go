@@ -0,0 +1,47 @@
+package i18n
+
+import (
+	"encoding/binary"
+	"testing"
+	"testing/fstest"
+	"unicode/utf16"
+)
+
+// encodeUTF16 encodes s as UTF-16 of the byte order prefixed by bom
+func encodeUTF16(s string, order binary.ByteOrder, bom []byte) []byte {
+	data := append([]byte(nil), bom...)
+	for _, unit := range utf16.Encode([]rune(s)) {
+		data = append(data, 0, 0)
+		order.PutUint16(data[len(data)-2:], unit)
+	}
+	return data
+}
+
+func TestDecodeUnicode(t *testing.T) {
+	const content = `{"greeting": "Grüß dich 👋"}`
+
+	fn := func(data []byte) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{"de.json": &fstest.MapFile{Data: data}}
+			translations, err := New(WithFS(fsys), WithDefaultLanguage("de")).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, err := translations.GenerateTranslate("de")("greeting"); err != nil || got != "Grüß dich 👋" {
+				t.Fatalf("expected decoded translation, got %q: %v", got, err)
+			}
+		}
+	}
+
+	t.Run("utf8", fn([]byte(content)))
+	t.Run("utf8 bom", fn(append(append([]byte(nil), utf8BOM...), content...)))
+	t.Run("utf16 little endian", fn(encodeUTF16(content, binary.LittleEndian, utf16LEBOM)))
+	t.Run("utf16 big endian", fn(encodeUTF16(content, binary.BigEndian, utf16BEBOM)))
+	t.Run("utf16 without bom", fn(encodeUTF16(content, binary.LittleEndian, nil)))
+	t.Run("utf16 big endian without bom", fn(encodeUTF16(content, binary.BigEndian, nil)))
+
+	issues := Validate(fstest.MapFS{"de.json": &fstest.MapFile{Data: append(encodeUTF16(content, binary.LittleEndian, utf16LEBOM), 0)}}, "de")
+	if len(issues) == 0 {
+		t.Fatal("expected issue for truncated UTF-16")
+	}
+}
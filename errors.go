@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// Error is an error to be reported to users in their language. The key denotes
+// both the translation of the message and the error code of API responses.
+type Error struct {
+	Key    string
+	Params []interface{}
+
+	// Err is the underlying error, which is never exposed to users
+	Err error
+}
+
+// NewError creates an error translated by key with the parameters
+func NewError(key string, params ...interface{}) *Error {
+	return &Error{Key: key, Params: params}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Key + ": " + e.Err.Error()
+	}
+	return e.Key
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// LocalizedError is an error translated for API responses
+type LocalizedError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorEnvelope is the body of API error responses
+type ErrorEnvelope struct {
+	Error LocalizedError `json:"error"`
+}
+
+// LocalizeError translates err in the language carried by ctx. Errors not wrapping an
+// Error, or whose key is not translated, are reported by the problem title of the status
+// code without exposing their message.
+func (trl Translations) LocalizeError(ctx context.Context, status int, err error) LocalizedError {
+	lang := trl.contextLanguage(ctx)
+
+	var e *Error
+	if errors.As(err, &e) {
+		message, rerr := trl.renderText(trl.languageChain(lang), Key(e.Key), e.Params)
+		if rerr == nil {
+			return LocalizedError{Code: e.Key, Message: message}
+		}
+	}
+	return LocalizedError{Message: trl.Problem(string(lang), status, "").Title}
+}
+
+// WriteError writes err translated in the language of the request context
+// as JSON error envelope, see LocalizeError
+func (trl Translations) WriteError(w http.ResponseWriter, r *http.Request, status int, err error) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(ErrorEnvelope{Error: trl.LocalizeError(r.Context(), status, err)})
+}
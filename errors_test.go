@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalizeError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"errors": {"500": {"title": "Something went wrong"}, "quota": "Quota of {{limit}} exceeded"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"errors": {"500": {"title": "Etwas ist schiefgelaufen"}, "quota": "Kontingent von {{limit}} überschritten"}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(ctx context.Context, err error, expected LocalizedError) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.LocalizeError(ctx, 500, err); got != expected {
+				t.Fatalf("expected %+v, got %+v", expected, got)
+			}
+		}
+	}
+
+	de := NewContext(context.Background(), "de")
+	quota := NewError("errors.quota", "limit", 10)
+
+	t.Run("error", fn(de, quota, LocalizedError{Code: "errors.quota", Message: "Kontingent von 10 überschritten"}))
+	t.Run("wrapped", fn(de, fmt.Errorf("upload: %w", quota), LocalizedError{Code: "errors.quota", Message: "Kontingent von 10 überschritten"}))
+	t.Run("default language", fn(context.Background(), quota, LocalizedError{Code: "errors.quota", Message: "Quota of 10 exceeded"}))
+	t.Run("untranslated", fn(de, NewError("errors.unknown"), LocalizedError{Message: "Etwas ist schiefgelaufen"}))
+	t.Run("plain error", fn(de, errors.New("secret"), LocalizedError{Message: "Etwas ist schiefgelaufen"}))
+
+	cause := errors.New("disk full")
+	if err := (&Error{Key: "errors.quota", Err: cause}); !errors.Is(err, cause) || err.Error() != "errors.quota: disk full" {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", nil).WithContext(de)
+	w := httptest.NewRecorder()
+	if err := translations.WriteError(w, r, http.StatusTooManyRequests, quota); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"error":{"code":"errors.quota","message":"Kontingent von 10 überschritten"}}`; w.Code != 429 || strings.TrimSpace(w.Body.String()) != expected {
+		t.Fatalf("unexpected response %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,77 @@
+package i18n
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// experimentVariant returns the key of the variant of key selected for the experiment unit,
+// or an empty key if the key declares no variants
+func (trl Translations) experimentVariant(key Key, unit string) Key {
+	variants := trl.metadata[key].Variants
+	if len(variants) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(variants))
+	total := 0
+	for name, weight := range variants {
+		names = append(names, name)
+		total += weight
+	}
+	sort.Strings(names)
+
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(unit))
+	point := int(h.Sum64() % uint64(total))
+
+	for _, name := range names {
+		point -= variants[name]
+		if point < 0 {
+			return trl.normalizeKey(Key(string(key) + VariantSeparator + name))
+		}
+	}
+	return ""
+}
+
+// reportExperiment reports the variant served for the experiment unit to the hook
+func (trl Translations) reportExperiment(unit string, key Key, variant Key, served bool) {
+	name := ""
+	if served {
+		name = strings.TrimPrefix(string(variant), string(trl.normalizeKey(key+VariantSeparator)))
+	}
+	trl.experimentHook(unit, key, name)
+}
+
+// checkVariants reports experiment variants not translated in the default language
+func (l *loader) checkVariants(defaultLanguage Language) {
+	var keys []Key
+	for key, metadata := range l.keyMetadata {
+		if len(metadata.Variants) > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	for _, key := range keys {
+		var names []string
+		for name := range l.keyMetadata[key].Variants {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			variant := Key(string(key) + VariantSeparator + name)
+			if l.trl.normalize != nil {
+				variant = l.trl.normalize(variant)
+			}
+			if _, ok := l.translations[defaultLanguage][variant]; !ok {
+				l.report("", defaultLanguage, key, fmt.Sprintf("variant %q not found", variant))
+			}
+		}
+	}
+}
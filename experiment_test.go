@@ -0,0 +1,85 @@
+package i18n
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExperiments(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"cta": "Sign up now", "cta#short": "Join", "cta#long": "Create your free account today",
+			"@cta": {"variants": {"short": 1, "long": 3}},
+			"title": "Welcome"
+		}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"cta": "Jetzt registrieren", "cta#short": "Mitmachen", "title": "Willkommen"}`)},
+	}
+
+	type report struct {
+		unit    string
+		key     Key
+		variant string
+	}
+	var reports []report
+	translations, err := New(
+		WithFS(fsys),
+		WithDefaultLanguage("en"),
+		WithExperimentHook(func(unit string, key Key, variant string) {
+			reports = append(reports, report{unit, key, variant})
+		}),
+	).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	served := make(map[string]int)
+	for i := 0; i < 400; i++ {
+		unit := fmt.Sprintf("user-%d", i)
+		first, err := translations.GenerateExperimentTranslate("en", unit)("cta")
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, _ := translations.GenerateExperimentTranslate("en", unit)("cta")
+		if first != second {
+			t.Fatalf("expected deterministic variant for %q, got %q and %q", unit, first, second)
+		}
+		served[string(first)]++
+	}
+	if served["Join"] < 60 || served["Join"] > 140 || served["Join"]+served["Create your free account today"] != 400 {
+		t.Fatalf("unexpected distribution %v", served)
+	}
+
+	reports = nil
+	translate := translations.GenerateExperimentTranslate("de", "user-1")
+	if got, err := translate("title"); err != nil || got != "Willkommen" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+	if len(reports) != 0 {
+		t.Fatalf("expected no report for keys without variants, got %v", reports)
+	}
+
+	got, err := translate("cta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].unit != "user-1" || reports[0].key != "cta" {
+		t.Fatalf("unexpected reports %v", reports)
+	}
+	if expected := map[string]string{"Mitmachen": "short", "Jetzt registrieren": ""}[string(got)]; reports[0].variant != expected {
+		t.Fatalf("expected variant %q reported for %q, got %q", expected, got, reports[0].variant)
+	}
+
+	invalid := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"cta": "Sign up", "@cta": {"variants": {"short": 1}}}`)},
+	}
+	if issues := Validate(invalid, "en"); len(issues) != 1 {
+		t.Fatalf("expected issue for missing variant, got %v", issues)
+	}
+	negative := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"cta": "Sign up", "cta#short": "Join", "@cta": {"variants": {"short": 0}}}`)},
+	}
+	if issues := Validate(negative, "en"); len(issues) != 1 {
+		t.Fatalf("expected issue for invalid weight, got %v", issues)
+	}
+}
@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ExportFormat is a file format translations are exported in
+type ExportFormat string
+
+// Supported export formats
+const (
+	// ExportJSON exports the translations as nested JSON language file
+	ExportJSON ExportFormat = "json"
+	// ExportPO exports the translations as gettext PO file, keyed by msgctxt
+	ExportPO ExportFormat = "po"
+	// ExportCSV exports the translations as CSV file with the columns key, source, translation and description
+	ExportCSV ExportFormat = "csv"
+)
+
+// ExportFiltered exports the translations of the language whose key is the prefix or lies
+// below it, e.g. "checkout" exporting "checkout.title", for handing translators just the
+// section they work on. An empty prefix exports all translations. Keys keep the prefix such
+// that exported files can be merged back. PO and CSV files include the messages of the
+// default language as source, listing keys untranslated in the language with empty message.
+func (trl Translations) ExportFiltered(lang Language, prefix Key, format ExportFormat) ([]byte, error) {
+	lang = normalizeLanguage(string(lang))
+	store, ok := trl.translations[lang]
+	if !ok {
+		return nil, fmt.Errorf("unknown language %q", lang)
+	}
+	prefix = trl.normalizeKey(Key(strings.Trim(string(prefix), ".")))
+
+	switch format {
+	case ExportJSON:
+		return trl.exportJSON(store, prefix)
+	case ExportPO:
+		return trl.exportPO(lang, trl.exportedKeys(lang, prefix)), nil
+	case ExportCSV:
+		return trl.exportCSV(lang, trl.exportedKeys(lang, prefix))
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// exportedKeys returns the sorted keys of the language and the default language matching the prefix
+func (trl Translations) exportedKeys(lang Language, prefix Key) []Key {
+	seen := make(map[Key]bool)
+	var keys []Key
+	for _, store := range []Store{trl.translations[lang], trl.translations[trl.defaultLanguage]} {
+		for key := range store {
+			if !seen[key] && matchesPrefix(key, prefix) {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// matchesPrefix reports whether the key is the prefix or lies below it
+func matchesPrefix(key Key, prefix Key) bool {
+	return prefix == "" || key == prefix || strings.HasPrefix(string(key), string(prefix)+".")
+}
+
+// exportJSON exports the translations of the store matching the prefix as nested object
+func (trl Translations) exportJSON(store Store, prefix Key) ([]byte, error) {
+	tree := make(map[string]interface{})
+	for key, translation := range store {
+		if !matchesPrefix(key, prefix) {
+			continue
+		}
+
+		node := tree
+		fragments := strings.Split(string(key), ".")
+		for _, fragment := range fragments[:len(fragments)-1] {
+			child, ok := node[fragment].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[fragment] = child
+			}
+			node = child
+		}
+		node[fragments[len(fragments)-1]] = translation.Message
+	}
+
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(tree); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// exportPO exports the keys as gettext PO file, the key being the context of each message
+func (trl Translations) exportPO(lang Language, keys []Key) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "msgid \"\"\nmsgstr \"\"\n%s\n%s\n", poString("Content-Type: text/plain; charset=UTF-8\n"), poString("Language: "+string(lang)+"\n"))
+
+	for _, key := range keys {
+		b.WriteByte('\n')
+		if description := trl.metadata[key].Description; description != "" {
+			for _, line := range strings.Split(description, "\n") {
+				fmt.Fprintf(&b, "#. %s\n", line)
+			}
+		}
+		fmt.Fprintf(&b, "msgctxt %s\n", poString(string(key)))
+		fmt.Fprintf(&b, "msgid %s\n", poString(trl.translations[trl.defaultLanguage][key].Message))
+		fmt.Fprintf(&b, "msgstr %s\n", poString(trl.translations[lang][key].Message))
+	}
+	return b.Bytes()
+}
+
+// poString quotes s as string of a PO file
+func poString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`).Replace(s) + `"`
+}
+
+// exportCSV exports the keys as CSV file with a header row
+func (trl Translations) exportCSV(lang Language, keys []Key) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	w.Write([]string{"key", "source", "translation", "description"})
+	for _, key := range keys {
+		w.Write([]string{
+			string(key),
+			trl.translations[trl.defaultLanguage][key].Message,
+			trl.translations[lang][key].Message,
+			trl.metadata[key].Description,
+		})
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
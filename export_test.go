@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestExportFiltered(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"checkout": {
+				"title": "Checkout",
+				"@title": {"description": "heading of the \"checkout\" page"},
+				"total": "Total: {{amount}}"
+			},
+			"checkouts": "Checkouts",
+			"home": "<b>Home</b>"
+		}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"checkout": {"title": "Kasse"}, "home": "Start"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang Language, prefix Key, format ExportFormat, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.ExportFiltered(lang, prefix, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("json", fn("en", "checkout", ExportJSON, `{
+  "checkout": {
+    "title": "Checkout",
+    "total": "Total: {{amount}}"
+  }
+}
+`))
+	t.Run("json of language", fn("de", "checkout", ExportJSON, `{
+  "checkout": {
+    "title": "Kasse"
+  }
+}
+`))
+	t.Run("po", fn("de", "checkout", ExportPO, `msgid ""
+msgstr ""
+"Content-Type: text/plain; charset=UTF-8\n"
+"Language: de\n"
+
+#. heading of the "checkout" page
+msgctxt "checkout.title"
+msgid "Checkout"
+msgstr "Kasse"
+
+msgctxt "checkout.total"
+msgid "Total: {{amount}}"
+msgstr ""
+`))
+	t.Run("csv", fn("de", "home", ExportCSV, "key,source,translation,description\nhome,<b>Home</b>,Start,\n"))
+	t.Run("html", fn("en", "home", ExportJSON, "{\n  \"home\": \"<b>Home</b>\"\n}\n"))
+	t.Run("key as prefix", fn("en", "checkouts", ExportJSON, "{\n  \"checkouts\": \"Checkouts\"\n}\n"))
+
+	if _, err := translations.ExportFiltered("fr", "", ExportJSON); err == nil {
+		t.Fatal("expected error for unknown language")
+	}
+	if _, err := translations.ExportFiltered("en", "", "xliff"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"fmt"
+	"path"
+)
+
+// ExtendsKey is the key of the top-level member of a language file declaring the language file
+// it extends, e.g. "$extends": "../base/de.json". The file inherits every translation, constant
+// and metadata of the extended file it does not override. Paths are relative to the file.
+const ExtendsKey = "$extends"
+
+// extend decodes the JSON or YAML file of the name relative to the decoded file as the file
+// extended by it, inherited by inherit once the decoded file is complete. Issues of the extended
+// file are reported for the extending file, the extended file is not loaded on its own.
+func (d *decoder) extend(rootKey Key, name string, depth int) error {
+	if depth != 1 {
+		d.report(rootKey, "invalid extension, must be declared at the top level")
+		return nil
+	}
+	if d.base != nil {
+		d.report(rootKey, "invalid extension, files may extend a single file")
+		return nil
+	}
+
+	target, file, r, err := d.open(rootKey, "extension", name)
+	if file == nil {
+		return err
+	}
+	defer file.Close()
+
+	base := newDecoder(r, d.interned)
+	base.limits, base.normalize = d.limits, d.normalize
+	base.lang, base.rules = d.lang, d.rules
+	base.fsys, base.path, base.included = d.fsys, target, d.included
+	base.checksums = d.checksums
+	base.including = append(d.including[:len(d.including):len(d.including)], d.path)
+
+	decode := (*decoder).decode
+	if path.Ext(target) != ".json" {
+		decode = (*decoder).decodeYAML
+	}
+	_, err = decode(base)
+	d.issues = append(d.issues, base.issues...)
+	if err != nil {
+		return fmt.Errorf("extended file %q: %v", target, err)
+	}
+	d.base = base
+	return nil
+}
+
+// inherit completes the decoded file with the translations, constants and metadata of the file
+// it extends. Overrides must keep the intermediates of the extended translation, callers
+// passing the parameters of the extended catalog.
+func (d *decoder) inherit() {
+	if d.base == nil {
+		return
+	}
+
+	for _, key := range sortedKeys(d.base.store) {
+		override, ok := d.store[key]
+		if !ok {
+			d.store[key] = d.base.store[key]
+			continue
+		}
+		if !sameIntermediates(override.Intermediates, d.base.store[key].Intermediates) {
+			d.report(key, fmt.Sprintf("conflicting override, intermediates differ from the extended file %q", d.base.path))
+		}
+	}
+	for name, value := range d.base.constants {
+		if _, ok := d.constants[name]; !ok {
+			d.constants[name] = value
+		}
+	}
+	for key, metadata := range d.base.metadata {
+		d.metadata[key] = d.metadata[key].merge(metadata)
+	}
+}
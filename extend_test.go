@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestExtend(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base/en.json": &fstest.MapFile{Data: []byte(`{
+			"$constants": {"brand": "Nimbusec"},
+			"@title": {"description": "Title of the dashboard"},
+			"title": "@{brand} Dashboard",
+			"greeting": "Hello {{name}}"
+		}`)},
+		"base/de.yml": &fstest.MapFile{Data: []byte("de:\n  title: \"@{brand} Übersicht\"\n  greeting: Hallo {{name}}\n")},
+		"acme/en.json": &fstest.MapFile{Data: []byte(`{
+			"$extends": "../base/en.json",
+			"$constants": {"brand": "Acme"},
+			"greeting": "Howdy {{name}}"
+		}`)},
+		"acme/de.json": &fstest.MapFile{Data: []byte(`{"$extends": "../base/de.yml", "support": "Hilfe"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "name", "Anna")
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("inherited", fn("en", "title", "Acme Dashboard"))
+	t.Run("override", fn("en", "greeting", "Howdy Anna"))
+	t.Run("yaml", fn("de", "greeting", "Hallo Anna"))
+	t.Run("inherited constant", fn("de", "title", "Acme Übersicht"))
+	t.Run("addition", fn("de", "support", "Hilfe"))
+
+	if metadata, ok := translations.Metadata("title"); !ok || metadata.Description != "Title of the dashboard" {
+		t.Fatalf("expected inherited metadata, got %+v", metadata)
+	}
+
+	validate := func(files fstest.MapFS, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(files, "en")
+			if len(issues) != 1 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("conflict", validate(fstest.MapFS{
+		"base/en.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hello {{name}}"}`)},
+		"acme/en.json": &fstest.MapFile{Data: []byte(`{"$extends": "../base/en.json", "greeting": "Hello {{user}}"}`)},
+	}, `conflicting override, intermediates differ from the extended file "base/en.json"`))
+	t.Run("nested", validate(fstest.MapFS{
+		"base/en.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hello"}`)},
+		"acme/en.json": &fstest.MapFile{Data: []byte(`{"a": {"$extends": "../base/en.json", "b": "c"}}`)},
+	}, "invalid extension, must be declared at the top level"))
+	t.Run("unknown", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"$extends": "base.json", "a": "b"}`)},
+	}, `unknown extension "base.json"`))
+	t.Run("invalid extended file", validate(fstest.MapFS{
+		"en.json":   &fstest.MapFile{Data: []byte(`{"$extends": "base.json", "a": "b"}`)},
+		"base.json": &fstest.MapFile{Data: []byte(`{"c": "{{d"}`)},
+	}, "invalid format of intermediates"))
+
+	included, err := New(WithFS(fstest.MapFS{
+		"en.json":     &fstest.MapFile{Data: []byte(`{"$include": "shared.json", "a": "b"}`)},
+		"shared.json": &fstest.MapFile{Data: []byte(`{"$extends": "base.json", "c": "d"}`)},
+		"base.json":   &fstest.MapFile{Data: []byte(`{"e": "f"}`)},
+	}), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := included.GenerateTranslate("en")("e"); err != nil || got != "f" {
+		t.Fatalf("expected extension of included file to be inherited, got %q: %v", got, err)
+	}
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import "html/template"
+
+const (
+	// FormsKey is the key prefix of form fields, e.g. "forms.signup.email.label"
+	FormsKey Key = "forms"
+	// SharedForm is the form whose fields apply to every form lacking their translation,
+	// e.g. "forms.shared.email.label"
+	SharedForm = "shared"
+)
+
+// FormField holds the translated texts of a form field
+type FormField struct {
+	Label       template.HTML
+	Placeholder template.HTML
+	Help        template.HTML
+}
+
+// FormField translates the label, placeholder and help text of the field of the form
+// in the language. Texts not translated for the form are taken of the shared form.
+// The label falls back to the field name, the placeholder and help text are empty.
+// Only missing labels are reported to the missing key hook.
+func (trl Translations) FormField(lang string, form string, field string) FormField {
+	translate := trl.GenerateTranslate(lang)
+	chain := trl.languageChain(trl.resolveLanguage(lang))
+	text := func(part string) (template.HTML, bool) {
+		for _, f := range []string{form, SharedForm} {
+			key := FormsKey.Append(f).Append(field).Append(part)
+			if !trl.exists(chain, trl.normalizeKey(key)) {
+				continue
+			}
+			translated, _ := translate(string(key))
+			return translated, true
+		}
+		return "", false
+	}
+
+	label, ok := text("label")
+	if !ok {
+		trl.reportMissing(chain.requested, trl.normalizeKey(FormsKey.Append(form).Append(field).Append("label")))
+	}
+	placeholder, _ := text("placeholder")
+	help, _ := text("help")
+
+	formField := FormField{
+		Label:       label,
+		Placeholder: placeholder,
+		Help:        help,
+	}
+	if formField.Label == "" {
+		formField.Label = template.HTML(template.HTMLEscapeString(field))
+	}
+	return formField
+}
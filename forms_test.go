@@ -0,0 +1,44 @@
+package i18n
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFormField(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"forms": {
+			"signup": {"email": {"label": "Your email", "help": "We never share it"}},
+			"shared": {"email": {"label": "Email", "placeholder": "name@example.com"}, "name": {"label": "Name"}}
+		}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(form string, field string, expected FormField) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.FormField("en", form, field); got != expected {
+				t.Fatalf("expected %+v, got %+v", expected, got)
+			}
+		}
+	}
+
+	t.Run("form", fn("signup", "email", FormField{Label: "Your email", Placeholder: "name@example.com", Help: "We never share it"}))
+	t.Run("shared", fn("login", "email", FormField{Label: "Email", Placeholder: "name@example.com"}))
+	t.Run("shared label", fn("signup", "name", FormField{Label: "Name"}))
+	t.Run("field name", fn("signup", "<zip>", FormField{Label: "&lt;zip&gt;"}))
+
+	tmpl := template.Must(template.New("").Funcs(translations.TemplateFuncs()).Parse(`{{with formField "en" "signup" "email"}}<label>{{.Label}}</label>{{end}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "<label>Your email</label>"; b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+}
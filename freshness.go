@@ -0,0 +1,69 @@
+package i18n
+
+import "time"
+
+// StaleTranslation describes a translation whose source in the default language
+// was modified after the translation itself
+type StaleTranslation struct {
+	Language Language
+	Key      Key
+	// SourceModified is the time the key was last modified in the default language
+	SourceModified time.Time
+	// Modified is the time the key was last modified in the language
+	Modified time.Time
+}
+
+// modifiedTimes determines the time each key of each language was last modified.
+// The modification time declared by the metadata of the key precedes the
+// modification time of the file the key was loaded from.
+func (l *loader) modifiedTimes() map[Language]map[Key]time.Time {
+	modified := make(map[Language]map[Key]time.Time, len(l.origins))
+	for lang, origins := range l.origins {
+		modified[lang] = make(map[Key]time.Time, len(origins))
+		for key, filePath := range origins {
+			if t, ok := l.keyModified[filePath][key]; ok {
+				modified[lang][key] = t
+			} else if t, ok := l.fileModified[filePath]; ok && !t.IsZero() {
+				modified[lang][key] = t
+			}
+		}
+	}
+	return modified
+}
+
+// Modified returns the time the key was last modified in the language, reporting
+// whether the language defines the key and its modification time is known
+func (trl Translations) Modified(lang Language, key Key) (time.Time, bool) {
+	modified, ok := trl.modified[normalizeLanguage(string(lang))][trl.normalizeKey(key)]
+	return modified, ok
+}
+
+// Stale reports the translations of every language other than the default language
+// whose source in the default language was modified after the translation, ordered
+// by language and key. Translations lacking a modification time are never stale.
+func (trl Translations) Stale() []StaleTranslation {
+	sources := trl.modified[trl.defaultLanguage]
+
+	var stale []StaleTranslation
+	for _, lang := range sortedLanguages(trl.translations) {
+		if lang == trl.defaultLanguage {
+			continue
+		}
+
+		for _, key := range sortedKeys(trl.translations[lang]) {
+			source, ok := sources[key]
+			if !ok {
+				continue
+			}
+			if modified, ok := trl.modified[lang][key]; ok && source.After(modified) {
+				stale = append(stale, StaleTranslation{
+					Language:       lang,
+					Key:            key,
+					SourceModified: source,
+					Modified:       modified,
+				})
+			}
+		}
+	}
+	return stale
+}
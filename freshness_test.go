@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestStale(t *testing.T) {
+	january := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	february := time.Date(2021, time.February, 1, 0, 0, 0, 0, time.UTC)
+	march := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"title": "Title",
+			"save": "Save",
+			"cancel": "Cancel",
+			"@cancel": {"modified": "2021-03-01T00:00:00Z"}
+		}`), ModTime: february},
+		"de.json": &fstest.MapFile{Data: []byte(`{
+			"title": "Titel",
+			"save": "Speichern",
+			"@save": {"modified": "2021-03-01T00:00:00Z"},
+			"cancel": "Abbrechen"
+		}`), ModTime: january},
+		"fr.json": &fstest.MapFile{Data: []byte(`{
+			"title": "Titre"
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang Language, key Key, expected time.Time, known bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			modified, ok := translations.Modified(lang, key)
+			if ok != known || !modified.Equal(expected) {
+				t.Fatalf("expected %v (%v), got %v (%v)", expected, known, modified, ok)
+			}
+		}
+	}
+
+	t.Run("file", fn("en", "title", february, true))
+	t.Run("explicit", fn("en", "cancel", march, true))
+	t.Run("explicit translation", fn("de", "save", march, true))
+	t.Run("unknown time", fn("fr", "title", time.Time{}, false))
+	t.Run("missing key", fn("fr", "save", time.Time{}, false))
+
+	stale := translations.Stale()
+	expected := []StaleTranslation{
+		{Language: "de", Key: "cancel", SourceModified: march, Modified: january},
+		{Language: "de", Key: "title", SourceModified: february, Modified: january},
+	}
+	if len(stale) != len(expected) {
+		t.Fatalf("expected %+v, got %+v", expected, stale)
+	}
+	for i := range expected {
+		if stale[i].Language != expected[i].Language || stale[i].Key != expected[i].Key ||
+			!stale[i].SourceModified.Equal(expected[i].SourceModified) || !stale[i].Modified.Equal(expected[i].Modified) {
+			t.Fatalf("expected %+v, got %+v", expected[i], stale[i])
+		}
+	}
+}
+
+func TestInvalidModified(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"title": "Title", "@title": {"modified": "yesterday"}}`)},
+	}
+
+	_, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	issue, ok := err.(Issue)
+	if !ok || issue.Key != "title" {
+		t.Fatalf("expected invalid metadata issue, got %v", err)
+	}
+}
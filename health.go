@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Pinger is implemented by sources able to check the reachability of their backend
+// without loading translations, e.g. RemoteBundle. See Catalog.Check.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Health describes the state of the translations of a catalog, e.g. for readiness probes
+type Health struct {
+	// Languages is the number of languages of the current translations
+	Languages int
+	// Loaded is the time the translations were last loaded or refreshed successfully
+	Loaded time.Time
+	// Failures is the number of consecutive failed refreshes and updates
+	Failures int
+	// Err is the error of the last failed refresh or update, nil once succeeded again
+	Err error
+	// Failed is the time of the last failed refresh or update
+	Failed time.Time
+	// Stale reports whether the last refresh or update failed, the translations served
+	// being the ones loaded last, and Age is the time passed since they were loaded
+	Stale bool
+	Age   time.Duration
+	// Backend is the error pinging the source of the catalog, nil if reachable or
+	// the source does not implement Pinger
+	Backend error
+}
+
+// catalogHealth tracks the outcome of loading the translations of a catalog
+type catalogHealth struct {
+	mu       sync.Mutex
+	loaded   time.Time
+	failures int
+	err      error
+	failed   time.Time
+}
+
+// record records the outcome of loading translations at the time
+func (h *catalogHealth) record(err error, at time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err != nil {
+		h.failures++
+		h.err, h.failed = err, at
+		return
+	}
+	h.loaded = at
+	h.failures, h.err = 0, nil
+}
+
+// Healthy returns an error if the catalog does not hold translations of the default
+// language or the last refresh or update failed, nil otherwise. Stale translations are
+// tolerated up to the age set by WithMaxStaleness. The translations are still served in
+// either case, the last successfully loaded ones being kept.
+func (c *Catalog) Healthy() error {
+	trl := c.Translations()
+	health := c.health.snapshot(trl)
+	if health.Languages == 0 {
+		return errors.New("no translations loaded")
+	}
+	if health.Stale && health.Age > trl.maxStaleness {
+		return fmt.Errorf("loading translations failed %d times, last loaded at %s: %v", health.Failures, health.Loaded.Format(time.RFC3339), health.Err)
+	}
+	return nil
+}
+
+// Check returns the health of the catalog, pinging the source of the catalog within
+// the context if it implements Pinger
+func (c *Catalog) Check(ctx context.Context) Health {
+	health := c.health.snapshot(c.Translations())
+	if pinger, ok := c.source.(Pinger); ok {
+		health.Backend = pinger.Ping(ctx)
+	}
+	return health
+}
+
+// snapshot returns the health of the translations as recorded
+func (h *catalogHealth) snapshot(trl Translations) Health {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	languages := 0
+	if _, ok := trl.translations[trl.defaultLanguage]; ok {
+		languages = len(trl.translations)
+	}
+	return Health{
+		Languages: languages,
+		Loaded:    h.loaded,
+		Failures:  h.failures,
+		Err:       h.err,
+		Failed:    h.failed,
+		Stale:     h.err != nil,
+		Age:       time.Since(h.loaded),
+	}
+}
+
+// Ping requests the headers of the bundle, verifying the endpoint is reachable and
+// serves the bundle
+func (r RemoteBundle) Ping(ctx context.Context) error {
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, r.URL, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to reach %q, status %d", r.URL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type pingingSource struct {
+	SourceFunc
+	err error
+}
+
+func (s pingingSource) Ping(ctx context.Context) error {
+	return s.err
+}
+
+func TestHealth(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+	}
+
+	var failure error
+	source := pingingSource{
+		SourceFunc: func(ctx context.Context, current Translations) (Translations, error) {
+			if failure != nil {
+				return Translations{}, failure
+			}
+			return current, nil
+		},
+		err: errors.New("unreachable"),
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithRefreshSource(source)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	if err := catalog.Healthy(); err != nil {
+		t.Fatalf("expected loaded catalog to be healthy, got %v", err)
+	}
+	loaded := catalog.Check(context.Background()).Loaded
+
+	failure = errors.New("unavailable")
+	catalog.Refresh(context.Background())
+	catalog.Refresh(context.Background())
+	if err := catalog.Healthy(); err == nil {
+		t.Fatal("expected catalog failing to refresh to be unhealthy")
+	}
+	health := catalog.Check(context.Background())
+	if health.Failures != 2 || health.Err != failure || health.Loaded != loaded || health.Failed.IsZero() {
+		t.Fatalf("expected 2 failures since the initial load, got %+v", health)
+	}
+	if health.Backend == nil {
+		t.Fatal("expected unreachable backend to be reported")
+	}
+	if got, _ := catalog.GenerateTranslate("en")("a"); got != "hello" {
+		t.Fatalf("expected translations to be kept, got %q", got)
+	}
+
+	failure = nil
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Healthy(); err != nil {
+		t.Fatalf("expected refreshed catalog to be healthy, got %v", err)
+	}
+	if health := catalog.Check(context.Background()); health.Failures != 0 || health.Languages != 1 {
+		t.Fatalf("expected failures to be reset, got %+v", health)
+	}
+
+	if err := NewCatalog(Translations{}).Healthy(); err == nil {
+		t.Fatal("expected catalog without translations to be unhealthy")
+	}
+}
+
+func TestRemoteBundlePing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("expected HEAD request, got %s", r.Method)
+		}
+		if r.URL.Path != "/bundle.zip" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	if err := (RemoteBundle{URL: server.URL + "/bundle.zip"}).Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := (RemoteBundle{URL: server.URL + "/missing.zip"}).Ping(context.Background()); err == nil {
+		t.Fatal("expected error for missing bundle")
+	}
+}
+
+func TestHealthStaleness(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+	}
+	failing := SourceFunc(func(ctx context.Context, current Translations) (Translations, error) {
+		return Translations{}, errors.New("unavailable")
+	})
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithRefreshSource(failing), WithMaxStaleness(time.Hour)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	if err := catalog.Refresh(context.Background()); err == nil {
+		t.Fatal("expected refresh to fail")
+	}
+	if err := catalog.Healthy(); err != nil {
+		t.Fatalf("expected stale translations to be tolerated, got %v", err)
+	}
+	if health := catalog.Check(context.Background()); !health.Stale || health.Age <= 0 {
+		t.Fatalf("expected staleness to be reported, got %+v", health)
+	}
+	if got, _ := catalog.GenerateTranslate("en")("a"); got != "hello" {
+		t.Fatalf("expected stale translations to be served, got %q", got)
+	}
+
+	catalog.health.loaded = time.Now().Add(-2 * time.Hour)
+	if err := catalog.Healthy(); err == nil {
+		t.Fatal("expected translations stale for too long to be unhealthy")
+	}
+}
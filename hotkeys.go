@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// statsHotKeys is the number of hottest keys reported by Stats
+const statsHotKeys = 10
+
+// KeyCount is the number of translations of a key
+type KeyCount struct {
+	Key Key
+	// Count is the number of translations, estimated from the sample if sampled
+	Count uint64
+}
+
+// keyCounter counts the translations of each key, sampling a fraction of them
+type keyCounter struct {
+	sampling float64
+	counts   sync.Map
+}
+
+// count counts a translation of the key if sampled
+func (c *keyCounter) count(key Key) {
+	if c.sampling < 1 && rand.Float64() >= c.sampling {
+		return
+	}
+
+	counter, ok := c.counts.Load(key)
+	if !ok {
+		counter, _ = c.counts.LoadOrStore(key, new(uint64))
+	}
+	atomic.AddUint64(counter.(*uint64), 1)
+}
+
+// hottest returns the n keys translated most often in descending order of their count,
+// equally counted keys in order. All keys are returned for negative n.
+func (c *keyCounter) hottest(n int) []KeyCount {
+	var counts []KeyCount
+	c.counts.Range(func(key, counter interface{}) bool {
+		count := atomic.LoadUint64(counter.(*uint64))
+		if c.sampling < 1 {
+			count = uint64(float64(count) / c.sampling)
+		}
+		counts = append(counts, KeyCount{Key: key.(Key), Count: count})
+		return true
+	})
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Key < counts[j].Key
+	})
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// HotKeys returns the n keys translated most often since the translations were created,
+// e.g. for choosing the namespaces to preload or cache. Counting is enabled by WithKeyStats,
+// without it no keys are returned. Only keys translated successfully are counted. Counts are
+// kept across reloads of the translations.
+func (trl Translations) HotKeys(n int) []KeyCount {
+	if trl.keyCounter == nil {
+		return nil
+	}
+	return trl.keyCounter.hottest(n)
+}
@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHotKeys(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "a", "b": "b", "c": "c"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithKeyStats(1)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translate := translations.GenerateTranslate("en")
+	for _, key := range []string{"b", "a", "b", "c", "b", "a", "missing"} {
+		translate(key)
+	}
+
+	// counts are kept across reloads
+	reloaded, err := translations.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	reloaded.GenerateTranslate("en")("c")
+
+	expected := []KeyCount{{Key: "b", Count: 3}, {Key: "a", Count: 2}}
+	if got := reloaded.HotKeys(2); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+	// unknown keys are not counted
+	if got := reloaded.Stats().HotKeys; len(got) != 3 {
+		t.Fatalf("expected all 3 known keys to be reported by stats, got %v", got)
+	}
+
+	if got := New(WithFS(fsys), WithDefaultLanguage("en")).HotKeys(10); got != nil {
+		t.Fatalf("expected no hot keys without counting, got %v", got)
+	}
+}
+
+func TestHotKeysSampling(t *testing.T) {
+	counter := &keyCounter{sampling: 0.5}
+	for i := 0; i < 10000; i++ {
+		counter.count("a")
+	}
+
+	// the estimate is within a generous margin of the actual count
+	if got := counter.hottest(1)[0].Count; got < 9000 || got > 11000 {
+		t.Fatalf("expected estimate of about 10000, got %d", got)
+	}
+}
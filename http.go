@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrorsKey is the key prefix of localized error responses. Each error is translated by
+// a title and an optional detail, e.g. "errors.404.title" and "errors.404.detail" for a
+// status code or "errors.quota_exceeded.title" for an application error code.
+const ErrorsKey Key = "errors"
+
+// Problem is a localized error response following the problem details of RFC 7807
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+
+	// Language is the language the problem is translated in
+	Language Language `json:"-"`
+}
+
+// RequestLanguage returns the loaded language best matching the Accept-Language header
+// of the request, preferring languages of higher quality. The default language is
+// returned if no loaded language matches.
+func (trl Translations) RequestLanguage(r *http.Request) Language {
+	for _, requested := range parseAcceptLanguage(r.Header.Get("Accept-Language")) {
+		if lang, ok := trl.match(requested); ok {
+			return lang
+		}
+	}
+	return trl.defaultLanguage
+}
+
+// parseAcceptLanguage returns the languages of an Accept-Language header ordered by
+// their quality, e.g. "de-AT,de;q=0.9,en;q=0.8". Wildcards and languages of zero
+// quality are omitted.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		lang    string
+		quality float64
+	}
+
+	var languages []weighted
+	for _, part := range strings.Split(header, ",") {
+		lang, params := part, ""
+		if i := strings.Index(part, ";"); i >= 0 {
+			lang, params = part[:i], strings.TrimSpace(part[i+1:])
+		}
+
+		lang = strings.TrimSpace(lang)
+		if lang == "" || lang == "*" {
+			continue
+		}
+
+		quality := 1.0
+		if strings.HasPrefix(params, "q=") {
+			parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality <= 0 {
+			continue
+		}
+		languages = append(languages, weighted{lang: lang, quality: quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool { return languages[i].quality > languages[j].quality })
+
+	ordered := make([]string, 0, len(languages))
+	for _, l := range languages {
+		ordered = append(ordered, l.lang)
+	}
+	return ordered
+}
+
+// Problem returns the problem of the status code translated in the language, preferring the
+// translation of the application error code if not empty. The title falls back to the
+// status text if neither is translated. Parameters are interpolated without escaping.
+func (trl Translations) Problem(targetLang string, status int, code string, params ...interface{}) Problem {
+	lang := trl.resolveLanguage(targetLang)
+	chain := trl.languageChain(lang)
+
+	problem := Problem{
+		Title:    http.StatusText(status),
+		Status:   status,
+		Language: lang,
+	}
+	if len(chain.languages) > 0 {
+		problem.Language = chain.languages[0]
+	}
+
+	var prefixes []Key
+	if code != "" {
+		prefixes = append(prefixes, ErrorsKey.Append(code))
+	}
+	prefixes = append(prefixes, ErrorsKey.Append(strconv.Itoa(status)))
+
+	// only the status fallback is reported missing, the details being optional
+	for i, prefix := range prefixes {
+		last := i == len(prefixes)-1
+		if !last && !trl.exists(chain, trl.normalizeKey(prefix.Append("title"))) {
+			continue
+		}
+		title, err := trl.renderText(chain, prefix.Append("title"), params)
+		if err != nil {
+			continue
+		}
+
+		problem.Title = title
+		if detail := prefix.Append("detail"); trl.exists(chain, trl.normalizeKey(detail)) {
+			problem.Detail, _ = trl.renderText(chain, detail, params)
+		}
+		break
+	}
+	return problem
+}
+
+// renderText renders the translation of key without escaping the parameter values
+func (trl Translations) renderText(chain languageChain, key Key, params []interface{}) (string, error) {
+	key = trl.normalizeKey(key)
+	translation, err := trl.lookup(chain, key, "")
+	if err != nil {
+		return "", err
+	}
+
+	lookup, err := createIntermediateLookup(params)
+	if err != nil {
+		return "", err
+	}
+	return trl.interpolate(chain.requested, key, translation, lookup, nil)
+}
+
+// WriteProblem writes the problem of the status code translated in the language of the
+// request as problem details JSON, see Problem
+func (trl Translations) WriteProblem(w http.ResponseWriter, r *http.Request, status int, code string, params ...interface{}) error {
+	problem := trl.Problem(string(trl.RequestLanguage(r)), status, code, params...)
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Content-Language", problem.Language.Tag())
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(problem)
+}
+
+// ErrorPage is the default template of error pages, executed with the Problem
+var ErrorPage = template.Must(template.New("error").Parse(`<!DOCTYPE html>
+<html lang="{{.Language.Tag}}" dir="{{.Language.Direction}}">
+<head><meta charset="utf-8"><title>{{.Title}}</title></head>
+<body><h1>{{.Title}}</h1>{{with .Detail}}<p>{{.}}</p>{{end}}</body>
+</html>
+`))
+
+// WriteErrorPage writes the problem of the status code translated in the language of the
+// request as HTML page executing tmpl with the Problem. ErrorPage is used if tmpl is nil.
+func (trl Translations) WriteErrorPage(w http.ResponseWriter, r *http.Request, tmpl *template.Template, status int, code string, params ...interface{}) error {
+	if tmpl == nil {
+		tmpl = ErrorPage
+	}
+
+	problem := trl.Problem(string(trl.RequestLanguage(r)), status, code, params...)
+	problem.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Language", problem.Language.Tag())
+	w.WriteHeader(status)
+	return tmpl.Execute(w, problem)
+}
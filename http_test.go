@@ -0,0 +1,130 @@
+package i18n
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	fn := func(header string, expected []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := parseAcceptLanguage(header); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("expected %v, got %v", expected, got)
+			}
+		}
+	}
+
+	t.Run("single", fn("de", []string{"de"}))
+	t.Run("quality", fn("en;q=0.8, de-AT, de;q=0.9", []string{"de-AT", "de", "en"}))
+	t.Run("equal quality", fn("fr;q=0.5,it;q=0.5", []string{"fr", "it"}))
+	t.Run("wildcard and zero quality", fn("*;q=0.1, de;q=0", []string{}))
+	t.Run("invalid quality", fn("de;q=high, en", []string{"en"}))
+	t.Run("empty", fn("", []string{}))
+}
+
+func newErrorTranslations(t *testing.T) Translations {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"errors": {
+			"404": {"title": "Not found", "detail": "The page {{path}} does not exist"},
+			"quota_exceeded": {"title": "Quota exceeded"}
+		}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"errors": {
+			"404": {"title": "Nicht gefunden", "detail": "Die Seite {{path}} existiert nicht"}
+		}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return translations
+}
+
+func TestRequestLanguage(t *testing.T) {
+	translations := newErrorTranslations(t)
+
+	fn := func(header string, expected Language) func(t *testing.T) {
+		return func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept-Language", header)
+			if got := translations.RequestLanguage(r); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("preferred", fn("de-AT,en;q=0.5", "de"))
+	t.Run("quality", fn("en;q=0.5,de;q=0.8", "de"))
+	t.Run("unavailable", fn("fr,it", "en"))
+	t.Run("missing", fn("", "en"))
+}
+
+func TestProblem(t *testing.T) {
+	translations := newErrorTranslations(t)
+
+	fn := func(lang string, status int, code string, expected Problem) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.Problem(lang, status, code, "path", "/<a>"); got != expected {
+				t.Fatalf("expected %+v, got %+v", expected, got)
+			}
+		}
+	}
+
+	t.Run("status", fn("de", 404, "", Problem{Title: "Nicht gefunden", Status: 404, Detail: "Die Seite /<a> existiert nicht", Language: "de"}))
+	t.Run("code", fn("en", 429, "quota_exceeded", Problem{Title: "Quota exceeded", Status: 429, Language: "en"}))
+	t.Run("code fallback", fn("de", 429, "quota_exceeded", Problem{Title: "Quota exceeded", Status: 429, Language: "de"}))
+	t.Run("unknown code", fn("de", 404, "unknown", Problem{Title: "Nicht gefunden", Status: 404, Detail: "Die Seite /<a> existiert nicht", Language: "de"}))
+	t.Run("status text", fn("de", 500, "", Problem{Title: "Internal Server Error", Status: 500, Language: "de"}))
+}
+
+func TestWriteProblem(t *testing.T) {
+	translations := newErrorTranslations(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	r.Header.Set("Accept-Language", "de-DE")
+	w := httptest.NewRecorder()
+	if err := translations.WriteProblem(w, r, 404, "", "path", "/missing"); err != nil {
+		t.Fatal(err)
+	}
+
+	if w.Code != 404 || w.Header().Get("Content-Type") != "application/problem+json" || w.Header().Get("Content-Language") != "de" {
+		t.Fatalf("unexpected response %d %v", w.Code, w.Header())
+	}
+
+	var problem map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{
+		"title":    "Nicht gefunden",
+		"status":   float64(404),
+		"detail":   "Die Seite /missing existiert nicht",
+		"instance": "/missing",
+	}
+	if !reflect.DeepEqual(problem, expected) {
+		t.Fatalf("expected %v, got %v", expected, problem)
+	}
+}
+
+func TestWriteErrorPage(t *testing.T) {
+	translations := newErrorTranslations(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/missing", nil)
+	r.Header.Set("Accept-Language", "de")
+	w := httptest.NewRecorder()
+	if err := translations.WriteErrorPage(w, r, nil, 404, "", "path", "<script>"); err != nil {
+		t.Fatal(err)
+	}
+
+	body := w.Body.String()
+	for _, expected := range []string{`<html lang="de" dir="ltr">`, "<h1>Nicht gefunden</h1>", "Die Seite &lt;script&gt; existiert nicht"} {
+		if !strings.Contains(body, expected) {
+			t.Fatalf("expected %q in %q", expected, body)
+		}
+	}
+}
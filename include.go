@@ -0,0 +1,93 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+)
+
+// IncludeKey is the key of a member including the translations of another file at its level,
+// e.g. "$include": "shared/countries.json". Paths are relative to the including file.
+const IncludeKey = "$include"
+
+// include decodes the JSON or YAML file of the name relative to the decoded file below the
+// root key as if its members were part of the decoded file. Issues of the included file are
+// reported for the including file, only syntax errors abort the decoding.
+func (d *decoder) include(rootKey Key, source string, name string, depth int) error {
+	target, file, r, err := d.open(rootKey, "include", name)
+	if file == nil {
+		return err
+	}
+	defer file.Close()
+
+	fragment := (*decoder).document
+	if path.Ext(target) != ".json" {
+		fragment = (*decoder).yamlDocument
+	}
+
+	included := *d
+	included.r, included.tokens = r, json.NewDecoder(r)
+	included.path = target
+	included.including = append(d.including[:len(d.including):len(d.including)], d.path)
+	err = fragment(&included, rootKey, source, depth)
+	// an extension declared by the included file applies to the including file
+	d.issues, d.base = included.issues, included.base
+	if err != nil {
+		return fmt.Errorf("included file %q: %v", target, err)
+	}
+	return nil
+}
+
+// open opens the JSON or YAML file of the name relative to the decoded file referred to by
+// the directive, e.g. "include". Invalid paths, references of files including the decoded
+// file and unknown files are reported, the file being nil then.
+func (d *decoder) open(rootKey Key, directive string, name string) (string, fs.File, io.Reader, error) {
+	target := path.Join(path.Dir(d.path), name)
+	if d.fsys == nil || name == "" || path.IsAbs(name) || !fs.ValidPath(target) {
+		d.report(rootKey, fmt.Sprintf("invalid %s %q, must be a path within the file system", directive, name))
+		return "", nil, nil, nil
+	}
+
+	switch path.Ext(target) {
+	case ".json", ".yml", ".yaml":
+	default:
+		d.report(rootKey, fmt.Sprintf("invalid %s %q, only json and yaml files are supported", directive, name))
+		return "", nil, nil, nil
+	}
+
+	for _, including := range append(d.including, d.path) {
+		if including == target {
+			d.report(rootKey, fmt.Sprintf("invalid %s %q, files must not refer to themselves", directive, name))
+			return "", nil, nil, nil
+		}
+	}
+
+	if _, ok := d.checksums[target]; d.checksums != nil && !ok {
+		d.report(rootKey, fmt.Sprintf("invalid %s %q, file not listed in manifest", directive, name))
+		return "", nil, nil, nil
+	}
+
+	file, err := d.fsys.Open(target)
+	if errors.Is(err, fs.ErrNotExist) {
+		d.report(rootKey, fmt.Sprintf("unknown %s %q", directive, name))
+		return "", nil, nil, nil
+	}
+	if err != nil {
+		return "", nil, nil, err
+	}
+	d.included[target] = true
+
+	var r io.Reader = file
+	if d.limits.MaxFileSize > 0 {
+		r = &limitedReader{r: file, n: d.limits.MaxFileSize}
+	}
+	r, err = decodeUnicode(r)
+	if err != nil {
+		file.Close()
+		return "", nil, nil, fmt.Errorf("file %q: %v", target, err)
+	}
+	return target, file, r, nil
+}
@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestInclude(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"title": "Shop",
+			"countries": {"$include": "shared/countries.en.json"},
+			"$include": "shared/legal.yml"
+		}`)},
+		"de.yml":                   &fstest.MapFile{Data: []byte("de:\n  title: Laden\n  $include: shared/legal.yml\n")},
+		"shared/countries.en.json": &fstest.MapFile{Data: []byte(`{"at": "Austria", "$include": "eu.json"}`)},
+		"shared/eu.json":           &fstest.MapFile{Data: []byte(`{"eu": "European Union"}`)},
+		"shared/legal.yml":         &fstest.MapFile{Data: []byte("legal:\n  imprint: Nimbusec GmbH\n")},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("nested", fn("en", "countries.at", "Austria"))
+	t.Run("transitive", fn("en", "countries.eu", "European Union"))
+	t.Run("root", fn("en", "legal.imprint", "Nimbusec GmbH"))
+	t.Run("shared across languages", fn("de", "legal.imprint", "Nimbusec GmbH"))
+
+	validate := func(files fstest.MapFS, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(files, "en")
+			if len(issues) != 1 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("unknown", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "b", "$include": "missing.json"}`)},
+	}, `unknown include "missing.json"`))
+	t.Run("cycle", validate(fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"a": "b", "$include": "other.json"}`)},
+		"other.json": &fstest.MapFile{Data: []byte(`{"c": "d", "$include": "en.json"}`)},
+	}, `invalid include "en.json", files must not refer to themselves`))
+	t.Run("outside", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "b", "$include": "../secrets.json"}`)},
+	}, `invalid include "../secrets.json", must be a path within the file system`))
+	t.Run("format", validate(fstest.MapFS{
+		"en.json":   &fstest.MapFile{Data: []byte(`{"a": "b", "$include": "legal.txt"}`)},
+		"legal.txt": &fstest.MapFile{Data: []byte("Nimbusec GmbH")},
+	}, `invalid include "legal.txt", only json and yaml files are supported`))
+	t.Run("not a path", validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "b", "$include": ["x.json"]}`)},
+	}, "invalid include, must be the path of a file"))
+	t.Run("not included", validate(fstest.MapFS{
+		"en.json":     &fstest.MapFile{Data: []byte(`{"a": "b"}`)},
+		"common.json": &fstest.MapFile{Data: []byte(`{"c": "d"}`)},
+	}, `invalid file naming scheme "common", allowed are only two letter codes and private use tags`))
+}
@@ -0,0 +1,142 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+)
+
+// intervalPatterns are the patterns of date ranges of a language, omitting the fields
+// shared by both dates. The fields of the end of the range are suffixed by 2, e.g. {d2}.
+type intervalPatterns struct {
+	// day formats ranges within a single day
+	day string
+	// month formats ranges within a month
+	month string
+	// year formats ranges within a year
+	year string
+	// years formats ranges spanning years
+	years string
+}
+
+// isoIntervalPatterns format ranges of languages lacking patterns in ISO 8601 notation
+var isoIntervalPatterns = intervalPatterns{
+	day:   "{y}-{MM}-{dd}",
+	month: "{y}-{MM}-{dd} – {y2}-{MM2}-{dd2}",
+	year:  "{y}-{MM}-{dd} – {y2}-{MM2}-{dd2}",
+	years: "{y}-{MM}-{dd} – {y2}-{MM2}-{dd2}",
+}
+
+// intervalPatternsByLanguage lists the patterns following the CLDR interval formats
+var intervalPatternsByLanguage = map[Language]intervalPatterns{
+	"de": {
+		day:   "{d}. {MMMM} {y}",
+		month: "{d}.–{d2}. {MMMM} {y}",
+		year:  "{d}. {MMMM} – {d2}. {MMMM2} {y}",
+		years: "{d}. {MMMM} {y} – {d2}. {MMMM2} {y2}",
+	},
+	"en": {
+		day:   "{MMM} {d}, {y}",
+		month: "{MMM} {d}–{d2}, {y}",
+		year:  "{MMM} {d} – {MMM2} {d2}, {y}",
+		years: "{MMM} {d}, {y} – {MMM2} {d2}, {y2}",
+	},
+	"en-gb": {
+		day:   "{d} {MMM} {y}",
+		month: "{d}–{d2} {MMM} {y}",
+		year:  "{d} {MMM} – {d2} {MMM2} {y}",
+		years: "{d} {MMM} {y} – {d2} {MMM2} {y2}",
+	},
+	"es": {
+		day:   "{d} de {MMMM} de {y}",
+		month: "{d}–{d2} de {MMMM} de {y}",
+		year:  "{d} de {MMMM} – {d2} de {MMMM2} de {y}",
+		years: "{d} de {MMMM} de {y} – {d2} de {MMMM2} de {y2}",
+	},
+	"fr": {
+		day:   "{d} {MMMM} {y}",
+		month: "{d}–{d2} {MMMM} {y}",
+		year:  "{d} {MMMM} – {d2} {MMMM2} {y}",
+		years: "{d} {MMMM} {y} – {d2} {MMMM2} {y2}",
+	},
+	"it": {
+		day:   "{d} {MMMM} {y}",
+		month: "{d}–{d2} {MMMM} {y}",
+		year:  "{d} {MMMM} – {d2} {MMMM2} {y}",
+		years: "{d} {MMMM} {y} – {d2} {MMMM2} {y2}",
+	},
+	"ja": {
+		day:   "{y}年{M}月{d}日",
+		month: "{y}年{M}月{d}日～{d2}日",
+		year:  "{y}年{M}月{d}日～{M2}月{d2}日",
+		years: "{y}年{M}月{d}日～{y2}年{M2}月{d2}日",
+	},
+	"nl": {
+		day:   "{d} {MMMM} {y}",
+		month: "{d}–{d2} {MMMM} {y}",
+		year:  "{d} {MMMM} – {d2} {MMMM2} {y}",
+		years: "{d} {MMMM} {y} – {d2} {MMMM2} {y2}",
+	},
+	"pt": {
+		day:   "{d} de {MMMM} de {y}",
+		month: "{d}–{d2} de {MMMM} de {y}",
+		year:  "{d} de {MMMM} – {d2} de {MMMM2} de {y}",
+		years: "{d} de {MMMM} de {y} – {d2} de {MMMM2} de {y2}",
+	},
+	"zh": {
+		day:   "{y}年{M}月{d}日",
+		month: "{y}年{M}月{d}日至{d2}日",
+		year:  "{y}年{M}月{d}日至{M2}月{d2}日",
+		years: "{y}年{M}月{d}日至{y2}年{M2}月{d2}日",
+	},
+}
+
+// intervalPatternsOf returns the interval patterns of the language
+func intervalPatternsOf(lang Language) intervalPatterns {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if patterns, ok := intervalPatternsByLanguage[candidate]; ok {
+			return patterns
+		}
+	}
+	return isoIntervalPatterns
+}
+
+// FormatDateRange formats the range of dates from and to in the language, not repeating the
+// month and year shared by both dates, e.g. "Jan 3–5, 2025" in English or "3.–5. Januar 2025"
+// in German. Dates are taken in the gregorian calendar, swapped if to is before from.
+func FormatDateRange(lang Language, from, to time.Time) string {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	if ty < fy || ty == fy && (tm < fm || tm == fm && td < fd) {
+		from, to = to, from
+		fy, fm, fd, ty, tm, td = ty, tm, td, fy, fm, fd
+	}
+
+	patterns := intervalPatternsOf(lang)
+	pattern := patterns.years
+	switch {
+	case fy == ty && fm == tm && fd == td:
+		pattern = patterns.day
+	case fy == ty && fm == tm:
+		pattern = patterns.month
+	case fy == ty:
+		pattern = patterns.year
+	}
+
+	start, end := Gregorian.date(from), Gregorian.date(to)
+	var b strings.Builder
+	for {
+		i := strings.Index(pattern, "{")
+		j := strings.Index(pattern, "}")
+		if i == -1 || j < i {
+			b.WriteString(pattern)
+			return b.String()
+		}
+		b.WriteString(pattern[:i])
+		if field := pattern[i+1 : j]; strings.HasSuffix(field, "2") {
+			b.WriteString(dateTimeField(lang, Gregorian, to, end, strings.TrimSuffix(field, "2")))
+		} else {
+			b.WriteString(dateTimeField(lang, Gregorian, from, start, field))
+		}
+		pattern = pattern[j+1:]
+	}
+}
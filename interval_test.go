@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDateRange(t *testing.T) {
+	fn := func(lang Language, from, to string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			start, err := time.Parse("2006-01-02", from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			end, err := time.Parse("2006-01-02", to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := FormatDateRange(lang, start, end); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english month", fn("en", "2025-01-03", "2025-01-05", "Jan 3–5, 2025"))
+	t.Run("english year", fn("en-us", "2025-01-30", "2025-02-02", "Jan 30 – Feb 2, 2025"))
+	t.Run("english years", fn("en", "2024-12-30", "2025-01-02", "Dec 30, 2024 – Jan 2, 2025"))
+	t.Run("english day", fn("en", "2025-01-03", "2025-01-03", "Jan 3, 2025"))
+	t.Run("british", fn("en-gb", "2025-01-03", "2025-01-05", "3–5 Jan 2025"))
+	t.Run("german month", fn("de", "2025-01-03", "2025-01-05", "3.–5. Januar 2025"))
+	t.Run("german year", fn("de-at", "2025-01-30", "2025-02-02", "30. Januar – 2. Februar 2025"))
+	t.Run("spanish", fn("es", "2025-01-03", "2025-01-05", "3–5 de enero de 2025"))
+	t.Run("japanese", fn("ja", "2025-01-30", "2025-02-02", "2025年1月30日～2月2日"))
+	t.Run("swapped", fn("en", "2025-01-05", "2025-01-03", "Jan 3–5, 2025"))
+	t.Run("iso", fn("xx", "2025-01-03", "2025-01-05", "2025-01-03 – 2025-01-05"))
+}
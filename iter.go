@@ -0,0 +1,42 @@
+//go:build go1.23
+// +build go1.23
+
+package i18n
+
+import "iter"
+
+// AllLanguages returns an iterator over the loaded languages, the default language first
+// followed by the other languages in sorted order
+func (trl Translations) AllLanguages() iter.Seq[Language] {
+	return func(yield func(Language) bool) {
+		for _, lang := range trl.orderedLanguages() {
+			if !yield(lang) {
+				return
+			}
+		}
+	}
+}
+
+// AllKeys returns an iterator over the keys of the language in no particular order,
+// without collecting the keys beforehand
+func (trl Translations) AllKeys(lang Language) iter.Seq[Key] {
+	return func(yield func(Key) bool) {
+		for key := range trl.translations[lang] {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over the keys and translations of the language in no particular order,
+// without collecting the translations beforehand
+func (trl Translations) All(lang Language) iter.Seq2[Key, Translation] {
+	return func(yield func(Key, Translation) bool) {
+		for key, translation := range trl.translations[lang] {
+			if !yield(key, translation) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,56 @@
+//go:build go1.23
+// +build go1.23
+
+package i18n
+
+import (
+	"sort"
+	"testing"
+	"testing/fstest"
+)
+
+func TestIterators(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "A", "b": "B", "c": "C"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "Ä"}`)},
+		"fr.json": &fstest.MapFile{Data: []byte(`{"a": "À"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var languages []Language
+	for lang := range translations.AllLanguages() {
+		languages = append(languages, lang)
+	}
+	if len(languages) != 3 || languages[0] != "en" || languages[1] != "de" || languages[2] != "fr" {
+		t.Fatalf("expected default language first, got %v", languages)
+	}
+
+	var keys []string
+	for key := range translations.AllKeys("en") {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+	if len(keys) != 3 || keys[0] != "a" || keys[2] != "c" {
+		t.Fatalf("expected all keys, got %v", keys)
+	}
+
+	messages := make(map[Key]string)
+	for key, translation := range translations.All("de") {
+		messages[key] = translation.Message
+	}
+	if len(messages) != 1 || messages["a"] != "Ä" {
+		t.Fatalf("expected translations of the language, got %v", messages)
+	}
+
+	count := 0
+	for range translations.AllKeys("en") {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Fatalf("expected iteration to stop, got %d keys", count)
+	}
+}
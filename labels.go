@@ -0,0 +1,24 @@
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+)
+
+// Labels maps constant values, e.g. of an enumeration type, to the keys of their labels
+type Labels map[interface{}]string
+
+// Label translates the label of the value registered using WithLabels into the language.
+// Values are distinguished by their type, i.e. constants of different types never share a label.
+func (trl Translations) Label(lang string, value interface{}) (template.HTML, error) {
+	if value != nil && !reflect.TypeOf(value).Comparable() {
+		return "", fmt.Errorf("no label registered for incomparable %T value", value)
+	}
+
+	key, ok := trl.labels[value]
+	if !ok {
+		return "", fmt.Errorf("no label registered for %T value %v", value, value)
+	}
+	return trl.GenerateTranslate(lang)(key)
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+type orderStatus int
+
+const (
+	orderStatusPending orderStatus = iota
+	orderStatusShipped
+)
+
+type paymentStatus int
+
+const paymentStatusPending paymentStatus = 0
+
+func TestLabels(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"order": {"pending": "Pending", "shipped": "Shipped"}, "payment": {"pending": "Awaiting payment"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"order": {"pending": "Ausstehend", "shipped": "Versandt"}, "payment": {"pending": "Zahlung ausstehend"}}`)},
+	}
+
+	translations, err := New(
+		WithFS(fsys),
+		WithDefaultLanguage("en"),
+		WithLabels(Labels{orderStatusPending: "order.pending", orderStatusShipped: "order.shipped"}),
+		WithLabels(Labels{paymentStatusPending: "payment.pending"}),
+	).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, value interface{}, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.Label(lang, value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("label", fn("de", orderStatusShipped, "Versandt"))
+	t.Run("other language", fn("en", orderStatusPending, "Pending"))
+	t.Run("distinct types", fn("de", paymentStatusPending, "Zahlung ausstehend"))
+
+	if _, err := translations.Label("en", 0); err == nil {
+		t.Fatal("expected error for unregistered value")
+	}
+	if _, err := translations.Label("en", []int{0}); err == nil {
+		t.Fatal("expected error for incomparable value")
+	}
+}
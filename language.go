@@ -0,0 +1,317 @@
+package i18n
+
+import "strings"
+
+// languageRules defines which language codes are accepted
+type languageRules struct {
+	// threeLetterCodes allows three letter ISO 639-2/639-3 codes besides two letter codes
+	threeLetterCodes bool
+}
+
+// valid verifies the validity of a language according to the rules.
+// Besides the primary language, a language may denote a script and a region
+// subtag, e.g. "zh-hant-tw" or "sr-latn".
+func (r languageRules) valid(lang Language) bool {
+	if lang.PrivateUse() {
+		return true
+	}
+
+	t, ok := parseTag(lang)
+	if !ok {
+		return false
+	}
+
+	return len(t.base) == 2 || (r.threeLetterCodes && len(t.base) == 3)
+}
+
+// describe returns a description of the accepted language codes for error messages
+func (r languageRules) describe() string {
+	if r.threeLetterCodes {
+		return "two or three letter codes and private use tags"
+	}
+	return "two letter codes and private use tags"
+}
+
+// PrivateUse reports whether the language is a private use tag like "x-pseudo".
+// Private use tags denote synthetic languages, e.g. for pseudo-localization, and
+// consist of the "x" singleton followed by subtags of up to eight letters or digits.
+func (lang Language) PrivateUse() bool {
+	parts := strings.Split(string(lang), "-")
+	if len(parts) < 2 || parts[0] != "x" {
+		return false
+	}
+
+	for _, part := range parts[1:] {
+		if len(part) > 8 || !isAlphanumeric(part) {
+			return false
+		}
+	}
+	return true
+}
+
+// Base returns the primary language subtag, e.g. "zh" for "zh-hant-tw"
+func (lang Language) Base() Language {
+	t, _ := parseTag(lang)
+	return Language(t.base)
+}
+
+// Script returns the script subtag, e.g. "hant" for "zh-hant-tw".
+// It is empty if the language does not denote a script.
+func (lang Language) Script() string {
+	t, _ := parseTag(lang)
+	return t.script
+}
+
+// Region returns the region subtag, e.g. "tw" for "zh-hant-tw".
+// It is empty if the language does not denote a region.
+func (lang Language) Region() string {
+	t, _ := parseTag(lang)
+	return t.region
+}
+
+// Tag returns the language as BCP 47 tag in its conventional casing, e.g. "zh-Hant-TW"
+func (lang Language) Tag() string {
+	t, ok := parseTag(lang)
+	if !ok {
+		return string(lang)
+	}
+
+	script := t.script
+	if script != "" {
+		script = strings.ToUpper(script[:1]) + script[1:]
+	}
+	return string(join(t.base, script, strings.ToUpper(t.region)))
+}
+
+// tag is a language split into its subtags
+type tag struct {
+	base   string
+	script string
+	region string
+}
+
+// parseTag splits a language in its lower case form into its subtags,
+// reporting whether the language is well-formed
+func parseTag(lang Language) (tag, bool) {
+	parts := strings.Split(string(lang), "-")
+
+	var t tag
+	t.base, parts = parts[0], parts[1:]
+	if len(t.base) < 2 || len(t.base) > 3 || !isAlpha(t.base) {
+		return tag{}, false
+	}
+
+	if len(parts) > 0 && len(parts[0]) == 4 && isAlpha(parts[0]) {
+		t.script, parts = parts[0], parts[1:]
+	}
+	if len(parts) > 0 && (len(parts[0]) == 2 && isAlpha(parts[0]) || len(parts[0]) == 3 && isDigit(parts[0])) {
+		t.region, parts = parts[0], parts[1:]
+	}
+
+	return t, len(parts) == 0
+}
+
+// join combines the given subtags into a language, skipping empty subtags
+func join(subtags ...string) Language {
+	var parts []string
+	for _, subtag := range subtags {
+		if subtag != "" {
+			parts = append(parts, subtag)
+		}
+	}
+	return Language(strings.Join(parts, "-"))
+}
+
+// normalizeLanguage converts a language as it may be passed by users,
+// e.g. "zh_Hant_TW", into its lower case form
+func normalizeLanguage(lang string) Language {
+	return Language(strings.ToLower(strings.Replace(lang, "_", "-", -1)))
+}
+
+// DefaultLanguageAliases maps deprecated and macro language codes still sent by clients
+// to the codes catalogs are commonly named by
+var DefaultLanguageAliases = map[string]string{
+	"in": "id",
+	"iw": "he",
+	"ji": "yi",
+	"jw": "jv",
+	"mo": "ro",
+	"no": "nb",
+	"sh": "sr-latn",
+}
+
+// resolveLanguage converts a requested language into the language to be used, normalizing it
+// and applying the language aliases. Invalid languages resolve to the default language.
+func (trl Translations) resolveLanguage(requested string) Language {
+	lang := trl.alias(normalizeLanguage(requested))
+	if !trl.languageRules.valid(lang) {
+		return trl.defaultLanguage
+	}
+	return lang
+}
+
+// match returns the first loaded language of the chain of the requested language,
+// reporting whether the requested language is valid and any language was found
+func (trl Translations) match(requested string) (Language, bool) {
+	lang := trl.alias(normalizeLanguage(requested))
+	if !trl.languageRules.valid(lang) {
+		return "", false
+	}
+
+	chain := trl.languageChain(lang)
+	if len(chain.languages) == 0 {
+		return "", false
+	}
+	return chain.languages[0], true
+}
+
+// alias applies the language aliases to the normalized language
+func (trl Translations) alias(lang Language) Language {
+	if alias, ok := trl.languageAliases[string(lang)]; ok {
+		lang = normalizeLanguage(alias)
+	} else if t, ok := parseTag(lang); ok {
+		if alias, ok := trl.languageAliases[t.base]; ok {
+			// keep the script and region subtags of the requested language unless denoted by the alias
+			aliased, _ := parseTag(normalizeLanguage(alias))
+			if aliased.script == "" {
+				aliased.script = t.script
+			}
+			if aliased.region == "" {
+				aliased.region = t.region
+			}
+			lang = join(aliased.base, aliased.script, aliased.region)
+		}
+	}
+	return lang
+}
+
+// likelyScripts are the scripts used by a language (within a region) if not
+// denoted otherwise. Only languages commonly written in multiple scripts are listed.
+var likelyScripts = map[Language]string{
+	"az":    "latn",
+	"bs":    "latn",
+	"mn":    "cyrl",
+	"pa":    "guru",
+	"pa-pk": "arab",
+	"sr":    "cyrl",
+	"sr-me": "latn",
+	"uz":    "latn",
+	"uz-af": "arab",
+	"zh":    "hans",
+	"zh-hk": "hant",
+	"zh-mo": "hant",
+	"zh-tw": "hant",
+}
+
+// likelyScript returns the script commonly used by the language within the region
+func likelyScript(base string, region string) string {
+	if script, ok := likelyScripts[join(base, region)]; ok {
+		return script
+	}
+	return likelyScripts[Language(base)]
+}
+
+// languageChain lists the loaded languages consulted in order for a requested language
+type languageChain struct {
+	requested Language
+	languages []Language
+}
+
+// languageChain resolves the requested language into the loaded languages to consult in order
+func (trl Translations) languageChain(lang Language) languageChain {
+	chain := languageChain{requested: lang}
+	trl.extendChain(&chain, lang, make(map[Language]bool))
+	return chain
+}
+
+// extendChain appends the loaded candidates of lang to the chain followed by their configured
+// fallbacks. Visited tracks the languages already extended, preventing cycles.
+func (trl Translations) extendChain(chain *languageChain, lang Language, visited map[Language]bool) {
+	if visited[lang] {
+		return
+	}
+	visited[lang] = true
+
+	candidates := languageCandidates(lang)
+	for _, candidate := range candidates {
+		if _, ok := trl.translations[candidate]; ok && !chain.contains(candidate) {
+			chain.languages = append(chain.languages, candidate)
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, fallback := range trl.fallbacks[candidate] {
+			trl.extendChain(chain, fallback, visited)
+		}
+	}
+}
+
+// languageCandidates returns the language itself followed by the languages it may fall back to.
+// More specific languages precede less specific ones, e.g. "de-at" precedes "de". A language
+// never falls back to a language written in a different script, e.g. "zh-tw" resolves to "zh-hant"
+// but not to "zh" which is written in simplified Chinese.
+func languageCandidates(lang Language) []Language {
+	candidates := []Language{lang}
+
+	t, ok := parseTag(lang)
+	if !ok {
+		return candidates
+	}
+
+	script := t.script
+	if script == "" {
+		script = likelyScript(t.base, t.region)
+	}
+
+	if script != "" {
+		candidates = append(candidates, join(t.base, script, t.region), join(t.base, script))
+	}
+	if t.region != "" && script == likelyScript(t.base, t.region) {
+		candidates = append(candidates, join(t.base, t.region))
+	}
+	if script == likelyScript(t.base, "") {
+		candidates = append(candidates, Language(t.base))
+	}
+	return candidates
+}
+
+// contains reports whether the chain contains lang
+func (c languageChain) contains(lang Language) bool {
+	for _, l := range c.languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// isAlpha reports whether s consists of ASCII letters only
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20
+		if c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAlphanumeric reports whether s consists of ASCII letters and digits only
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isAlpha(s[i:i+1]) && !isDigit(s[i:i+1]) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isDigit reports whether s consists of ASCII digits only
+func isDigit(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,299 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLanguageRules(t *testing.T) {
+	fn := func(rules languageRules, code string, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := rules.valid(Language(code)); got != expected {
+				t.Fatalf("expected %v for %q, got %v", expected, code, got)
+			}
+		}
+	}
+
+	three := languageRules{threeLetterCodes: true}
+
+	t.Run("two letters", fn(languageRules{}, "de", true))
+	t.Run("three letters", fn(languageRules{}, "fil", false))
+	t.Run("three letters allowed", fn(three, "fil", true))
+	t.Run("two letters allowed", fn(three, "de", true))
+	t.Run("four letters", fn(three, "abcd", false))
+	t.Run("non alpha", fn(three, "h4w", false))
+	t.Run("non ascii", fn(three, "hä", false))
+}
+
+func TestThreeLetterCodes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":  &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"haw.json": &fstest.MapFile{Data: []byte(`{"a": "aloha"}`)},
+	}
+
+	if _, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load(); err == nil {
+		t.Fatal("expected three letter codes to be rejected by default")
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithThreeLetterCodes()).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := translations.GenerateTranslate("haw")("a"); err != nil || got != "aloha" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+	if issues := Validate(fsys, "en", WithThreeLetterCodes()); len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestParseTag(t *testing.T) {
+	fn := func(lang Language, expected tag, valid bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, ok := parseTag(lang)
+			if ok != valid {
+				t.Fatalf("expected validity %v for %q, got %v", valid, lang, ok)
+			}
+			if ok && got != expected {
+				t.Fatalf("expected %+v for %q, got %+v", expected, lang, got)
+			}
+		}
+	}
+
+	t.Run("base", fn("de", tag{base: "de"}, true))
+	t.Run("region", fn("de-at", tag{base: "de", region: "at"}, true))
+	t.Run("numeric region", fn("es-419", tag{base: "es", region: "419"}, true))
+	t.Run("script", fn("sr-latn", tag{base: "sr", script: "latn"}, true))
+	t.Run("script and region", fn("zh-hant-tw", tag{base: "zh", script: "hant", region: "tw"}, true))
+	t.Run("region before script", fn("zh-tw-hant", tag{}, false))
+	t.Run("empty subtag", fn("de-", tag{}, false))
+	t.Run("invalid base", fn("d3-at", tag{}, false))
+
+	if lang := Language("zh-hant-tw"); lang.Base() != "zh" || lang.Script() != "hant" || lang.Region() != "tw" {
+		t.Fatalf("unexpected subtags of %q", lang)
+	}
+	if tag := Language("zh-hant-tw").Tag(); tag != "zh-Hant-TW" {
+		t.Fatalf("unexpected tag %q", tag)
+	}
+	if lang := normalizeLanguage("zh_Hant_TW"); lang != "zh-hant-tw" {
+		t.Fatalf("unexpected normalization %q", lang)
+	}
+}
+
+func TestScripts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":      &fstest.MapFile{Data: []byte(`{"a": "language", "b": "country"}`)},
+		"zh-hans.json": &fstest.MapFile{Data: []byte(`{"a": "语言", "b": "国家"}`)},
+		"zh-hant.json": &fstest.MapFile{Data: []byte(`{"a": "語言"}`)},
+		"sr-latn.json": &fstest.MapFile{Data: []byte(`{"a": "jezik"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key)
+			if expected == "" {
+				if err == nil {
+					t.Fatalf("expected error, got %q", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("simplified", fn("zh", "a", "语言"))
+	t.Run("simplified explicit", fn("zh-Hans", "a", "语言"))
+	t.Run("simplified region", fn("zh-CN", "a", "语言"))
+	t.Run("traditional", fn("zh-Hant", "a", "語言"))
+	t.Run("traditional region", fn("zh_TW", "a", "語言"))
+	t.Run("traditional script and region", fn("zh-Hant-HK", "a", "語言"))
+	t.Run("no simplified for traditional", fn("zh-TW", "b", ""))
+	t.Run("latin", fn("sr-Latn", "a", "jezik"))
+	t.Run("no latin for cyrillic", fn("sr", "a", ""))
+}
+
+func TestOverlays(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"color": "color", "truck": "truck", "hello": "hello"}`)},
+		"en-gb.json": &fstest.MapFile{Data: []byte(`{"color": "colour", "truck": "lorry"}`)},
+		"en-au.json": &fstest.MapFile{Data: []byte(`{"hello": "g'day"}`)},
+		"zh.json":    &fstest.MapFile{Data: []byte(`{"color": "颜色"}`)},
+		"zh-tw.json": &fstest.MapFile{Data: []byte(`{"truck": "卡車"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang Language, key Key, expected string, ok bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			translation, found := translations.translations[lang][key]
+			if found != ok {
+				t.Fatalf("expected %q in %q to be present %v", key, lang, ok)
+			}
+			if found && translation.Message != expected {
+				t.Fatalf("expected %q, got %q", expected, translation.Message)
+			}
+		}
+	}
+
+	t.Run("overridden", fn("en-gb", "color", "colour", true))
+	t.Run("inherited", fn("en-gb", "hello", "hello", true))
+	t.Run("base unchanged", fn("en", "color", "color", true))
+	t.Run("other region", fn("en-au", "truck", "truck", true))
+	t.Run("no inheritance across scripts", fn("zh-tw", "color", "", false))
+
+	if tree := translations.Tree("en-gb", ""); len(tree) != 3 {
+		t.Fatalf("expected composed tree, got %v", tree)
+	}
+
+	t.Run("collision with base", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.json":    &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+			"en-gb.json": &fstest.MapFile{Data: []byte(`{"a.b": "y"}`)},
+		}
+
+		if issues := Validate(fsys, "en"); len(issues) != 1 {
+			t.Fatalf("expected a single issue, got %v", issues)
+		}
+	})
+}
+
+func TestLanguageAliases(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":      &fstest.MapFile{Data: []byte(`{"a": "language"}`)},
+		"nb.json":      &fstest.MapFile{Data: []byte(`{"a": "språk"}`)},
+		"he.json":      &fstest.MapFile{Data: []byte(`{"a": "שפה"}`)},
+		"sr-latn.json": &fstest.MapFile{Data: []byte(`{"a": "jezik"}`)},
+	}
+
+	fn := func(translations Translations, lang string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)("a")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("macro language", fn(translations, "no", "språk"))
+	t.Run("macro language with region", fn(translations, "no-NO", "språk"))
+	t.Run("deprecated code", fn(translations, "iw", "שפה"))
+	t.Run("alias with script", fn(translations, "sh", "jezik"))
+	t.Run("unaliased", fn(translations, "nb", "språk"))
+
+	custom, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithLanguageAliases(map[string]string{"nn": "nb"})).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("custom alias", fn(custom, "nn", "språk"))
+	t.Run("defaults replaced", func(t *testing.T) {
+		if got, err := custom.GenerateTranslate("no")("a"); err == nil {
+			t.Fatalf("expected error, got %q", got)
+		}
+	})
+}
+
+func TestPrivateUse(t *testing.T) {
+	fn := func(lang Language, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := lang.PrivateUse(); got != expected {
+				t.Fatalf("expected %v for %q, got %v", expected, lang, got)
+			}
+			if got := (languageRules{}).valid(lang); got != expected {
+				t.Fatalf("expected validity %v for %q, got %v", expected, lang, got)
+			}
+		}
+	}
+
+	t.Run("pseudo", fn("x-pseudo", true))
+	t.Run("multiple subtags", fn("x-keys-v2", true))
+	t.Run("singleton only", fn("x", false))
+	t.Run("empty subtag", fn("x-", false))
+	t.Run("subtag too long", fn("x-pseudolocale", false))
+	t.Run("other singleton", fn("y-pseudo", false))
+
+	fsys := fstest.MapFS{
+		"en.json":       &fstest.MapFile{Data: []byte(`{"a": "hello", "b": "world"}`)},
+		"x-pseudo.json": &fstest.MapFile{Data: []byte(`{"a": "[ħéľľö]"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translate := translations.GenerateTranslate("x-Pseudo")
+	if got, err := translate("a"); err != nil || got != "[ħéľľö]" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+	if got, err := translate("b"); err != nil || got != "world" {
+		t.Fatalf("unexpected fallback %q: %v", got, err)
+	}
+}
+
+func TestFallbacks(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello", "b": "world", "c": "!"}`)},
+		"es.json": &fstest.MapFile{Data: []byte(`{"a": "hola", "b": "mundo"}`)},
+		"gl.json": &fstest.MapFile{Data: []byte(`{"a": "ola"}`)},
+		"ru.json": &fstest.MapFile{Data: []byte(`{"a": "привет"}`)},
+	}
+
+	translations, err := New(
+		WithFS(fsys),
+		WithDefaultLanguage("en"),
+		WithFallbackChain("en"),
+		WithFallbacks("gl", "es"),
+		WithFallbacks("es", "gl"),
+		WithFallbacks("be", "ru", "en"),
+	).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("own translation", fn("gl", "a", "ola"))
+	t.Run("fallback", fn("gl", "b", "mundo"))
+	t.Run("fallback chain", fn("gl", "c", "!"))
+	t.Run("cyclic fallbacks", fn("es", "a", "hola"))
+	t.Run("regional variant", fn("gl-ES", "b", "mundo"))
+	t.Run("unavailable language", fn("be", "a", "привет"))
+
+	if chain := translations.languageChain("be"); !reflect.DeepEqual(chain.languages, []Language{"ru", "en"}) {
+		t.Fatalf("unexpected chain %v", chain.languages)
+	}
+}
@@ -0,0 +1,539 @@
+package i18n
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Issue describes a problem found within the language files
+type Issue struct {
+	// File is the path of the language file within the file system.
+	// It is empty for issues concerning the whole catalog.
+	File     string
+	Language Language
+	Key      Key
+	Message  string
+}
+
+func (i Issue) Error() string {
+	s := i.Message
+	if i.Key != "" {
+		s += fmt.Sprintf(" with key %q", i.Key)
+	}
+	if i.Language != "" {
+		s += fmt.Sprintf(" for %q", i.Language)
+	}
+	if i.File != "" {
+		s += fmt.Sprintf(" in %q", i.File)
+	}
+	return s
+}
+
+// Validate runs all checks performed upon loading the language files of fsys and
+// returns the issues found, without retaining the parsed translations. Unlike Load,
+// it does not stop at the first issue. It is intended for linting language files,
+// e.g. within continuous integration. Options affecting the loading may be passed.
+func Validate(fsys fs.FS, defaultLang string, options ...Option) []Issue {
+	trl := New(append(options, WithFS(fsys), WithDefaultLanguage(defaultLang))...)
+
+	l := newLoader(trl)
+	l.load()
+	return l.issues
+}
+
+// loader reads all language files of a file system into stores,
+// collecting the issues found on the way
+type loader struct {
+	// trl holds the configuration of the loading
+	trl  Translations
+	fsys fs.FS
+
+	// equal keys and messages across the language files share their memory
+	interned interner
+
+	translations map[Language]Store
+	metadata     map[Language]map[Key]Metadata
+	issues       []Issue
+
+	// keyMetadata is the metadata merged across all languages
+	keyMetadata map[Key]Metadata
+
+	// origins tracks the file each key of a language was loaded from
+	origins map[Language]map[Key]string
+
+	// routes are the translated path segments per language
+	routes map[Language]routeTable
+
+	// constants are the constants declared by the files of each language
+	constants map[Language]map[string]string
+
+	// included tracks the files included or extended by others, misnamed the issues of files
+	// not named by a language and decoded the files decoded, reported respectively added once
+	// all files were walked unless the files are included
+	included map[string]bool
+	misnamed []Issue
+	decoded  []decodedFile
+
+	// checksums are the hashes of the files listed by the manifest if configured
+	checksums map[string]string
+
+	// fileModified and keyModified track the modification times of each file
+	// and of the keys declaring it explicitly per file
+	fileModified map[string]time.Time
+	keyModified  map[string]map[Key]time.Time
+
+	// tenants are the composed stores and routes of each tenant
+	tenants      map[string]map[Language]Store
+	tenantRoutes map[string]map[Language]routeTable
+}
+
+// decodedFile is a language file decoded while walking the file system
+type decodedFile struct {
+	path      string
+	lang      Language
+	store     Store
+	constants map[string]string
+	metadata  map[Key]Metadata
+}
+
+func newLoader(trl Translations) *loader {
+	return &loader{
+		trl:          trl,
+		fsys:         trl.source(),
+		interned:     make(interner),
+		translations: make(map[Language]Store),
+		metadata:     make(map[Language]map[Key]Metadata),
+		origins:      make(map[Language]map[Key]string),
+		constants:    make(map[Language]map[string]string),
+		included:     make(map[string]bool),
+		fileModified: make(map[string]time.Time),
+		keyModified:  make(map[string]map[Key]time.Time),
+	}
+}
+
+// report records an issue
+func (l *loader) report(file string, lang Language, key Key, message string) {
+	l.issues = append(l.issues, Issue{
+		File:     file,
+		Language: lang,
+		Key:      key,
+		Message:  message,
+	})
+}
+
+// load walks the file system, loading every language file using its
+// base name as language identifier
+func (l *loader) load() {
+	defaultLanguage := l.trl.defaultLanguage
+	if !l.trl.languageRules.valid(defaultLanguage) {
+		l.report("", "", "", "invalid default language, must follow "+l.trl.languageRules.describe())
+		return
+	}
+
+	if l.trl.manifest != "" {
+		l.verifyManifest()
+	}
+
+	if err := l.walk("."); err != nil {
+		l.report("", "", "", err.Error())
+		return
+	}
+
+	if _, ok := l.translations[defaultLanguage]; !ok {
+		l.report("", defaultLanguage, "", "no translations found for default language")
+	}
+
+	l.resolveConstants(nil)
+	// persisted edits are checked like the language files
+	if l.trl.persister != nil {
+		if err := l.trl.applyEdits(l.translations); err != nil {
+			l.report("", "", "", err.Error())
+			return
+		}
+	}
+	l.composeOverlays()
+	l.keyMetadata = l.mergedMetadata(defaultLanguage)
+	l.check(defaultLanguage)
+
+	if l.trl.tenantDirectory != "" {
+		l.loadTenants()
+	}
+}
+
+// walk loads every language file of a supported format below root using its base name as
+// language identifier unless declared by the file. The tenant directory is skipped when walking
+// the root of the file system. Files included or extended by others need not be named by
+// a language and are not loaded on their own, the files being added once all were walked.
+func (l *loader) walk(root string) error {
+	err := fs.WalkDir(l.fsys, root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if root == "." && l.trl.tenantDirectory != "" && filePath == path.Clean(l.trl.tenantDirectory) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if l.trl.manifest != "" && filePath == path.Clean(l.trl.manifest) {
+			return nil
+		}
+
+		// compressed files are named by the file they contain, e.g. "de.json.gz"
+		decompressed := strings.TrimSuffix(filePath, gzipSuffix)
+		if strings.HasSuffix(decompressed, MultilingualSuffix) {
+			l.loadFile(filePath, l.trl.defaultLanguage)
+			return nil
+		}
+
+		extension := path.Ext(decompressed)
+		format, ok := fileFormats[extension]
+		if !ok {
+			return nil
+		}
+
+		// allow only language code file names
+		name := strings.TrimSuffix(path.Base(decompressed), extension)
+		if format.language != nil {
+			name = format.language(name, l.trl.defaultLanguage)
+		}
+		lang := Language(strings.ToLower(name))
+		if !format.declared && !l.trl.languageRules.valid(lang) {
+			l.misnamed = append(l.misnamed, Issue{File: filePath, Message: fmt.Sprintf("invalid file naming scheme %q, allowed are only %s", lang, l.trl.languageRules.describe())})
+			return nil
+		}
+
+		l.loadFile(filePath, lang)
+		return nil
+	})
+
+	for _, issue := range l.misnamed {
+		if !l.included[issue.File] {
+			l.issues = append(l.issues, issue)
+		}
+	}
+	for _, file := range l.decoded {
+		if !l.included[file.path] {
+			l.add(file)
+		}
+	}
+	l.misnamed, l.decoded = nil, nil
+	return err
+}
+
+// loadFile decodes a single language file into the store of lang
+func (l *loader) loadFile(filePath string, lang Language) {
+	if _, ok := l.checksums[filePath]; l.checksums != nil && !ok {
+		l.report(filePath, lang, "", "file not listed in manifest")
+		return
+	}
+
+	file, err := l.fsys.Open(filePath)
+	if err != nil {
+		l.report(filePath, lang, "", err.Error())
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err == nil {
+		l.fileModified[filePath] = info.ModTime()
+	}
+
+	var r io.Reader = file
+	decompressed := strings.TrimSuffix(filePath, gzipSuffix)
+	if decompressed != filePath {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			l.report(filePath, lang, "", err.Error())
+			return
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	if limits := l.trl.limits; limits.MaxFileSize > 0 {
+		if err == nil && info.Size() > limits.MaxFileSize {
+			l.report(filePath, lang, "", fmt.Sprintf("file exceeds maximum size of %d bytes", limits.MaxFileSize))
+			return
+		}
+
+		// the reported size may not be trusted for every file system,
+		// compressed files are limited by their decompressed size
+		r = &limitedReader{r: r, n: limits.MaxFileSize}
+	}
+
+	r, err = decodeUnicode(r)
+	if err != nil {
+		l.report(filePath, lang, "", err.Error())
+		return
+	}
+
+	d := newDecoder(r, l.interned)
+	d.limits = l.trl.limits
+	d.normalize = l.trl.normalize
+	d.lang, d.rules = lang, l.trl.languageRules
+	d.fsys, d.path, d.included = l.fsys, filePath, l.included
+	d.checksums = l.checksums
+	store, err := fileFormats[path.Ext(decompressed)].decode(d)
+	// formats declaring their language are validated after decoding
+	lang = d.lang
+	if !l.trl.languageRules.valid(lang) {
+		l.misnamed = append(l.misnamed, Issue{File: filePath, Message: fmt.Sprintf("invalid language %q, allowed are only %s", lang, l.trl.languageRules.describe())})
+		return
+	}
+	for _, issue := range d.issues {
+		l.report(filePath, lang, issue.Key, issue.Message)
+	}
+	if err != nil {
+		l.report(filePath, lang, "", err.Error())
+		return
+	}
+
+	// within the translations file, there must be at least one translation
+	if len(store) == 0 && len(d.issues) == 0 && len(d.constants) == 0 {
+		l.report(filePath, lang, "", "no translations found")
+	}
+
+	if strings.HasSuffix(decompressed, MultilingualSuffix) {
+		l.decoded = append(l.decoded, l.split(filePath, d)...)
+		return
+	}
+	l.decoded = append(l.decoded, decodedFile{path: filePath, lang: lang, store: store, constants: d.constants, metadata: d.metadata})
+}
+
+// add merges the translations, constants and metadata of a decoded file
+func (l *loader) add(file decodedFile) {
+	filePath, lang := file.path, file.lang
+	l.merge(filePath, lang, file.store)
+	l.mergeConstants(filePath, lang, file.constants)
+
+	if l.metadata[lang] == nil {
+		l.metadata[lang] = make(map[Key]Metadata)
+	}
+	for key, metadata := range file.metadata {
+		l.metadata[lang][key] = l.metadata[lang][key].merge(metadata)
+		if !metadata.Modified.IsZero() {
+			if l.keyModified[filePath] == nil {
+				l.keyModified[filePath] = make(map[Key]time.Time)
+			}
+			l.keyModified[filePath][key] = metadata.Modified
+		}
+	}
+}
+
+// mergedMetadata combines the metadata of all languages per key.
+// The metadata declared by the default language takes precedence.
+func (l *loader) mergedMetadata(defaultLanguage Language) map[Key]Metadata {
+	merged := make(map[Key]Metadata)
+	for key, metadata := range l.metadata[defaultLanguage] {
+		merged[key] = metadata
+	}
+
+	for _, lang := range sortedLanguages(l.translations) {
+		for key, metadata := range l.metadata[lang] {
+			merged[key] = merged[key].merge(metadata)
+		}
+	}
+	return merged
+}
+
+// merge adds the translations of a file to the store of lang.
+// Multiple files of the same language must not define the same key.
+func (l *loader) merge(filePath string, lang Language, store Store) {
+	if _, ok := l.translations[lang]; !ok {
+		l.translations[lang] = make(Store, len(store))
+		l.origins[lang] = make(map[Key]string, len(store))
+	}
+
+	for _, key := range sortedKeys(store) {
+		if other, ok := l.origins[lang][key]; ok {
+			l.report(filePath, lang, key, fmt.Sprintf("duplicate key, already defined in %q", other))
+			continue
+		}
+
+		l.translations[lang][key] = store[key]
+		l.origins[lang][key] = filePath
+	}
+}
+
+// composeOverlays completes the stores of regional (or script specific) languages with the
+// translations of the language they derive from, e.g. "en-us" with the translations of "en".
+// The file of a regional language therefore only needs to contain the differing translations.
+func (l *loader) composeOverlays() {
+	composed := make(map[Language]bool)
+
+	var compose func(lang Language, visiting map[Language]bool)
+	compose = func(lang Language, visiting map[Language]bool) {
+		if composed[lang] {
+			return
+		}
+		visiting[lang] = true
+		defer delete(visiting, lang)
+
+		for _, parent := range languageCandidates(lang)[1:] {
+			if _, ok := l.translations[parent]; !ok || parent == lang || visiting[parent] {
+				continue
+			}
+
+			// the nearest parent is composed itself, containing the translations of its parents
+			compose(parent, visiting)
+
+			store := l.translations[lang]
+			for key, translation := range l.translations[parent] {
+				if _, ok := store[key]; !ok {
+					store[key] = translation
+					l.origins[lang][key] = l.origins[parent][key]
+				}
+			}
+			break
+		}
+		composed[lang] = true
+	}
+
+	for _, lang := range sortedLanguages(l.translations) {
+		compose(lang, make(map[Language]bool))
+	}
+}
+
+// check runs the checks across the stores of all languages, building their route tables
+func (l *loader) check(defaultLanguage Language) {
+	l.checkCollisions()
+	l.checkTypes(defaultLanguage)
+	if l.trl.printf {
+		l.checkPrintf(defaultLanguage)
+	}
+	l.routes = l.checkRoutes(l.translations, func(lang Language, key Key) string { return l.origins[lang][key] })
+	l.checkAliases(defaultLanguage)
+	l.checkVariants(defaultLanguage)
+}
+
+// checkCollisions reports keys which are used both for a translation and as the
+// parent of nested translations, which can not be represented in a nested structure
+func (l *loader) checkCollisions() {
+	for _, lang := range sortedLanguages(l.translations) {
+		store := l.translations[lang]
+
+		reported := make(map[Key]bool)
+		for _, key := range sortedKeys(store) {
+			for i := 0; i < len(key); i++ {
+				if key[i] != '.' {
+					continue
+				}
+
+				parent := key[:i]
+				if _, ok := store[parent]; ok && !reported[parent] {
+					reported[parent] = true
+					l.report(l.origins[lang][parent], lang, parent, fmt.Sprintf("key collision, is both a translation and the parent of %q", key))
+				}
+			}
+		}
+	}
+}
+
+// checkTypes reports intermediates declaring different types across languages.
+// The types declared by the default language take precedence.
+func (l *loader) checkTypes(defaultLanguage Language) {
+	type declaration struct {
+		typ  IntermediateType
+		lang Language
+	}
+	declared := make(map[Key]map[Intermediate]declaration)
+
+	languages := sortedLanguages(l.translations)
+	sort.SliceStable(languages, func(i, j int) bool { return languages[i] == defaultLanguage })
+
+	for _, lang := range languages {
+		store := l.translations[lang]
+		for _, key := range sortedKeys(store) {
+			for _, segment := range store[key].segments {
+				if segment.typ == "" {
+					continue
+				}
+
+				if declared[key] == nil {
+					declared[key] = make(map[Intermediate]declaration)
+				}
+
+				other, ok := declared[key][segment.intermediate]
+				if !ok {
+					declared[key][segment.intermediate] = declaration{typ: segment.typ, lang: lang}
+					continue
+				}
+				if other.typ != segment.typ {
+					l.report(l.origins[lang][key], lang, key, fmt.Sprintf("intermediate %q is declared as %s, but as %s for %q", segment.intermediate, segment.typ, other.typ, other.lang))
+				}
+			}
+		}
+	}
+}
+
+// checkAliases reports aliases which are translated on their own or
+// whose canonical key is not translated in the default language
+func (l *loader) checkAliases(defaultLanguage Language) {
+	var aliases []Key
+	for key, metadata := range l.keyMetadata {
+		if metadata.Alias != "" {
+			aliases = append(aliases, key)
+		}
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i] < aliases[j] })
+
+	for _, key := range aliases {
+		alias := l.keyMetadata[key].Alias
+		if _, ok := l.translations[defaultLanguage][alias]; !ok {
+			l.report("", defaultLanguage, key, fmt.Sprintf("canonical key %q of alias not found", alias))
+		}
+
+		for _, lang := range sortedLanguages(l.translations) {
+			if _, ok := l.translations[lang][key]; ok {
+				l.report(l.origins[lang][key], lang, key, fmt.Sprintf("alias of %q must not be translated", alias))
+			}
+		}
+	}
+}
+
+// sortedKeys returns the keys of the store in ascending order
+func sortedKeys(store Store) []Key {
+	keys := make([]Key, 0, len(store))
+	for key := range store {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// sortedLanguages returns the languages of the translations in ascending order
+func sortedLanguages(translations map[Language]Store) []Language {
+	languages := make([]Language, 0, len(translations))
+	for lang := range translations {
+		languages = append(languages, lang)
+	}
+
+	sort.Slice(languages, func(i, j int) bool { return languages[i] < languages[j] })
+	return languages
+}
+
+// limitedReader reads from r failing once more than n bytes were read
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errors.New("file exceeds maximum size")
+	}
+	return n, err
+}
@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValidate(t *testing.T) {
+	fn := func(fsys fstest.MapFS, expected []Issue) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fsys, "en")
+			if len(issues) != len(expected) {
+				t.Fatalf("expected %d issues, got %d: %v", len(expected), len(issues), issues)
+			}
+
+			for i, issue := range issues {
+				if issue.File != expected[i].File || issue.Language != expected[i].Language || issue.Key != expected[i].Key {
+					t.Fatalf("expected issue %+v, got %+v", expected[i], issue)
+				}
+			}
+		}
+	}
+
+	t.Run("valid", fn(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+	}, nil))
+
+	t.Run("multiple issues", fn(fstest.MapFS{
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": {"": "x"}, "b": 1, "c": [{"d": "e"}], "f": "{{", "g": {}, "h": "ok"}`)},
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+	}, []Issue{
+		{File: "de.json", Language: "de", Key: "a"},
+		{File: "de.json", Language: "de", Key: "b"},
+		{File: "de.json", Language: "de", Key: "c"},
+		{File: "de.json", Language: "de", Key: "f"},
+		{File: "de.json", Language: "de", Key: "g"},
+	}))
+
+	t.Run("invalid files", fn(fstest.MapFS{
+		"en.json":       &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+		"fr.json":       &fstest.MapFile{Data: []byte(`{"a": "x"`)},
+		"sub/l4ng.json": &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+	}, []Issue{
+		{File: "fr.json", Language: "fr"},
+		{File: "sub/l4ng.json"},
+	}))
+
+	t.Run("missing default language", fn(fstest.MapFS{
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+	}, []Issue{
+		{Language: "en"},
+	}))
+
+	t.Run("test data", func(t *testing.T) {
+		if issues := Validate(os.DirFS(Validity+"invalid_key_2"), "bb"); len(issues) != 1 {
+			t.Fatalf("expected a single issue, got %v", issues)
+		}
+	})
+}
+
+func TestCollisions(t *testing.T) {
+	fn := func(fsys fstest.MapFS, expected ...Key) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fsys, "en")
+			if len(issues) != len(expected) {
+				t.Fatalf("expected %d issues, got %d: %v", len(expected), len(issues), issues)
+			}
+
+			for i, issue := range issues {
+				if issue.Key != expected[i] {
+					t.Fatalf("expected issue for %q, got %v", expected[i], issue)
+				}
+			}
+		}
+	}
+
+	t.Run("flat and nested", fn(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a.b": "x", "a": {"b": "y"}}`)},
+	}, "a.b"))
+	t.Run("string and object", fn(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "x", "a.b": "y", "a.c": "z"}`)},
+	}, "a"))
+	t.Run("deep string and object", fn(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": {"b": "x"}, "a.b.c": "y"}`)},
+	}, "a.b"))
+	t.Run("repeated member", fn(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "x", "a": "y"}`)},
+	}))
+	t.Run("multiple files", fn(fstest.MapFS{
+		"en.json":       &fstest.MapFile{Data: []byte(`{"a": "x", "b": "y"}`)},
+		"admin/en.json": &fstest.MapFile{Data: []byte(`{"a": "x", "c": "z"}`)},
+	}, "a"))
+
+	t.Run("merged files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.json":       &fstest.MapFile{Data: []byte(`{"a": "x"}`)},
+			"admin/en.json": &fstest.MapFile{Data: []byte(`{"b": "y"}`)},
+		}
+
+		translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !translations.Has("en", "a") || !translations.Has("en", "b") {
+			t.Fatal("expected translations of both files")
+		}
+	})
+}
+
+func TestLimitedReader(t *testing.T) {
+	r := &limitedReader{r: strings.NewReader("hello, world"), n: 5}
+	if _, err := io.ReadAll(r); err == nil {
+		t.Fatal("expected error for exceeding the limit")
+	}
+
+	r = &limitedReader{r: strings.NewReader("hello"), n: 5}
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,132 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+)
+
+const (
+	// TagName is the struct tag denoting the key of a field localized by Localize
+	TagName = "i18n"
+	// ValueKey is the key of a struct tag denoting the current value of the field as key
+	ValueKey = "$"
+)
+
+var htmlType = reflect.TypeOf(template.HTML(""))
+
+// Localize fills the fields of the struct pointed to by v tagged by the key of their
+// translation, e.g. `i18n:"greeting"`. Parameters of intermediates are taken of other fields
+// listed after the key, e.g. `i18n:"greeting,name=Name"` passes the field Name as intermediate
+// name. The key "$" translates the current value of the field itself. Fields of type string
+// are filled with the plain message, fields of type template.HTML with the escaped message.
+// Nested structs, pointers and slices of these are localized as well.
+func (trl Translations) Localize(lang string, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("localize requires a non-nil pointer to a struct")
+	}
+
+	return trl.localize(trl.languageChain(trl.resolveLanguage(lang)), rv.Elem())
+}
+
+// localize fills the tagged fields of the struct and its nested structs
+func (trl Translations) localize(chain languageChain, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return trl.localize(chain, rv.Elem())
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := trl.localize(chain, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Struct:
+	default:
+		return nil
+	}
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(TagName)
+		if !ok {
+			if err := trl.localize(chain, rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := trl.localizeField(chain, rv, field, tag); err != nil {
+			return fmt.Errorf("failed to localize field %s: %v", field.Name, err)
+		}
+	}
+	return nil
+}
+
+// localizeField fills the field of the struct with the translation denoted by the tag
+func (trl Translations) localizeField(chain languageChain, rv reflect.Value, field reflect.StructField, tag string) error {
+	value := rv.FieldByIndex(field.Index)
+	if value.Kind() != reflect.String {
+		return fmt.Errorf("type %s can not be localized, must be string or template.HTML", field.Type)
+	}
+
+	parts := strings.Split(tag, ",")
+	key := Key(parts[0])
+	if key == ValueKey {
+		key = Key(value.String())
+	}
+	if key == "" {
+		return nil
+	}
+
+	var params []interface{}
+	for _, param := range parts[1:] {
+		i := strings.Index(param, "=")
+		if i < 0 {
+			return fmt.Errorf("invalid parameter %q, must be intermediate=Field", param)
+		}
+
+		source := rv.FieldByName(param[i+1:])
+		if !source.IsValid() || !source.CanInterface() {
+			return fmt.Errorf("unknown field %q of parameter %q", param[i+1:], param[:i])
+		}
+		params = append(params, param[:i], source.Interface())
+	}
+
+	if field.Type == htmlType {
+		translation, err := trl.lookup(chain, trl.normalizeKey(key), "")
+		if err != nil {
+			return err
+		}
+		lookup, err := createIntermediateLookup(params)
+		if err != nil {
+			return err
+		}
+		message, err := trl.interpolate(chain.requested, key, translation, lookup, trl.escape)
+		if err != nil {
+			return err
+		}
+		value.SetString(message)
+		return nil
+	}
+
+	message, err := trl.renderText(chain, key, params)
+	if err != nil {
+		return err
+	}
+	value.SetString(message)
+	return nil
+}
@@ -0,0 +1,71 @@
+package i18n
+
+import (
+	"html/template"
+	"testing"
+	"testing/fstest"
+)
+
+type localizedItem struct {
+	Status string `i18n:"$"`
+}
+
+type localizedResponse struct {
+	Name     string
+	Count    int
+	Greeting string        `i18n:"greeting,name=Name"`
+	Summary  template.HTML `i18n:"summary,name=Name,count=Count"`
+	Items    []localizedItem
+	Nested   *localizedItem
+	Empty    string `i18n:"$"`
+}
+
+func TestLocalize(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hello {{name}}", "summary": "<b>{{name}}</b> has {{count}} orders", "status": {"open": "Open", "closed": "Closed"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hallo {{name}}", "summary": "<b>{{name}}</b> hat {{count}} Bestellungen", "status": {"open": "Offen", "closed": "Geschlossen"}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response := localizedResponse{
+		Name:   "<Bob>",
+		Count:  3,
+		Items:  []localizedItem{{Status: "status.open"}, {Status: "status.closed"}},
+		Nested: &localizedItem{Status: "status.open"},
+	}
+	if err := translations.Localize("de", &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Greeting != "Hallo <Bob>" {
+		t.Fatalf("unexpected greeting %q", response.Greeting)
+	}
+	if response.Summary != "<b>&lt;Bob&gt;</b> hat 3 Bestellungen" {
+		t.Fatalf("unexpected summary %q", response.Summary)
+	}
+	if response.Items[0].Status != "Offen" || response.Items[1].Status != "Geschlossen" || response.Nested.Status != "Offen" {
+		t.Fatalf("unexpected nested fields %+v %+v", response.Items, response.Nested)
+	}
+
+	fn := func(v interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			if err := translations.Localize("en", v); err == nil {
+				t.Fatal("expected error")
+			}
+		}
+	}
+
+	t.Run("no pointer", fn(response))
+	t.Run("no struct", fn(new(string)))
+	t.Run("unknown key", fn(&localizedItem{Status: "status.unknown"}))
+	t.Run("invalid type", fn(&struct {
+		Count int `i18n:"count"`
+	}{}))
+	t.Run("unknown parameter field", fn(&struct {
+		Greeting string `i18n:"greeting,name=Unknown"`
+	}{}))
+}
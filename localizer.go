@@ -0,0 +1,38 @@
+package i18n
+
+import "html/template"
+
+// Localizer translates keys into a preset language, optionally below a key prefix, e.g. for
+// a handler rendering a single section of a page. Localizers are lightweight values and
+// safe for concurrent use.
+type Localizer struct {
+	lang      Language
+	prefix    Key
+	translate func(k string, params ...interface{}) (template.HTML, error)
+}
+
+// WithLanguage returns a localizer translating into the language, resolved as by GenerateTranslate
+func (trl Translations) WithLanguage(lang string) Localizer {
+	return Localizer{
+		lang:      trl.resolveLanguage(lang),
+		translate: trl.GenerateTranslate(lang),
+	}
+}
+
+// WithPrefix returns a localizer translating keys below the prefix, e.g. "title" as
+// "checkout.title" for the prefix "checkout". Prefixes of nested localizers are combined.
+func (l Localizer) WithPrefix(prefix string) Localizer {
+	l.prefix = l.prefix.Append(prefix)
+	return l
+}
+
+// Language returns the language translated into
+func (l Localizer) Language() Language {
+	return l.lang
+}
+
+// T translates the key below the prefix of the localizer, interpolating the parameters
+// as the functions returned by GenerateTranslate
+func (l Localizer) T(key string, params ...interface{}) (template.HTML, error) {
+	return l.translate(string(l.prefix.Append(key)), params...)
+}
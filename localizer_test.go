@@ -0,0 +1,36 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalizer(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"checkout": {"title": "Checkout", "payment": {"card": "Pay by card, {{name}}"}}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"checkout": {"title": "Kasse", "payment": {"card": "Mit Karte zahlen, {{name}}"}}}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := translations.WithLanguage("de-DE")
+	if lang := l.Language(); lang != "de-de" {
+		t.Fatalf("expected resolved language, got %q", lang)
+	}
+
+	fn := func(l Localizer, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := l.T(key, "name", "Anna")
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("full key", fn(l, "checkout.title", "Kasse"))
+	t.Run("prefix", fn(l.WithPrefix("checkout"), "title", "Kasse"))
+	t.Run("nested prefix", fn(l.WithPrefix("checkout").WithPrefix("payment"), "card", "Mit Karte zahlen, Anna"))
+	t.Run("default language", fn(translations.WithLanguage("").WithPrefix("checkout"), "title", "Checkout"))
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	// machineTimeout limits machine translations requested within contexts without deadline
+	machineTimeout = 5 * time.Second
+	// machineRetryInterval is the time failed or rejected machine translations are not retried
+	machineRetryInterval = time.Minute
+	// maxMachineTranslations bounds the number of cached machine translations and failures
+	maxMachineTranslations = 10000
+)
+
+// MTProvider machine translates messages, e.g. using a translation API. Messages contain
+// intermediates like "{{name}}" which must be retained as they are.
+type MTProvider interface {
+	Translate(ctx context.Context, message string, from Language, to Language) (string, error)
+}
+
+// machineTranslator translates missing keys using a provider, caching the results.
+// Failures are cached as well, tracking the time of the failure.
+type machineTranslator struct {
+	provider MTProvider
+	// targets are the languages translated into, the loaded languages if empty
+	targets map[Language]bool
+
+	mu       sync.Mutex
+	cache    map[machineKey]Translation
+	failures map[machineKey]time.Time
+}
+
+// machineKey identifies a machine translation by its target language and source message
+type machineKey struct {
+	lang    Language
+	message string
+}
+
+// translate returns the machine translation of the translation of the default language into
+// lang within the context, limited to machineTimeout unless the context has a deadline.
+// Translations not retaining the intermediates of the source are rejected. Failed and rejected
+// translations are not retried within machineRetryInterval.
+func (mt *machineTranslator) translate(ctx context.Context, source Translation, from Language, lang Language) (Translation, bool) {
+	k := machineKey{lang: lang, message: source.Message}
+
+	mt.mu.Lock()
+	translation, ok := mt.cache[k]
+	failed, retry := mt.failures[k]
+	mt.mu.Unlock()
+	if ok {
+		return translation, true
+	}
+	if retry && time.Since(failed) < machineRetryInterval {
+		return Translation{}, false
+	}
+
+	requestCtx := ctx
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		requestCtx, cancel = context.WithTimeout(ctx, machineTimeout)
+		defer cancel()
+	}
+
+	message, err := mt.provider.Translate(requestCtx, source.Message, from, lang)
+	if err != nil {
+		// requests canceled by the caller are no failures of the provider
+		if ctx.Err() == nil {
+			mt.fail(k)
+		}
+		return Translation{}, false
+	}
+
+	intermediates, segments, err := parseIntermediates(message)
+	if err != nil || !sameIntermediates(intermediates, source.Intermediates) {
+		mt.fail(k)
+		return Translation{}, false
+	}
+	translation = Translation{Message: message, Intermediates: intermediates, segments: segments}
+
+	mt.mu.Lock()
+	if len(mt.cache) >= maxMachineTranslations {
+		mt.cache = make(map[machineKey]Translation)
+	}
+	mt.cache[k] = translation
+	delete(mt.failures, k)
+	mt.mu.Unlock()
+	return translation, true
+}
+
+// fail records the failure of the machine translation of the key
+func (mt *machineTranslator) fail(k machineKey) {
+	mt.mu.Lock()
+	if len(mt.failures) >= maxMachineTranslations {
+		mt.failures = make(map[machineKey]time.Time)
+	}
+	mt.failures[k] = time.Now()
+	mt.mu.Unlock()
+}
+
+// machineTarget returns the language machine translations into the requested language are
+// made in, the first of its candidates being a target or loaded if no targets are configured,
+// e.g. "de" for "de-at". Requested languages of clients therefore can not cause translations
+// into arbitrary languages. It reports false for the default language or if none is allowed.
+func (trl Translations) machineTarget(requested Language) (Language, bool) {
+	for _, candidate := range languageCandidates(requested) {
+		allowed := trl.machine.targets[candidate]
+		if len(trl.machine.targets) == 0 {
+			_, allowed = trl.translations[candidate]
+		}
+		if allowed {
+			return candidate, candidate != trl.defaultLanguage
+		}
+	}
+	return "", false
+}
+
+// sameIntermediates reports whether both lists contain the same intermediates regardless of order
+func sameIntermediates(a []Intermediate, b []Intermediate) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[Intermediate]int, len(a))
+	for _, intermediate := range a {
+		counts[intermediate]++
+	}
+	for _, intermediate := range b {
+		counts[intermediate]--
+		if counts[intermediate] < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,195 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type pseudoProvider struct {
+	calls int
+}
+
+func (p *pseudoProvider) Translate(ctx context.Context, message string, from Language, to Language) (string, error) {
+	p.calls++
+	switch {
+	case strings.Contains(message, "fail"):
+		return "", errors.New("unavailable")
+	case strings.Contains(message, "drop"):
+		return "[" + string(to) + "] dropped", nil
+	}
+	return "[" + string(to) + "] " + message, nil
+}
+
+func TestMachineTranslation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello", "b": "hi {{name}}", "c": "fail", "d": "drop {{name}}"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	provider := &pseudoProvider{}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithMachineTranslation(provider)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "name", "Bob")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("human translation", fn("de", "a", "hallo"))
+	t.Run("machine translation", fn("de", "b", "[de] hi Bob"))
+	t.Run("cached", fn("de", "b", "[de] hi Bob"))
+	t.Run("default language", fn("en", "b", "hi Bob"))
+	t.Run("provider failure", fn("de", "c", "fail"))
+	t.Run("dropped intermediates", fn("de", "d", "drop Bob"))
+
+	if provider.calls != 3 {
+		t.Fatalf("expected 3 calls to the provider, got %d", provider.calls)
+	}
+
+	// failures are not retried until the retry interval passed
+	t.Run("cached failure", fn("de", "c", "fail"))
+	t.Run("cached rejection", fn("de", "d", "drop Bob"))
+	if provider.calls != 3 {
+		t.Fatalf("expected failures not to be retried, got %d calls", provider.calls)
+	}
+
+	for k := range translations.machine.failures {
+		translations.machine.failures[k] = time.Now().Add(-machineRetryInterval)
+	}
+	t.Run("retried failure", fn("de", "c", "fail"))
+	if provider.calls != 4 {
+		t.Fatalf("expected failure to be retried, got %d calls", provider.calls)
+	}
+}
+
+func TestMachineTranslationTargets(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"b": "hallo"}`)},
+	}
+
+	fn := func(targets []Language, requested []string, expectedCalls int) func(t *testing.T) {
+		return func(t *testing.T) {
+			provider := &pseudoProvider{}
+			translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithMachineTranslation(provider, targets...)).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, lang := range requested {
+				translations.GenerateTranslate(lang)("a")
+			}
+			if provider.calls != expectedCalls {
+				t.Fatalf("expected %d calls to the provider, got %d", expectedCalls, provider.calls)
+			}
+			if cached := len(translations.machine.cache); cached != expectedCalls {
+				t.Fatalf("expected %d cached translations, got %d", expectedCalls, cached)
+			}
+		}
+	}
+
+	t.Run("arbitrary languages", fn(nil, []string{"xx", "x-abc123", "fr", "en-us"}, 0))
+	t.Run("regional languages", fn(nil, []string{"de", "de-at", "de-ch"}, 1))
+	t.Run("targets", fn([]Language{"fr"}, []string{"de", "fr", "fr-ca", "it"}, 1))
+
+	mt := &machineTranslator{failures: make(map[machineKey]time.Time)}
+	for i := 0; i <= maxMachineTranslations; i++ {
+		mt.fail(machineKey{lang: "de", message: strconv.Itoa(i)})
+	}
+	if len(mt.failures) > maxMachineTranslations {
+		t.Fatalf("expected at most %d tracked failures, got %d", maxMachineTranslations, len(mt.failures))
+	}
+}
+
+type deadlineProvider struct {
+	deadline time.Time
+}
+
+func (p *deadlineProvider) Translate(ctx context.Context, message string, from Language, to Language) (string, error) {
+	p.deadline, _ = ctx.Deadline()
+	return message, nil
+}
+
+func TestMachineTranslationTimeout(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello", "b": "hi"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"c": "hallo"}`)},
+	}
+
+	provider := &deadlineProvider{}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithMachineTranslation(provider)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := translations.GenerateTranslate("de")("a"); err != nil {
+		t.Fatal(err)
+	}
+	if remaining := time.Until(provider.deadline); remaining <= 0 || remaining > machineTimeout {
+		t.Fatalf("expected requests without deadline to time out within %v, got deadline %v", machineTimeout, provider.deadline)
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	if _, err := translations.TranslateCtx(ctx, "de", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if !provider.deadline.Equal(deadline) {
+		t.Fatalf("expected deadline of the context %v, got %v", deadline, provider.deadline)
+	}
+}
+
+type blockingProvider struct{}
+
+func (blockingProvider) Translate(ctx context.Context, message string, from Language, to Language) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestTranslateCtx(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello", "b": "hi {{name}}"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithMachineTranslation(blockingProvider{})).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(ctx context.Context, lang string, key string, expected string, expectedErr error) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.TranslateCtx(ctx, lang, key, "name", "Bob")
+			if !errors.Is(err, expectedErr) {
+				t.Fatalf("expected error %v, got %v", expectedErr, err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelTimeout()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("human translation", fn(timeout, "de", "a", "hallo", nil))
+	t.Run("default language", fn(timeout, "en", "b", "hi Bob", nil))
+	t.Run("deadline exceeded", fn(timeout, "de", "b", "", context.DeadlineExceeded))
+	t.Run("cancelled", fn(cancelled, "de", "a", "", context.Canceled))
+}
@@ -0,0 +1,73 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// verifyManifest verifies the SHA-256 hashes of the files listed by the manifest, reporting
+// files missing or whose content differs. The manifest lists a hash and a path relative to
+// the manifest per line as printed by sha256sum.
+// Language, tenant, included and extended files not listed are reported as well.
+func (l *loader) verifyManifest() {
+	name := path.Clean(l.trl.manifest)
+	data, err := fs.ReadFile(l.fsys, name)
+	if err != nil {
+		l.report(name, "", "", fmt.Sprintf("invalid manifest: %v", err))
+		return
+	}
+
+	l.checksums = make(map[string]string)
+	lines := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; lines.Scan(); n++ {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || len(fields[0]) != 2*sha256.Size {
+			l.report(name, "", "", fmt.Sprintf("invalid manifest in line %d, must list a hash and a path", n))
+			continue
+		}
+		if _, err := hex.DecodeString(fields[0]); err != nil {
+			l.report(name, "", "", fmt.Sprintf("invalid manifest in line %d, invalid hash %q", n, fields[0]))
+			continue
+		}
+		file := path.Join(path.Dir(name), strings.TrimPrefix(fields[1], "*"))
+		l.checksums[file] = strings.ToLower(fields[0])
+	}
+
+	for _, file := range sortedNames(l.checksums) {
+		checksum, err := fileChecksum(l.fsys, file)
+		if err != nil {
+			l.report(file, "", "", fmt.Sprintf("file listed in manifest can not be verified: %v", err))
+			continue
+		}
+		if checksum != l.checksums[file] {
+			l.report(file, "", "", fmt.Sprintf("checksum mismatch, expected sha256 %s, got %s", l.checksums[file], checksum))
+		}
+	}
+}
+
+// fileChecksum returns the hex encoded SHA-256 hash of the file
+func fileChecksum(fsys fs.FS, name string) (string, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
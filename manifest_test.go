@@ -0,0 +1,76 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestManifest(t *testing.T) {
+	en := []byte(`{"a": "hello"}`)
+	de := []byte(`{"a": "hallo"}`)
+	checksum := func(data []byte) string {
+		sum := sha256.Sum256(data)
+		return hex.EncodeToString(sum[:])
+	}
+
+	fn := func(manifest string, files map[string][]byte, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{"SHA256SUMS": &fstest.MapFile{Data: []byte(manifest)}}
+			for name, data := range files {
+				fsys[name] = &fstest.MapFile{Data: data}
+			}
+
+			_, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithManifest("SHA256SUMS")).Load()
+			if expected == "" && err != nil {
+				t.Fatal(err)
+			}
+			if expected != "" && (err == nil || !strings.Contains(err.Error(), expected)) {
+				t.Fatalf("expected error %q, got %v", expected, err)
+			}
+		}
+	}
+
+	valid := checksum(en) + "  en.json\n" + checksum(de) + " *de.json\n"
+	t.Run("valid", fn(valid, map[string][]byte{"en.json": en, "de.json": de}, ""))
+	t.Run("tampered", fn(valid, map[string][]byte{"en.json": en, "de.json": []byte(`{"a": "evil"}`)}, "checksum mismatch, expected sha256 "+checksum(de)))
+	t.Run("missing", fn(valid, map[string][]byte{"en.json": en}, "file listed in manifest can not be verified"))
+	t.Run("not listed", fn(checksum(en)+"  en.json\n", map[string][]byte{"en.json": en, "de.json": de}, `file not listed in manifest for "de" in "de.json"`))
+	t.Run("invalid line", fn("# hashes\n"+checksum(en)+"\n", map[string][]byte{"en.json": en}, "invalid manifest in line 2"))
+	t.Run("invalid hash", fn(strings.Repeat("x", 64)+"  en.json\n", map[string][]byte{"en.json": en}, "invalid hash"))
+
+	including := []byte(`{"$include": "shared.json"}`)
+	extending := []byte(`{"$extends": "base/en.json"}`)
+	shared := []byte(`{"a": "hello"}`)
+	t.Run("included", fn(checksum(including)+"  en.json\n"+checksum(shared)+"  shared.json\n", map[string][]byte{"en.json": including, "shared.json": shared}, ""))
+	t.Run("included not listed", fn(checksum(including)+"  en.json\n", map[string][]byte{"en.json": including, "shared.json": shared}, `invalid include "shared.json", file not listed in manifest`))
+	t.Run("included tampered", fn(checksum(including)+"  en.json\n"+checksum(shared)+"  shared.json\n", map[string][]byte{"en.json": including, "shared.json": []byte(`{"a": "evil"}`)}, "checksum mismatch"))
+
+	extended := fstest.MapFS{
+		"SHA256SUMS":   &fstest.MapFile{Data: []byte(checksum(extending) + "  en.json\n")},
+		"en.json":      &fstest.MapFile{Data: extending},
+		"base/en.json": &fstest.MapFile{Data: shared},
+	}
+	var reported bool
+	for _, issue := range Validate(extended, "en", WithManifest("SHA256SUMS")) {
+		reported = reported || issue.File == "en.json" && issue.Message == `invalid extension "base/en.json", file not listed in manifest`
+	}
+	if !reported {
+		t.Fatal("expected unlisted extended file to be reported")
+	}
+
+	tenants := fstest.MapFS{
+		"SHA256SUMS":           &fstest.MapFile{Data: []byte(checksum(en) + "  en.json\n")},
+		"en.json":              &fstest.MapFile{Data: en},
+		"tenants/acme/en.json": &fstest.MapFile{Data: []byte(`{"a": "evil"}`)},
+	}
+	if _, err := New(WithFS(tenants), WithDefaultLanguage("en"), WithTenantDirectory("tenants"), WithManifest("SHA256SUMS")).Load(); err == nil || !strings.Contains(err.Error(), `file not listed in manifest for "en" in "tenants/acme/en.json"`) {
+		t.Fatalf("expected unlisted tenant file to be reported, got %v", err)
+	}
+
+	if _, err := New(WithFS(fstest.MapFS{"en.json": &fstest.MapFile{Data: en}}), WithDefaultLanguage("en"), WithManifest("SHA256SUMS")).Load(); err == nil {
+		t.Fatal("expected error for missing manifest")
+	}
+}
@@ -0,0 +1,239 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+)
+
+// UnitSystem is a system of measurement units
+type UnitSystem int
+
+const (
+	// Metric measures in meters, degrees Celsius, kilometers per hour, kilograms and liters
+	Metric UnitSystem = iota
+	// USSystem measures in feet and miles, degrees Fahrenheit, miles per hour, pounds and gallons
+	USSystem
+	// UKSystem measures long distances in miles and speeds in miles per hour, otherwise metric
+	UKSystem
+)
+
+// unitSystems are the systems by their names within format options
+var unitSystems = map[string]UnitSystem{
+	"metric": Metric,
+	"us":     USSystem,
+	"uk":     UKSystem,
+}
+
+// regionalUnitSystems are the systems of regions not measuring in metric units following CLDR
+var regionalUnitSystems = map[string]UnitSystem{
+	"gb": UKSystem,
+	"lr": USSystem,
+	"mm": USSystem,
+	"us": USSystem,
+}
+
+// MeasurementSystem returns the unit system of the region of the language, e.g. USSystem for
+// "en-us" and "en", UKSystem for "en-gb" and Metric for "de". Regions are assumed like
+// FirstDayOfWeek does.
+func MeasurementSystem(lang Language) UnitSystem {
+	if system, ok := regionalUnitSystems[region(lang)]; ok {
+		return system
+	}
+	return Metric
+}
+
+// Quantity is a physical quantity measured in units depending on the unit system
+type Quantity int
+
+// Supported quantities, measured in meters, degrees Celsius, kilometers per hour,
+// kilograms and liters
+const (
+	Distance Quantity = iota
+	Temperature
+	Speed
+	Mass
+	Volume
+)
+
+// quantities are the quantities by their names within format options
+var quantities = map[string]Quantity{
+	"distance":    Distance,
+	"temperature": Temperature,
+	"speed":       Speed,
+	"mass":        Mass,
+	"volume":      Volume,
+}
+
+// measureUnit indexes the units of measures
+type measureUnit int
+
+const (
+	meter measureUnit = iota
+	kilometer
+	foot
+	mile
+	celsius
+	fahrenheit
+	kilometerPerHour
+	milePerHour
+	kilogram
+	pound
+	liter
+	gallon
+	measureUnitCount
+)
+
+// metersPerMile and metersPerFoot convert distances
+const (
+	metersPerMile = 1609.344
+	metersPerFoot = 0.3048
+)
+
+// measureNames are the names of the units of measures in a language
+type measureNames struct {
+	// one and other are the wide names for a single and for multiple units
+	one, other [measureUnitCount]string
+	short      [measureUnitCount]string
+	// narrowSeparator separates the number from the short unit in the narrow style
+	narrowSeparator string
+	// attachedDegrees reports whether degrees follow the number without space as in English
+	attachedDegrees bool
+	// singularBelowTwo reports whether less than two units are named in singular as in French
+	singularBelowTwo bool
+}
+
+// measureNamesByLanguage lists the names of units following the CLDR.
+// Languages not listed format measures in English.
+var measureNamesByLanguage = map[Language]measureNames{
+	"de": {
+		one:             [...]string{"Meter", "Kilometer", "Fuß", "Meile", "Grad Celsius", "Grad Fahrenheit", "Kilometer pro Stunde", "Meile pro Stunde", "Kilogramm", "Pfund", "Liter", "Gallone"},
+		other:           [...]string{"Meter", "Kilometer", "Fuß", "Meilen", "Grad Celsius", "Grad Fahrenheit", "Kilometer pro Stunde", "Meilen pro Stunde", "Kilogramm", "Pfund", "Liter", "Gallonen"},
+		short:           [...]string{"m", "km", "ft", "mi", "°C", "°F", "km/h", "mi/h", "kg", "lb", "l", "gal"},
+		narrowSeparator: noBreakSpace,
+	},
+	"en": {
+		one:             [...]string{"meter", "kilometer", "foot", "mile", "degree Celsius", "degree Fahrenheit", "kilometer per hour", "mile per hour", "kilogram", "pound", "liter", "gallon"},
+		other:           [...]string{"meters", "kilometers", "feet", "miles", "degrees Celsius", "degrees Fahrenheit", "kilometers per hour", "miles per hour", "kilograms", "pounds", "liters", "gallons"},
+		short:           [...]string{"m", "km", "ft", "mi", "°C", "°F", "km/h", "mph", "kg", "lb", "L", "gal"},
+		attachedDegrees: true,
+	},
+	"es": {
+		one:             [...]string{"metro", "kilómetro", "pie", "milla", "grado Celsius", "grado Fahrenheit", "kilómetro por hora", "milla por hora", "kilogramo", "libra", "litro", "galón"},
+		other:           [...]string{"metros", "kilómetros", "pies", "millas", "grados Celsius", "grados Fahrenheit", "kilómetros por hora", "millas por hora", "kilogramos", "libras", "litros", "galones"},
+		short:           [...]string{"m", "km", "ft", "mi", "°C", "°F", "km/h", "mi/h", "kg", "lb", "l", "gal"},
+		narrowSeparator: noBreakSpace,
+	},
+	"fr": {
+		one:              [...]string{"mètre", "kilomètre", "pied", "mile", "degré Celsius", "degré Fahrenheit", "kilomètre-heure", "mile à l’heure", "kilogramme", "livre", "litre", "gallon"},
+		other:            [...]string{"mètres", "kilomètres", "pieds", "miles", "degrés Celsius", "degrés Fahrenheit", "kilomètres-heure", "miles à l’heure", "kilogrammes", "livres", "litres", "gallons"},
+		short:            [...]string{"m", "km", "pi", "mi", "°C", "°F", "km/h", "mi/h", "kg", "lb", "l", "gal"},
+		narrowSeparator:  noBreakSpace,
+		singularBelowTwo: true,
+	},
+}
+
+// FormatMeasure formats the value of the quantity in the unit system of the language, see
+// MeasurementSystem, e.g. "3.1 mi" for 5000 meters in US English. Values are given in metric
+// units and converted into the system, see FormatMeasureIn.
+func FormatMeasure(lang Language, value float64, quantity Quantity, style Style) string {
+	return FormatMeasureIn(lang, value, quantity, style, MeasurementSystem(lang))
+}
+
+// FormatMeasureIn formats the value of the quantity in the unit system, e.g. "5 km",
+// "5 kilometers" or "5km" in English for 5000 meters. Values are given in meters, degrees
+// Celsius, kilometers per hour, kilograms and liters. Distances below a kilometer or a tenth
+// of a mile are formatted in meters or feet. Numbers are rounded to a single fraction digit and
+// separated from the unit by no-break spaces. Languages lacking unit names format in English.
+func FormatMeasureIn(lang Language, value float64, quantity Quantity, style Style, system UnitSystem) string {
+	unit := meter
+	switch quantity {
+	case Distance:
+		switch {
+		case system != Metric && math.Abs(value) >= metersPerMile/10:
+			value, unit = value/metersPerMile, mile
+		case system == USSystem:
+			value, unit = value/metersPerFoot, foot
+		case math.Abs(value) >= 1000:
+			value, unit = value/1000, kilometer
+		}
+	case Temperature:
+		unit = celsius
+		if system == USSystem {
+			value, unit = value*9/5+32, fahrenheit
+		}
+	case Speed:
+		unit = kilometerPerHour
+		if system != Metric {
+			value, unit = value*1000/metersPerMile, milePerHour
+		}
+	case Mass:
+		unit = kilogram
+		if system == USSystem {
+			value, unit = value/0.45359237, pound
+		}
+	case Volume:
+		unit = liter
+		if system == USSystem {
+			value, unit = value/3.785411784, gallon
+		}
+	}
+
+	names, ok := measureNamesByLanguage[lang.Base()]
+	if !ok {
+		names = measureNamesByLanguage["en"]
+	}
+	return names.format(lang, math.Round(value*10)/10, unit, style)
+}
+
+// format formats the rounded value in the unit and style
+func (names measureNames) format(lang Language, value float64, unit measureUnit, style Style) string {
+	number, _ := FormatNumber(lang, value, ".#")
+
+	degrees := unit == celsius || unit == fahrenheit
+	switch {
+	case style != Wide && degrees && names.attachedDegrees:
+		return number + names.short[unit]
+	case style == Short:
+		return number + noBreakSpace + names.short[unit]
+	case style == Narrow:
+		return number + names.narrowSeparator + names.short[unit]
+	}
+
+	if value == 1 || names.singularBelowTwo && math.Abs(value) < 2 {
+		return number + noBreakSpace + names.one[unit]
+	}
+	return number + noBreakSpace + names.other[unit]
+}
+
+// measureOptions are the options of the measure format: the quantity of the value, the style
+// and the unit system overriding the system of the language
+var measureOptions = []string{"quantity", "style", "system"}
+
+// formatMeasure formats numeric parameter values as measures of the quantity option,
+// e.g. {{distance, measure(quantity=distance)}} for a distance in meters
+func formatMeasure(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error) {
+	number, err := numberOf(value)
+	if err != nil {
+		return "", err
+	}
+
+	quantity, ok := quantities[options["quantity"]]
+	if !ok {
+		return "", fmt.Errorf("unknown quantity %q", options["quantity"])
+	}
+
+	style := Short
+	if name, ok := options["style"]; ok {
+		if style, ok = styles[name]; !ok {
+			return "", fmt.Errorf("unknown style %q", name)
+		}
+	}
+
+	system := MeasurementSystem(lang)
+	if name, ok := options["system"]; ok {
+		if system, ok = unitSystems[name]; !ok {
+			return "", fmt.Errorf("unknown unit system %q", name)
+		}
+	}
+	return FormatMeasureIn(lang, number, quantity, style, system), nil
+}
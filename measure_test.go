@@ -0,0 +1,84 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestMeasurementSystem(t *testing.T) {
+	fn := func(lang Language, expected UnitSystem) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := MeasurementSystem(lang); got != expected {
+				t.Fatalf("expected %d, got %d", expected, got)
+			}
+		}
+	}
+
+	t.Run("united states", fn("en-us", USSystem))
+	t.Run("likely region", fn("en", USSystem))
+	t.Run("united kingdom", fn("en-gb", UKSystem))
+	t.Run("australia", fn("en-au", Metric))
+	t.Run("german", fn("de", Metric))
+	t.Run("unknown", fn("xx", Metric))
+}
+
+func TestFormatMeasure(t *testing.T) {
+	fn := func(lang Language, value float64, quantity Quantity, style Style, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FormatMeasure(lang, value, quantity, style); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("metric distance", fn("de", 5000, Distance, Short, "5\u00a0km"))
+	t.Run("metric short distance", fn("de", 250, Distance, Wide, "250\u00a0Meter"))
+	t.Run("us distance", fn("en-us", 5000, Distance, Short, "3.1\u00a0mi"))
+	t.Run("us short distance", fn("en-us", 100, Distance, Wide, "328.1\u00a0feet"))
+	t.Run("uk distance", fn("en-gb", 5000, Distance, Wide, "3.1\u00a0miles"))
+	t.Run("uk short distance", fn("en-gb", 100, Distance, Short, "100\u00a0m"))
+	t.Run("us temperature", fn("en", 20, Temperature, Short, "68°F"))
+	t.Run("uk temperature", fn("en-gb", 20, Temperature, Narrow, "20°C"))
+	t.Run("german temperature", fn("de", 20.5, Temperature, Short, "20,5\u00a0°C"))
+	t.Run("uk speed", fn("en-gb", 100, Speed, Short, "62.1\u00a0mph"))
+	t.Run("us mass", fn("en", 1, Mass, Wide, "2.2\u00a0pounds"))
+	t.Run("singular", fn("en-gb", 1, Mass, Wide, "1\u00a0kilogram"))
+	t.Run("french singular", fn("fr", 1.5, Mass, Wide, "1,5\u00a0kilogramme"))
+	t.Run("us volume", fn("en", 10, Volume, Short, "2.6\u00a0gal"))
+	t.Run("narrow", fn("en", 5000, Distance, Narrow, "3.1mi"))
+	t.Run("fallback", fn("xx", 5000, Distance, Wide, "5\u00a0kilometers"))
+
+	if got := FormatMeasureIn("en", 5000, Distance, Short, Metric); got != "5\u00a0km" {
+		t.Fatalf("expected metric override, got %q", got)
+	}
+}
+
+func TestMeasureFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"distance": "{{meters, measure(quantity=distance)}} away",
+			"metric": "{{meters, measure(quantity=distance; system=metric; style=wide)}} away",
+			"unknown": "{{meters, measure(quantity=time)}} away"
+		}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "meters", 5000)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("system of language", fn("en-us", "distance", "3.1\u00a0mi away"))
+	t.Run("system override", fn("en-us", "metric", "5\u00a0kilometers away"))
+
+	if _, err := translations.GenerateTranslate("en")("unknown", "meters", 5000); err == nil {
+		t.Fatal("expected error for unknown quantity")
+	}
+}
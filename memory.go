@@ -0,0 +1,128 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Suggestion is an existing translation of a source string similar to the one to be translated
+type Suggestion struct {
+	// Key is the key of the existing translation
+	Key Key
+	// Source is the message of the key in the default language
+	Source string
+	// Translation is the message of the key in the target language
+	Translation string
+	// Score is the similarity of the sources between 0 and 1, 1 denoting identical sources
+	Score float64
+}
+
+// Suggest searches the loaded translations as translation memory, returning the translations
+// into the target language of keys whose default language message is similar to the source.
+// Only suggestions scoring at least minScore are returned, the most similar first.
+func (trl Translations) Suggest(source string, target Language, minScore float64) []Suggestion {
+	return trl.suggest(source, target, minScore, "")
+}
+
+// SuggestFor returns the suggestions for translating the key into the target language,
+// using the message of the key in the default language as source
+func (trl Translations) SuggestFor(key Key, target Language, minScore float64) ([]Suggestion, error) {
+	key = trl.canonical(trl.normalizeKey(key))
+	source, ok := trl.translations[trl.defaultLanguage][key]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", key)
+	}
+	return trl.suggest(source.Message, target, minScore, key), nil
+}
+
+// suggest returns the suggestions for the source, excluding the key if not empty
+func (trl Translations) suggest(source string, target Language, minScore float64, exclude Key) []Suggestion {
+	translations := trl.translations[normalizeLanguage(string(target))]
+	sourceRunes := []rune(source)
+
+	var suggestions []Suggestion
+	for key, original := range trl.translations[trl.defaultLanguage] {
+		if key == exclude {
+			continue
+		}
+		translation, ok := translations[key]
+		if !ok {
+			continue
+		}
+
+		score := similarity(sourceRunes, []rune(original.Message), minScore)
+		if score < minScore {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Key:         key,
+			Source:      original.Message,
+			Translation: translation.Message,
+			Score:       score,
+		})
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Key < suggestions[j].Key
+	})
+	return suggestions
+}
+
+// similarity returns one minus the edit distance of a and b relative to the longer one.
+// Strings whose lengths differ too much for scoring at least minScore are scored 0
+// without computing their distance.
+func similarity(a []rune, b []rune, minScore float64) float64 {
+	longest := len(a)
+	if len(b) > longest {
+		longest = len(b)
+	}
+	if longest == 0 {
+		return 1
+	}
+
+	difference := len(a) - len(b)
+	if difference < 0 {
+		difference = -difference
+	}
+	if 1-float64(difference)/float64(longest) < minScore {
+		return 0
+	}
+
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// levenshtein returns the number of insertions, deletions and substitutions transforming a into b
+func levenshtein(a []rune, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// min3 returns the minimum of three integers
+func min3(a int, b int, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,60 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLevenshtein(t *testing.T) {
+	fn := func(a string, b string, expected int) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := levenshtein([]rune(a), []rune(b)); got != expected {
+				t.Fatalf("expected %d for %q and %q, got %d", expected, a, b, got)
+			}
+		}
+	}
+
+	t.Run("identical", fn("save", "save", 0))
+	t.Run("empty", fn("", "save", 4))
+	t.Run("substitution", fn("save", "sane", 1))
+	t.Run("insertion", fn("save", "saved", 1))
+	t.Run("classic", fn("kitten", "sitting", 3))
+	t.Run("runes", fn("größe", "grösse", 2))
+}
+
+func TestSuggest(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"save": "Save changes", "store": "Save change", "cancel": "Cancel", "delete": "Delete changes"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"save": "Änderungen speichern", "store": "Änderung speichern", "cancel": "Abbrechen"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := translations.Suggest("Save changes", "de", 0.8)
+	expected := []Suggestion{
+		{Key: "save", Source: "Save changes", Translation: "Änderungen speichern", Score: 1},
+		{Key: "store", Source: "Save change", Translation: "Änderung speichern", Score: 1 - 1.0/12},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+
+	suggestions, err := translations.SuggestFor("store", "de", 0.8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(suggestions) != 1 || suggestions[0].Key != "save" {
+		t.Fatalf("unexpected suggestions %v", suggestions)
+	}
+
+	if suggestions := translations.Suggest("Delete changes", "de", 0.9); len(suggestions) != 0 {
+		t.Fatalf("expected no suggestions for untranslated key, got %v", suggestions)
+	}
+	if _, err := translations.SuggestFor("unknown", "de", 0.8); err == nil {
+		t.Fatal("expected error for unknown key")
+	}
+}
@@ -0,0 +1,65 @@
+package i18n
+
+import (
+	"html"
+	"html/template"
+	"strings"
+)
+
+// Locale returns the language in the language_TERRITORY form of
+// Open Graph, e.g. "de_AT" for "de-at" and "de" for "de"
+func (lang Language) Locale() string {
+	t, ok := parseTag(lang)
+	if !ok {
+		return string(lang)
+	}
+	if t.region == "" {
+		return t.base
+	}
+	return t.base + "_" + strings.ToUpper(t.region)
+}
+
+// servedLanguage returns the loaded language serving the negotiated language
+func (trl Translations) servedLanguage(negotiated string) Language {
+	if lang, ok := trl.match(negotiated); ok {
+		return lang
+	}
+	return trl.defaultLanguage
+}
+
+// HTMLAttributes returns the lang and dir attributes of the html element
+// for the language serving the negotiated language, e.g. lang="he" dir="rtl"
+func (trl Translations) HTMLAttributes(negotiated string) template.HTMLAttr {
+	lang := trl.servedLanguage(negotiated)
+	return template.HTMLAttr(`lang="` + html.EscapeString(lang.Tag()) + `" dir="` + string(lang.Direction()) + `"`)
+}
+
+// OpenGraphLocales returns the og:locale meta tag of the language serving the negotiated
+// language followed by og:locale:alternate meta tags of the other loaded languages.
+// Private use languages are not listed as alternates, being synthetic.
+func (trl Translations) OpenGraphLocales(negotiated string) template.HTML {
+	lang := trl.servedLanguage(negotiated)
+
+	var b strings.Builder
+	b.WriteString(`<meta property="og:locale" content="` + html.EscapeString(lang.Locale()) + `">`)
+	for _, alternate := range trl.orderedLanguages() {
+		if alternate == lang || alternate.PrivateUse() {
+			continue
+		}
+		b.WriteString("\n" + `<meta property="og:locale:alternate" content="` + html.EscapeString(alternate.Locale()) + `">`)
+	}
+	return template.HTML(b.String())
+}
+
+// TemplateFuncs returns the helpers for html templates, called with the negotiated language:
+//
+//	<html {{htmlAttributes .Language}}>
+//	<head>{{openGraphLocales .Language}}</head>
+//	{{with formField .Language "signup" "email"}}<label>{{.Label}}</label>{{end}}
+func (trl Translations) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"htmlAttributes":   trl.HTMLAttributes,
+		"openGraphLocales": trl.OpenGraphLocales,
+		"formField":        trl.FormField,
+	}
+}
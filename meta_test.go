@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMeta(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":       &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de-at.json":    &fstest.MapFile{Data: []byte(`{"a": "servus"}`)},
+		"he.json":       &fstest.MapFile{Data: []byte(`{"a": "שלום"}`)},
+		"x-pseudo.json": &fstest.MapFile{Data: []byte(`{"a": "[ħéľľö]"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(negotiated string, attributes string, locales string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.HTMLAttributes(negotiated); string(got) != attributes {
+				t.Fatalf("expected attributes %q, got %q", attributes, got)
+			}
+			if got := translations.OpenGraphLocales(negotiated); string(got) != locales {
+				t.Fatalf("expected locales %q, got %q", locales, got)
+			}
+		}
+	}
+
+	t.Run("region", fn("de-AT", `lang="de-AT" dir="ltr"`,
+		`<meta property="og:locale" content="de_AT">`+"\n"+
+			`<meta property="og:locale:alternate" content="en">`+"\n"+
+			`<meta property="og:locale:alternate" content="he">`))
+	t.Run("right to left", fn("he-IL", `lang="he" dir="rtl"`,
+		`<meta property="og:locale" content="he">`+"\n"+
+			`<meta property="og:locale:alternate" content="en">`+"\n"+
+			`<meta property="og:locale:alternate" content="de_AT">`))
+	t.Run("unavailable", fn("fr", `lang="en" dir="ltr"`,
+		`<meta property="og:locale" content="en">`+"\n"+
+			`<meta property="og:locale:alternate" content="de_AT">`+"\n"+
+			`<meta property="og:locale:alternate" content="he">`))
+
+	tmpl := template.Must(template.New("").Funcs(translations.TemplateFuncs()).Parse(`<html {{htmlAttributes .}}>`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, "he"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := `<html lang="he" dir="rtl">`; b.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, b.String())
+	}
+}
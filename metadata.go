@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// MetadataPrefix marks a key within a language file as metadata of the sibling key named
+// without the prefix, e.g. "@title" describes "title" (following the ARB convention)
+const MetadataPrefix = "@"
+
+// Metadata carries additional information about a key meant for translators and tooling
+type Metadata struct {
+	// Description explains the context a translation is used in
+	Description string `json:"description"`
+	// MaxLength is the maximum number of characters of a translation, not counting
+	// the content of intermediates. Zero imposes no limit.
+	MaxLength int `json:"maxLength"`
+	// Deprecated marks the key as deprecated, explaining e.g. which key to use instead.
+	// Translating a deprecated key emits a warning to the logger.
+	Deprecated string `json:"deprecated"`
+	// Alias declares the key as an alias of the given canonical key. An alias has no translations
+	// on its own, translating it translates the canonical key instead.
+	Alias Key `json:"alias"`
+	// Variants declares the weights of the experiment variants of the key by their name,
+	// e.g. {"short": 1, "long": 3} serving "key#short" to a quarter of the experiment units
+	Variants map[string]int `json:"variants"`
+	// Modified is the time the translation was last modified in the language declaring it,
+	// e.g. as exported by a translation management system. It takes precedence over the
+	// modification time of the language file. See Translations.Modified.
+	Modified time.Time `json:"modified"`
+}
+
+// merge fills the fields of m not yet set from other
+func (m Metadata) merge(other Metadata) Metadata {
+	if m.Description == "" {
+		m.Description = other.Description
+	}
+	if m.MaxLength == 0 {
+		m.MaxLength = other.MaxLength
+	}
+	if m.Deprecated == "" {
+		m.Deprecated = other.Deprecated
+	}
+	if m.Alias == "" {
+		m.Alias = other.Alias
+	}
+	if m.Variants == nil {
+		m.Variants = other.Variants
+	}
+	return m
+}
+
+// Metadata returns the metadata declared for the key in any of the language files
+func (trl Translations) Metadata(key Key) (Metadata, bool) {
+	metadata, ok := trl.metadata[trl.normalizeKey(key)]
+	return metadata, ok
+}
+
+// canonical resolves the key if it is an alias
+func (trl Translations) canonical(key Key) Key {
+	if alias := trl.metadata[key].Alias; alias != "" {
+		return alias
+	}
+	return key
+}
+
+// warnDeprecated emits a warning if the key is deprecated
+func (trl Translations) warnDeprecated(key Key) {
+	if reason := trl.metadata[key].Deprecated; reason != "" {
+		trl.logger.Printf("i18n: translated deprecated key %q: %s", key, reason)
+	}
+}
+
+// Check verifies the loaded translations against their metadata,
+// reporting every translation exceeding the maximum length of its key
+func (trl Translations) Check() []Issue {
+	var issues []Issue
+	for _, lang := range sortedLanguages(trl.translations) {
+		store := trl.translations[lang]
+		for _, key := range sortedKeys(store) {
+			maxLength := trl.metadata[key].MaxLength
+			if maxLength <= 0 {
+				continue
+			}
+
+			if length := store[key].length(); length > maxLength {
+				issues = append(issues, Issue{
+					Language: lang,
+					Key:      key,
+					Message:  fmt.Sprintf("translation of %d characters exceeds maximum length of %d", length, maxLength),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// length returns the number of characters of the message without its intermediates
+func (t Translation) length() int {
+	if t.segments == nil {
+		return utf8.RuneCountInString(t.Message)
+	}
+
+	length := 0
+	for _, segment := range t.segments {
+		length += utf8.RuneCountInString(segment.literal)
+	}
+	return length
+}
@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMetadata(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"button": {
+				"save": "Save",
+				"@save": {"description": "label of the save button", "maxLength": 10},
+				"cancel": "Cancel {{name}}",
+				"@cancel": {"maxLength": 7}
+			}
+		}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{
+			"button": {
+				"save": "Speichern und schließen",
+				"@save": {"description": "Beschriftung", "maxLength": 30},
+				"cancel": "Abbruch {{name}}"
+			}
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// metadata of the default language takes precedence
+	metadata, ok := translations.Metadata("button.save")
+	if !ok || metadata.Description != "label of the save button" || metadata.MaxLength != 10 {
+		t.Fatalf("unexpected metadata %+v", metadata)
+	}
+	if translations.Has("en", "button.@save") || translations.Has("en", "@save") {
+		t.Fatal("metadata must not be loaded as translations")
+	}
+
+	issues := translations.Check()
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %v", issues)
+	}
+	if issues[0].Language != "de" || issues[0].Key != "button.cancel" {
+		t.Fatalf("unexpected issue %v", issues[0])
+	}
+	if issues[1].Language != "de" || issues[1].Key != "button.save" {
+		t.Fatalf("unexpected issue %v", issues[1])
+	}
+}
+
+func TestInvalidMetadata(t *testing.T) {
+	fn := func(data string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(data)},
+			}
+
+			if issues := Validate(fsys, "en"); len(issues) != 1 {
+				t.Fatalf("expected a single issue, got %v", issues)
+			}
+		}
+	}
+
+	t.Run("no object", fn(`{"a": "x", "@a": "description"}`))
+	t.Run("invalid field", fn(`{"a": "x", "@a": {"maxLength": "10"}}`))
+	t.Run("negative length", fn(`{"a": "x", "@a": {"maxLength": -1}}`))
+	t.Run("empty key", fn(`{"a": "x", "@": {}}`))
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+func TestDeprecated(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"login": "Login",
+			"@login": {"deprecated": "use auth.login instead"},
+			"auth": {"login": "Login"}
+		}`)},
+	}
+
+	logger := &recordingLogger{}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithLogger(logger)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translate := translations.GenerateDefaultTranslate()
+	for _, key := range []string{"login", "auth.login", "login"} {
+		if _, err := translate(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(logger.messages) != 2 {
+		t.Fatalf("expected 2 warnings, got %v", logger.messages)
+	}
+	if !strings.Contains(logger.messages[0], "use auth.login instead") {
+		t.Fatalf("unexpected warning %q", logger.messages[0])
+	}
+}
+
+func TestAliases(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"login": {"@title": {"alias": "auth.login.title"}},
+			"auth": {"login": {"title": "Sign in, {{name}}"}}
+		}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{
+			"auth": {"login": {"title": "Anmelden, {{name}}"}}
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !translations.Has("de", "login.title") {
+		t.Fatal("expected alias to be resolved")
+	}
+
+	got, err := translations.GenerateTranslate("de")("login.title", "name", "Bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "Anmelden, Bob" {
+		t.Fatalf("unexpected translation %q", got)
+	}
+
+	fn := func(en string, de string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(en)},
+				"de.json": &fstest.MapFile{Data: []byte(de)},
+			}
+
+			if issues := Validate(fsys, "en"); len(issues) != 1 {
+				t.Fatalf("expected a single issue, got %v", issues)
+			}
+		}
+	}
+
+	t.Run("unknown canonical key", fn(`{"a": "x", "@b": {"alias": "c"}}`, `{"c": "y"}`))
+	t.Run("translated alias", fn(`{"a": "x", "@b": {"alias": "a"}}`, `{"b": "y"}`))
+	t.Run("alias of alias", fn(`{"a": "x", "@b": {"alias": "a"}, "@c": {"alias": "b"}}`, `{"a": "y"}`))
+}
@@ -0,0 +1,64 @@
+package i18n
+
+import (
+	"sync"
+	"time"
+)
+
+// maxTrackedMissingKeys bounds the number of missing keys whose reports are rate limited,
+// such that requests of arbitrary keys can not exhaust the memory
+const maxTrackedMissingKeys = 10000
+
+// MissingKey reports a key not translated in the requested language nor its fallbacks
+type MissingKey struct {
+	Language Language
+	Key      Key
+	// Suppressed is the number of reports of the key suppressed since the last report
+	Suppressed int
+}
+
+// missingReporter reports missing keys to a hook, at most once per interval for a key of a language
+type missingReporter struct {
+	hook     func(MissingKey)
+	interval time.Duration
+
+	mu       sync.Mutex
+	reported map[languageKey]*missingReport
+}
+
+// languageKey identifies a key of a language
+type languageKey struct {
+	lang Language
+	key  Key
+}
+
+// missingReport tracks the reports of a missing key
+type missingReport struct {
+	at         time.Time
+	suppressed int
+}
+
+// report reports the key missing in the language at the time unless reported within the interval
+func (r *missingReporter) report(lang Language, key Key, at time.Time) {
+	k := languageKey{lang: lang, key: key}
+
+	r.mu.Lock()
+	last, ok := r.reported[k]
+	if ok && at.Sub(last.at) < r.interval {
+		last.suppressed++
+		r.mu.Unlock()
+		return
+	}
+	if !ok && len(r.reported) >= maxTrackedMissingKeys {
+		r.reported = make(map[languageKey]*missingReport)
+	}
+
+	suppressed := 0
+	if ok {
+		suppressed = last.suppressed
+	}
+	r.reported[k] = &missingReport{at: at}
+	r.mu.Unlock()
+
+	r.hook(MissingKey{Language: lang, Key: key, Suppressed: suppressed})
+}
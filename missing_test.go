@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestMissingKeyHook(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	var reports []MissingKey
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithMissingKeyHook(func(missing MissingKey) {
+		reports = append(reports, missing)
+	}, time.Hour)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translate := translations.GenerateTranslate("de")
+	for i := 0; i < 1000; i++ {
+		translate("a")
+		translate("b")
+	}
+	translations.GenerateTranslate("en")("b")
+
+	expected := []MissingKey{{Language: "de", Key: "b"}, {Language: "en", Key: "b"}}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Fatalf("expected %v, got %v", expected, reports)
+	}
+}
+
+func TestMissingKeyProbes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"forms": {"shared": {"email": {"label": "Email"}}},
+			"errors": {"404": {"title": "Not found"}}
+		}`)},
+	}
+
+	var reports []MissingKey
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithMissingKeyHook(func(missing MissingKey) {
+		reports = append(reports, missing)
+	}, time.Hour)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// optional texts and keys preceding a fallback are probed without being reported
+	translations.FormField("en", "signup", "email")
+	translations.Problem("en", 404, "not_found")
+	if len(reports) != 0 {
+		t.Fatalf("expected no reports for probed keys, got %v", reports)
+	}
+
+	translations.FormField("en", "signup", "name")
+	translations.Problem("en", 500, "")
+	expected := []MissingKey{{Language: "en", Key: "forms.signup.name.label"}, {Language: "en", Key: "errors.500.title"}}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Fatalf("expected %v, got %v", expected, reports)
+	}
+}
+
+func TestMissingReporter(t *testing.T) {
+	var reports []MissingKey
+	reporter := &missingReporter{
+		hook:     func(missing MissingKey) { reports = append(reports, missing) },
+		interval: time.Minute,
+		reported: make(map[languageKey]*missingReport),
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	reporter.report("de", "a", start)
+	reporter.report("de", "a", start.Add(time.Second))
+	reporter.report("de", "a", start.Add(30*time.Second))
+	reporter.report("de", "a", start.Add(time.Minute))
+	reporter.report("de", "a", start.Add(90*time.Second))
+
+	expected := []MissingKey{{Language: "de", Key: "a"}, {Language: "de", Key: "a", Suppressed: 2}}
+	if !reflect.DeepEqual(reports, expected) {
+		t.Fatalf("expected %v, got %v", expected, reports)
+	}
+}
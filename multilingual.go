@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultilingualSuffix is the suffix of language files listing the messages of all languages
+// side by side, e.g. "checkout.i18n.json" containing {"title": {"en": "Checkout", "de": "Kasse"}}.
+// Constants and metadata declared by such files apply to the default language.
+const MultilingualSuffix = ".i18n.json"
+
+// split divides the translations of a multilingual file into a file per language, the last
+// fragment of each key denoting the language, e.g. "title.de". The constants and metadata are
+// added to the default language.
+func (l *loader) split(filePath string, d *decoder) []decodedFile {
+	stores := make(map[Language]Store)
+	for _, key := range sortedKeys(d.store) {
+		i := strings.LastIndex(string(key), ".")
+		if i == -1 {
+			l.report(filePath, "", key, "invalid translation, must map languages to messages")
+			continue
+		}
+
+		lang := Language(strings.ToLower(string(key[i+1:])))
+		if !l.trl.languageRules.valid(lang) {
+			l.report(filePath, "", key, fmt.Sprintf("invalid language %q, allowed are only %s", lang, l.trl.languageRules.describe()))
+			continue
+		}
+
+		if stores[lang] == nil {
+			stores[lang] = make(Store)
+		}
+		stores[lang][Key(l.interned.intern(string(key[:i])))] = d.store[key]
+	}
+
+	files := []decodedFile{{path: filePath, lang: l.trl.defaultLanguage, constants: d.constants, metadata: d.metadata}}
+	for _, lang := range sortedLanguages(stores) {
+		files = append(files, decodedFile{path: filePath, lang: lang, store: stores[lang]})
+	}
+	return files
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestMultilingual(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"title": "Shop"}`)},
+		"features/checkout.i18n.json": &fstest.MapFile{Data: []byte(`{
+			"checkout": {
+				"@title": {"description": "Heading of the checkout page"},
+				"title": {"en": "Checkout", "de": "Kasse", "de-AT": "Zur Kassa"},
+				"total": {"en": "Total: {{amount}}", "de": "Summe: {{amount}}"}
+			}
+		}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, "amount", "3 €")
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("default language", fn("en", "checkout.title", "Checkout"))
+	t.Run("language", fn("de", "checkout.total", "Summe: 3 €"))
+	t.Run("regional language", fn("de-at", "checkout.title", "Zur Kassa"))
+	t.Run("other files", fn("en", "title", "Shop"))
+
+	if metadata, ok := translations.Metadata("checkout.title"); !ok || metadata.Description != "Heading of the checkout page" {
+		t.Fatalf("expected metadata of the key, got %+v", metadata)
+	}
+
+	validate := func(data string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fstest.MapFS{
+				"en.json":         &fstest.MapFile{Data: []byte(`{"title": "Shop"}`)},
+				"forms.i18n.json": &fstest.MapFile{Data: []byte(data)},
+			}, "en")
+			if len(issues) != 1 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("missing language", validate(`{"submit": "Send"}`, "invalid translation, must map languages to messages"))
+	t.Run("invalid language", validate(`{"submit": {"english": "Send"}}`, `invalid language "english", allowed are only two letter codes and private use tags`))
+	t.Run("duplicate", validate(`{"title": {"en": "Store"}}`, `duplicate key, already defined in "en.json"`))
+}
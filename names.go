@@ -0,0 +1,258 @@
+package i18n
+
+import "strings"
+
+// Direction is the direction text of a language is written in
+type Direction string
+
+const (
+	// LeftToRight is the direction of e.g. latin, cyrillic or CJK scripts
+	LeftToRight Direction = "ltr"
+	// RightToLeft is the direction of e.g. arabic or hebrew scripts
+	RightToLeft Direction = "rtl"
+)
+
+// LanguageInfo describes a loaded language, e.g. for building a language picker
+type LanguageInfo struct {
+	// Code is the language as named by its language file
+	Code Language
+	// Name is the english name of the language, e.g. "German"
+	Name string
+	// NativeName is the name of the language in the language itself, e.g. "Deutsch"
+	NativeName string
+	// Direction is the direction text of the language is written in
+	Direction Direction
+}
+
+// languageName holds the english and native name of a language
+type languageName struct {
+	english string
+	native  string
+}
+
+// languageNames lists the names of common languages and language variants.
+// Languages not listed are named by their primary language.
+var languageNames = map[Language]languageName{
+	"ar":      {"Arabic", "العربية"},
+	"bg":      {"Bulgarian", "български"},
+	"bn":      {"Bangla", "বাংলা"},
+	"ca":      {"Catalan", "català"},
+	"cs":      {"Czech", "čeština"},
+	"da":      {"Danish", "dansk"},
+	"de":      {"German", "Deutsch"},
+	"de-at":   {"Austrian German", "Österreichisches Deutsch"},
+	"de-ch":   {"Swiss High German", "Schweizer Hochdeutsch"},
+	"el":      {"Greek", "Ελληνικά"},
+	"en":      {"English", "English"},
+	"en-gb":   {"British English", "British English"},
+	"en-us":   {"American English", "American English"},
+	"es":      {"Spanish", "español"},
+	"es-419":  {"Latin American Spanish", "español latinoamericano"},
+	"et":      {"Estonian", "eesti"},
+	"fa":      {"Persian", "فارسی"},
+	"fi":      {"Finnish", "suomi"},
+	"fil":     {"Filipino", "Filipino"},
+	"fr":      {"French", "français"},
+	"fr-ca":   {"Canadian French", "français canadien"},
+	"he":      {"Hebrew", "עברית"},
+	"hi":      {"Hindi", "हिन्दी"},
+	"hr":      {"Croatian", "hrvatski"},
+	"hu":      {"Hungarian", "magyar"},
+	"id":      {"Indonesian", "Indonesia"},
+	"it":      {"Italian", "italiano"},
+	"ja":      {"Japanese", "日本語"},
+	"jv":      {"Javanese", "Jawa"},
+	"ko":      {"Korean", "한국어"},
+	"lt":      {"Lithuanian", "lietuvių"},
+	"lv":      {"Latvian", "latviešu"},
+	"ms":      {"Malay", "Melayu"},
+	"nb":      {"Norwegian Bokmål", "norsk bokmål"},
+	"nl":      {"Dutch", "Nederlands"},
+	"pl":      {"Polish", "polski"},
+	"pt":      {"Portuguese", "português"},
+	"pt-br":   {"Brazilian Portuguese", "português (Brasil)"},
+	"pt-pt":   {"European Portuguese", "português europeu"},
+	"ro":      {"Romanian", "română"},
+	"ru":      {"Russian", "русский"},
+	"sk":      {"Slovak", "slovenčina"},
+	"sl":      {"Slovenian", "slovenščina"},
+	"sr":      {"Serbian", "српски"},
+	"sr-latn": {"Serbian (Latin)", "srpski (latinica)"},
+	"sv":      {"Swedish", "svenska"},
+	"th":      {"Thai", "ไทย"},
+	"tr":      {"Turkish", "Türkçe"},
+	"uk":      {"Ukrainian", "українська"},
+	"ur":      {"Urdu", "اردو"},
+	"vi":      {"Vietnamese", "Tiếng Việt"},
+	"yi":      {"Yiddish", "ייִדיש"},
+	"zh":      {"Chinese", "中文"},
+	"zh-hans": {"Simplified Chinese", "简体中文"},
+	"zh-hant": {"Traditional Chinese", "繁體中文"},
+}
+
+// rightToLeftScripts are the scripts written from right to left
+var rightToLeftScripts = map[string]bool{
+	"arab": true,
+	"hebr": true,
+	"syrc": true,
+	"thaa": true,
+}
+
+// rightToLeftLanguages are the languages written from right to left if not denoted otherwise
+var rightToLeftLanguages = map[string]bool{
+	"ar":  true,
+	"ckb": true,
+	"dv":  true,
+	"fa":  true,
+	"he":  true,
+	"ps":  true,
+	"sd":  true,
+	"ug":  true,
+	"ur":  true,
+	"yi":  true,
+}
+
+// Direction returns the direction text of the language is written in.
+// Unknown and private use languages are written from left to right.
+func (lang Language) Direction() Direction {
+	t, ok := parseTag(lang)
+	if !ok {
+		return LeftToRight
+	}
+
+	script := t.script
+	if script == "" {
+		script = likelyScript(t.base, t.region)
+	}
+	if rightToLeftScripts[script] || (script == "" && rightToLeftLanguages[t.base]) {
+		return RightToLeft
+	}
+	return LeftToRight
+}
+
+// name returns the english and native name of the language, falling back to the language itself
+func (lang Language) name() languageName {
+	english := lang.nameBy(func(l Language) string { return languageNames[l].english })
+	native := lang.nameBy(func(l Language) string { return languageNames[l].native })
+	if english == "" {
+		english, native = string(lang), string(lang)
+	}
+	return languageName{english: english, native: native}
+}
+
+// nameBy returns the name of the language as returned by names. Variants not named are
+// named by the closest named language followed by their subtags, e.g. "German (DE)".
+// It is empty if no language is named.
+func (lang Language) nameBy(names func(Language) string) string {
+	if name := names(lang); name != "" {
+		return name
+	}
+
+	// unlike translations, names may also be taken of the primary language written in another script
+	t, _ := parseTag(lang)
+	for _, candidate := range append(languageCandidates(lang)[1:], Language(t.base)) {
+		name := names(candidate)
+		if name == "" {
+			continue
+		}
+
+		// denote the subtags not covered by the name
+		var subtags []string
+		c, _ := parseTag(candidate)
+		if c.script == "" && t.script != "" {
+			subtags = append(subtags, strings.ToUpper(t.script[:1])+t.script[1:])
+		}
+		if c.region == "" && t.region != "" {
+			subtags = append(subtags, strings.ToUpper(t.region))
+		}
+		if len(subtags) == 0 {
+			return name
+		}
+		return name + " (" + strings.Join(subtags, ", ") + ")"
+	}
+	return ""
+}
+
+// displayNames lists the names of common languages in languages other than english.
+// The names follow the CLDR language display names.
+var displayNames = map[Language]map[Language]string{
+	"de": {
+		"ar": "Arabisch", "de": "Deutsch", "en": "Englisch", "es": "Spanisch", "fr": "Französisch",
+		"it": "Italienisch", "ja": "Japanisch", "ko": "Koreanisch", "nl": "Niederländisch", "pl": "Polnisch",
+		"pt": "Portugiesisch", "ru": "Russisch", "tr": "Türkisch", "zh": "Chinesisch",
+	},
+	"es": {
+		"ar": "árabe", "de": "alemán", "en": "inglés", "es": "español", "fr": "francés",
+		"it": "italiano", "ja": "japonés", "ko": "coreano", "nl": "neerlandés", "pl": "polaco",
+		"pt": "portugués", "ru": "ruso", "tr": "turco", "zh": "chino",
+	},
+	"fr": {
+		"ar": "arabe", "de": "allemand", "en": "anglais", "es": "espagnol", "fr": "français",
+		"it": "italien", "ja": "japonais", "ko": "coréen", "nl": "néerlandais", "pl": "polonais",
+		"pt": "portugais", "ru": "russe", "tr": "turc", "zh": "chinois",
+	},
+	"it": {
+		"ar": "arabo", "de": "tedesco", "en": "inglese", "es": "spagnolo", "fr": "francese",
+		"it": "italiano", "ja": "giapponese", "ko": "coreano", "nl": "olandese", "pl": "polacco",
+		"pt": "portoghese", "ru": "russo", "tr": "turco", "zh": "cinese",
+	},
+	"nl": {
+		"ar": "Arabisch", "de": "Duits", "en": "Engels", "es": "Spaans", "fr": "Frans",
+		"it": "Italiaans", "ja": "Japans", "ko": "Koreaans", "nl": "Nederlands", "pl": "Pools",
+		"pt": "Portugees", "ru": "Russisch", "tr": "Turks", "zh": "Chinees",
+	},
+	"pt": {
+		"ar": "árabe", "de": "alemão", "en": "inglês", "es": "espanhol", "fr": "francês",
+		"it": "italiano", "ja": "japonês", "ko": "coreano", "nl": "holandês", "pl": "polonês",
+		"pt": "português", "ru": "russo", "tr": "turco", "zh": "chinês",
+	},
+}
+
+// DisplayName returns the name of the language of in the language in, e.g. "German",
+// "Deutsch" or "allemand" for "de". A language is named by its native name in itself.
+// Display names are only available in english and in German, Spanish, French, Italian,
+// Dutch and Portuguese for Arabic, Chinese, Dutch, English, French, German, Italian,
+// Japanese, Korean, Polish, Portuguese, Russian, Spanish and Turkish. For other languages
+// ok is false, the english name or the language itself being returned instead.
+func DisplayName(of Language, in Language) (name string, ok bool) {
+	of, in = normalizeLanguage(string(of)), normalizeLanguage(string(in))
+
+	names := of.name()
+	known := of.nameBy(func(l Language) string { return languageNames[l].english }) != ""
+	if of.Base() != "" && of.Base() == in.Base() {
+		return names.native, known
+	}
+
+	for _, display := range append(languageCandidates(in), in.Base()) {
+		if display.Base() == "en" {
+			return names.english, known
+		}
+
+		localizedNames, ok := displayNames[display]
+		if !ok {
+			continue
+		}
+		if localized := of.nameBy(func(l Language) string { return localizedNames[l] }); localized != "" {
+			return localized, true
+		}
+	}
+	return names.english, false
+}
+
+// Languages returns the information about all loaded languages,
+// ordered like AvailableLanguages with the default language first
+func (trl Translations) Languages() []LanguageInfo {
+	languages := trl.orderedLanguages()
+
+	infos := make([]LanguageInfo, 0, len(languages))
+	for _, lang := range languages {
+		name := lang.name()
+		infos = append(infos, LanguageInfo{
+			Code:       lang,
+			Name:       name.english,
+			NativeName: name.native,
+			Direction:  lang.Direction(),
+		})
+	}
+	return infos
+}
@@ -0,0 +1,89 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestDirection(t *testing.T) {
+	fn := func(lang Language, expected Direction) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := lang.Direction(); got != expected {
+				t.Fatalf("expected %q for %q, got %q", expected, lang, got)
+			}
+		}
+	}
+
+	t.Run("latin", fn("de", LeftToRight))
+	t.Run("arabic", fn("ar", RightToLeft))
+	t.Run("hebrew with region", fn("he-il", RightToLeft))
+	t.Run("arabic script", fn("uz-arab", RightToLeft))
+	t.Run("likely arabic script", fn("pa-pk", RightToLeft))
+	t.Run("latin script", fn("ku-latn", LeftToRight))
+	t.Run("private use", fn("x-pseudo", LeftToRight))
+}
+
+func TestLanguageNames(t *testing.T) {
+	fn := func(lang Language, english string, native string) func(t *testing.T) {
+		return func(t *testing.T) {
+			name := lang.name()
+			if name.english != english || name.native != native {
+				t.Fatalf("expected %q and %q for %q, got %q and %q", english, native, lang, name.english, name.native)
+			}
+		}
+	}
+
+	t.Run("listed", fn("de", "German", "Deutsch"))
+	t.Run("listed variant", fn("pt-br", "Brazilian Portuguese", "português (Brasil)"))
+	t.Run("unlisted region", fn("de-de", "German (DE)", "Deutsch (DE)"))
+	t.Run("unlisted region of script", fn("zh-tw", "Traditional Chinese (TW)", "繁體中文 (TW)"))
+	t.Run("unlisted script", fn("de-latn", "German (Latn)", "Deutsch (Latn)"))
+	t.Run("unknown", fn("x-pseudo", "x-pseudo", "x-pseudo"))
+}
+
+func TestLanguages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+		"he.json": &fstest.MapFile{Data: []byte(`{"a": "שלום"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []LanguageInfo{
+		{Code: "en", Name: "English", NativeName: "English", Direction: LeftToRight},
+		{Code: "de", Name: "German", NativeName: "Deutsch", Direction: LeftToRight},
+		{Code: "he", Name: "Hebrew", NativeName: "עברית", Direction: RightToLeft},
+	}
+	if got := translations.Languages(); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDisplayName(t *testing.T) {
+	fn := func(of Language, in Language, expected string, expectedOk bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got, ok := DisplayName(of, in); got != expected || ok != expectedOk {
+				t.Fatalf("expected %q (%v) for %q in %q, got %q (%v)", expected, expectedOk, of, in, got, ok)
+			}
+		}
+	}
+
+	t.Run("english", fn("de", "en", "German", true))
+	t.Run("english of variant", fn("sv", "en-gb", "Swedish", true))
+	t.Run("native", fn("de", "de", "Deutsch", true))
+	t.Run("native variant", fn("de-at", "de", "Österreichisches Deutsch", true))
+	t.Run("localized", fn("de", "fr", "allemand", true))
+	t.Run("localized in variant", fn("de", "fr-ca", "allemand", true))
+	t.Run("localized variant", fn("en-gb", "de", "Englisch (GB)", true))
+	t.Run("not localized", fn("sv", "fr", "Swedish", false))
+	t.Run("not localized in german", fn("sv", "de", "Swedish", false))
+	t.Run("no display names", fn("de", "sv", "German", false))
+	t.Run("unnormalized", fn("de", "FR", "allemand", true))
+	t.Run("unknown", fn("x-pseudo", "de", "x-pseudo", false))
+	t.Run("unknown in english", fn("x-pseudo", "en", "x-pseudo", false))
+}
@@ -0,0 +1,105 @@
+package i18n
+
+import "strings"
+
+// numberSymbols are the symbols of a language for formatting numbers
+type numberSymbols struct {
+	decimal string
+	group   string
+	// minimumGrouping is the number of integer digits from which on digits are grouped
+	minimumGrouping int
+}
+
+// defaultNumberSymbols are the symbols of languages not listed within numberSymbolsByLanguage
+var defaultNumberSymbols = numberSymbols{decimal: ".", group: ",", minimumGrouping: 4}
+
+// commaPeriod and commaSpace are the most common symbols besides the default ones,
+// the latter grouping digits by no-break spaces
+var (
+	commaPeriod = numberSymbols{decimal: ",", group: ".", minimumGrouping: 4}
+	commaSpace  = numberSymbols{decimal: ",", group: "\u00a0", minimumGrouping: 4}
+)
+
+// numberSymbolsByLanguage lists the number symbols following the CLDR
+var numberSymbolsByLanguage = map[Language]numberSymbols{
+	"bg":    {decimal: ",", group: "\u00a0", minimumGrouping: 5},
+	"cs":    commaSpace,
+	"da":    commaPeriod,
+	"de":    commaPeriod,
+	"de-at": commaSpace,
+	"de-ch": {decimal: ".", group: "’", minimumGrouping: 4},
+	"el":    commaPeriod,
+	"es":    {decimal: ",", group: ".", minimumGrouping: 5},
+	"es-mx": defaultNumberSymbols,
+	"es-us": defaultNumberSymbols,
+	"et":    {decimal: ",", group: "\u00a0", minimumGrouping: 5},
+	"fi":    commaSpace,
+	"fr":    {decimal: ",", group: "\u202f", minimumGrouping: 4},
+	"fr-ch": {decimal: ",", group: "\u202f", minimumGrouping: 4},
+	"hr":    commaPeriod,
+	"hu":    commaSpace,
+	"id":    commaPeriod,
+	"it":    commaPeriod,
+	"it-ch": {decimal: ".", group: "’", minimumGrouping: 4},
+	"lt":    commaSpace,
+	"lv":    commaSpace,
+	"nb":    commaSpace,
+	"nl":    commaPeriod,
+	"nn":    commaSpace,
+	"pl":    {decimal: ",", group: "\u00a0", minimumGrouping: 5},
+	"pt":    commaPeriod,
+	"pt-pt": commaSpace,
+	"ro":    commaPeriod,
+	"ru":    commaSpace,
+	"sk":    commaSpace,
+	"sl":    commaPeriod,
+	"sr":    commaPeriod,
+	"sv":    commaSpace,
+	"tr":    commaPeriod,
+	"uk":    commaSpace,
+	"vi":    commaPeriod,
+}
+
+// numberSymbolsOf returns the number symbols of the language
+func numberSymbolsOf(lang Language) numberSymbols {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if symbols, ok := numberSymbolsByLanguage[candidate]; ok {
+			return symbols
+		}
+	}
+	return defaultNumberSymbols
+}
+
+// localizeNumber converts a number formatted by strconv, e.g. "-1234.5",
+// into the notation of the language, e.g. "-1.234,5" in German
+func localizeNumber(lang Language, number string) string {
+	symbols := numberSymbolsOf(lang)
+
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	integer, fraction := number, ""
+	if i := strings.IndexByte(number, '.'); i != -1 {
+		integer, fraction = number[:i], number[i+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	if len(integer) >= symbols.minimumGrouping {
+		for i := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteString(symbols.group)
+			}
+			b.WriteByte(integer[i])
+		}
+	} else {
+		b.WriteString(integer)
+	}
+	if fraction != "" {
+		b.WriteString(symbols.decimal)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
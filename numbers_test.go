@@ -0,0 +1,23 @@
+package i18n
+
+import "testing"
+
+func TestLocalizeNumber(t *testing.T) {
+	fn := func(lang Language, number string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := localizeNumber(lang, number); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", "-1234567.89", "-1,234,567.89"))
+	t.Run("german", fn("de", "1234567.89", "1.234.567,89"))
+	t.Run("austrian", fn("de-at", "1234.5", "1\u00a0234,5"))
+	t.Run("swiss", fn("de-ch", "1234.5", "1’234.5"))
+	t.Run("french", fn("fr-ca", "1234.5", "1\u202f234,5"))
+	t.Run("minimum grouping", fn("es", "1234", "1234"))
+	t.Run("grouped", fn("es", "12345", "12.345"))
+	t.Run("mexican", fn("es-mx", "1234.5", "1,234.5"))
+	t.Run("small", fn("de", "123", "123"))
+}
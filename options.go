@@ -0,0 +1,343 @@
+package i18n
+
+import (
+	"html"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+)
+
+// Option configures a Translations object upon creation
+type Option func(*Translations)
+
+// New initializes a new translations object configured by the given options.
+// Without any options translations are loaded from the current working directory.
+func New(options ...Option) Translations {
+	trl := Translations{
+		directory:       ".",
+		escape:          html.EscapeString,
+		languageAliases: DefaultLanguageAliases,
+	}
+
+	for _, option := range options {
+		option(&trl)
+	}
+	return trl
+}
+
+// WithDirectory sets the directory the language files are loaded from
+func WithDirectory(directory string) Option {
+	return func(trl *Translations) {
+		trl.directory = directory
+		trl.fsys = nil
+	}
+}
+
+// WithFS sets the file system the language files are loaded from.
+// It takes precedence over a directory.
+func WithFS(fsys fs.FS) Option {
+	return func(trl *Translations) {
+		trl.fsys = fsys
+	}
+}
+
+// WithDefaultLanguage sets the language used whenever the
+// requested language is not valid
+func WithDefaultLanguage(lang string) Option {
+	return func(trl *Translations) {
+		trl.defaultLanguage = Language(lang)
+	}
+}
+
+// WithFallbackChain sets the languages which are tried in the given order
+// whenever a key can not be found in the requested language
+func WithFallbackChain(languages ...string) Option {
+	return func(trl *Translations) {
+		trl.fallbackChain = make([]Language, 0, len(languages))
+		for _, lang := range languages {
+			trl.fallbackChain = append(trl.fallbackChain, Language(lang))
+		}
+	}
+}
+
+// WithFallbacks sets the languages which are tried in the given order for the
+// language lang, e.g. "es" and "en" for "gl". The fallbacks precede the fallback
+// chain and apply likewise if lang itself is not available. Fallbacks of the
+// fallback languages are followed as well.
+func WithFallbacks(lang string, fallbacks ...string) Option {
+	return func(trl *Translations) {
+		languages := make([]Language, 0, len(fallbacks))
+		for _, fallback := range fallbacks {
+			languages = append(languages, normalizeLanguage(fallback))
+		}
+
+		if trl.fallbacks == nil {
+			trl.fallbacks = make(map[Language][]Language)
+		}
+		trl.fallbacks[normalizeLanguage(lang)] = languages
+	}
+}
+
+// WithEscapeFunc sets the function used for escaping the parameter
+// values which are interpolated into a translation. By default the
+// values are HTML escaped.
+func WithEscapeFunc(escape func(string) string) Option {
+	return func(trl *Translations) {
+		trl.escape = escape
+	}
+}
+
+// WithSubstitute sets what nil parameters and intermediates without parameter render as,
+// e.g. SubstituteText("-"), instead of "<nil>" respectively failing the translation.
+// It is overridden for a single translation by the parameter named by SubstituteParam.
+func WithSubstitute(substitute Substitute) Option {
+	return func(trl *Translations) {
+		trl.substitution = substitute
+	}
+}
+
+// source returns the file system the language files are loaded from
+func (trl Translations) source() fs.FS {
+	if trl.fsys != nil {
+		return trl.fsys
+	}
+	return os.DirFS(trl.directory)
+}
+
+// WithCache enables a cache for rendered translations holding up to size entries.
+// Rendered translations are cached by language, key and parameters, evicting the
+// least recently used entry once the cache is full.
+func WithCache(size int) Option {
+	return func(trl *Translations) {
+		trl.cacheSize = size
+	}
+}
+
+// WithKeyStats counts the translations of each key, sampling the fraction of translations,
+// e.g. 0.01 for one percent, or all of them for one. The hottest keys are reported by
+// HotKeys and Stats.
+func WithKeyStats(sampling float64) Option {
+	return func(trl *Translations) {
+		trl.keyCounter = &keyCounter{sampling: sampling}
+	}
+}
+
+// WithPrerender enables pre-rendering all translations of the default language
+// that do not contain any intermediates upon loading. Translating them without
+// parameters is then served by a single lookup.
+func WithPrerender() Option {
+	return func(trl *Translations) {
+		trl.prerender = true
+	}
+}
+
+// WithPrintfMessages interprets messages as printf-style format strings, e.g. "%s has %d
+// items", for migrating from localization based on fmt.Sprintf. Parameters are passed by
+// position instead of by name and are escaped once formatted. Upon loading the verbs of each
+// translation must match those of the default language in number and kind.
+func WithPrintfMessages() Option {
+	return func(trl *Translations) {
+		trl.printf = true
+	}
+}
+
+// Limits restricts the resources language files may consume upon loading, protecting
+// against corrupted or malicious files. A zero value imposes no restriction.
+type Limits struct {
+	// MaxFileSize is the maximum size of a language file in bytes
+	MaxFileSize int64
+	// MaxDepth is the maximum nesting depth of objects within a language file
+	MaxDepth int
+	// MaxKeyLength is the maximum length of a complete key in bytes
+	MaxKeyLength int
+	// MaxIntermediates is the maximum number of intermediates within a single message
+	MaxIntermediates int
+}
+
+// WithLimits sets the limits enforced upon loading the language files
+func WithLimits(limits Limits) Option {
+	return func(trl *Translations) {
+		trl.limits = limits
+	}
+}
+
+// Logger is the hook warnings are emitted to, it is satisfied by *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger sets the logger warnings are emitted to, e.g. upon translating deprecated keys.
+// Without a logger, no warnings are emitted.
+func WithLogger(logger Logger) Option {
+	return func(trl *Translations) {
+		trl.logger = logger
+	}
+}
+
+// WithKeyNormalization sets a function normalizing keys upon loading and translating,
+// such that differently spelled keys refer to the same translation. Keys which are
+// equal after normalization collide when loading. The function must be idempotent.
+// LowerCaseKeys folds the casing of keys, a Unicode normalization form may be applied
+// on top, e.g. using golang.org/x/text/unicode/norm.
+func WithKeyNormalization(normalize func(Key) Key) Option {
+	return func(trl *Translations) {
+		trl.normalize = normalize
+	}
+}
+
+// LowerCaseKeys normalizes a key to lower case, to be used with WithKeyNormalization
+func LowerCaseKeys(key Key) Key {
+	return Key(strings.ToLower(string(key)))
+}
+
+// WithThreeLetterCodes allows three letter ISO 639-2/639-3 language codes
+// like "fil" or "haw" besides two letter codes
+func WithThreeLetterCodes() Option {
+	return func(trl *Translations) {
+		trl.languageRules.threeLetterCodes = true
+	}
+}
+
+// WithLanguageAliases sets the aliases applied to requested languages, replacing
+// the DefaultLanguageAliases. An alias maps either a complete language or only
+// its primary subtag, e.g. "no" maps "no-NO" to "nb-no".
+func WithLanguageAliases(aliases map[string]string) Option {
+	return func(trl *Translations) {
+		trl.languageAliases = make(map[string]string, len(aliases))
+		for from, to := range aliases {
+			trl.languageAliases[string(normalizeLanguage(from))] = to
+		}
+	}
+}
+
+// WithLabels registers the keys of the labels of constant values to be translated using Label,
+// adding to the labels registered before. The values must be comparable.
+func WithLabels(labels Labels) Option {
+	return func(trl *Translations) {
+		merged := make(Labels, len(trl.labels)+len(labels))
+		for value, key := range trl.labels {
+			merged[value] = key
+		}
+		for value, key := range labels {
+			merged[value] = key
+		}
+		trl.labels = merged
+	}
+}
+
+// WithMachineTranslation machine translates keys missing in the requested language from the
+// default language using the provider, e.g. for showing machine translated placeholders in
+// staging environments while human translations are pending. Machine translations are cached.
+// The fallback chain applies if the provider fails or does not retain the intermediates, such
+// translations being retried after a minute. Requests without deadline time out after 5 seconds.
+// Keys are only translated into the targets, or into the loaded languages if none are given,
+// regional languages being translated into the closest of them, e.g. "de" for "de-at".
+func WithMachineTranslation(provider MTProvider, targets ...Language) Option {
+	return func(trl *Translations) {
+		trl.machine = &machineTranslator{
+			provider: provider,
+			targets:  make(map[Language]bool, len(targets)),
+			cache:    make(map[machineKey]Translation),
+			failures: make(map[machineKey]time.Time),
+		}
+		for _, target := range targets {
+			trl.machine.targets[normalizeLanguage(string(target))] = true
+		}
+	}
+}
+
+// WithTenantDirectory loads the overrides of tenants from the directory, e.g. "tenants",
+// containing a directory of language files per tenant, e.g. "tenants/acme/de.json".
+// The directory is skipped upon loading the base translations. See ForTenant.
+func WithTenantDirectory(dir string) Option {
+	return func(trl *Translations) {
+		trl.tenantDirectory = dir
+	}
+}
+
+// WithMissingKeyHook reports keys translated but missing in the requested language and its
+// fallbacks to the hook, e.g. for logging them. Each key of a language is reported at most once
+// within the interval, the report counting the reports suppressed since, such that a hot page
+// missing a key does not flood the logs. The hook is called synchronously.
+func WithMissingKeyHook(hook func(MissingKey), interval time.Duration) Option {
+	return func(trl *Translations) {
+		trl.missing = &missingReporter{
+			hook:     hook,
+			interval: interval,
+			reported: make(map[languageKey]*missingReport),
+		}
+	}
+}
+
+// WithExperimentHook sets the hook reporting the experiment variants served for experiment
+// units, e.g. for tracking conversions. The variant is empty if the key itself was served
+// since the variant is not translated in the language. See GenerateExperimentTranslate.
+func WithExperimentHook(hook func(unit string, key Key, variant string)) Option {
+	return func(trl *Translations) {
+		trl.experimentHook = hook
+	}
+}
+
+// WithRefreshInterval refreshes the translations of catalogs in the background within the
+// interval, e.g. 5*time.Minute, swapping in updated translations. The interval varies
+// by a random jitter and backs off exponentially while refreshing fails. See NewCatalog.
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(trl *Translations) {
+		trl.refreshInterval = interval
+	}
+}
+
+// WithRefreshSource sets the source catalogs are refreshed from, e.g. a RemoteBundle.
+// By default the language files are reloaded. See Catalog.Refresh.
+func WithRefreshSource(source Source) Option {
+	return func(trl *Translations) {
+		trl.refreshSource = source
+	}
+}
+
+// WithMaxStaleness tolerates failing refreshes of catalogs for the age, e.g. 30*time.Minute,
+// before Catalog.Healthy reports the catalog unhealthy. Meanwhile the translations loaded
+// last are served, their staleness being reported by Catalog.Check.
+func WithMaxStaleness(age time.Duration) Option {
+	return func(trl *Translations) {
+		trl.maxStaleness = age
+	}
+}
+
+// WithPersister persists the edits made through Catalog.Set and Catalog.Delete using the
+// persister, e.g. a FilePersister. Persisted edits are applied on top of the language
+// files upon loading, such that they survive restarts and refreshes.
+func WithPersister(persister Persister) Option {
+	return func(trl *Translations) {
+		trl.persister = persister
+	}
+}
+
+// WithAuditSink records every edit made through Catalog.Set and Catalog.Delete to the sink,
+// including the actor carried by the context and the previous message. Edits are recorded
+// before being persisted and are rejected if recording fails, such that no edit goes unrecorded.
+func WithAuditSink(sink AuditSink) Option {
+	return func(trl *Translations) {
+		trl.auditSink = sink
+	}
+}
+
+// WithManifest verifies the language files upon loading against the SHA-256 hashes listed by the
+// manifest of the name within the file system, e.g. "SHA256SUMS" as written by sha256sum, detecting
+// tampered or truncated files at startup. Language files not listed by the manifest are reported.
+func WithManifest(name string) Option {
+	return func(trl *Translations) {
+		trl.manifest = name
+	}
+}
+
+// WithTracer traces loading and refreshing the translations using the tracer, e.g. an
+// adapter of an OpenTelemetry tracer. The fraction of translations made through TranslateCtx
+// traced is set by sampling, e.g. 0.01 for one percent, none being traced for zero.
+func WithTracer(tracer Tracer, sampling float64) Option {
+	return func(trl *Translations) {
+		trl.tracer = tracer
+		trl.traceSampling = sampling
+	}
+}
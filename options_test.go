@@ -0,0 +1,139 @@
+package i18n
+
+import (
+	"html/template"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestOptions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"hello": "hello, {{name}}", "bye": "goodbye"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"hello": "hallo, {{name}}"}`)},
+	}
+
+	fn := func(options []Option, lang string, key string, expected template.HTML) func(t *testing.T) {
+		return func(t *testing.T) {
+			translations, err := New(options...).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := translations.GenerateTranslate(lang)(key, "name", "<b>")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("directory", fn([]Option{WithDirectory(Count + "first"), WithDefaultLanguage("en")}, "en", "hello.world", "hello, world"))
+	t.Run("fs", fn([]Option{WithFS(fsys), WithDefaultLanguage("en")}, "de", "hello", "hallo, &lt;b&gt;"))
+	t.Run("fs with directory", fn([]Option{WithFS(os.DirFS(Count + "first")), WithDefaultLanguage("en")}, "en", "os.win.xp", "obsolete"))
+	t.Run("fallback chain", fn([]Option{WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en")}, "de", "bye", "goodbye"))
+	t.Run("escape func", fn([]Option{WithFS(fsys), WithDefaultLanguage("en"), WithEscapeFunc(strings.ToUpper)}, "en", "hello", "hello, <B>"))
+
+	t.Run("no fallback chain", func(t *testing.T) {
+		translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := translations.GenerateTranslate("de")("bye"); err == nil {
+			t.Fatal("expected error for key missing in requested language")
+		}
+	})
+}
+
+func TestPrerender(t *testing.T) {
+	translations, err := NewTranslations(Count+"first", "en", WithPrerender()).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// all translations without intermediates are pre-rendered
+	if l := len(translations.prerendered); l != 6 {
+		t.Fatalf("expected 6 pre-rendered translations, got %d", l)
+	}
+
+	translate := translations.GenerateDefaultTranslate()
+	got, err := translate("os.win.vista")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "don't go there" {
+		t.Fatalf("unexpected translation %q", got)
+	}
+
+	// pre-rendered translations still reject superfluous parameters like the others
+	if _, err := translate("os.win.vista", "a"); err == nil {
+		t.Fatal("expected error for invalid parameters")
+	}
+	if got, _ := translate("whoami", "whoami", "bob"); got != "you are bob" {
+		t.Fatalf("unexpected translation %q", got)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	fn := func(data string, limits Limits, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(data)},
+			}
+
+			_, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithLimits(limits)).Load()
+			if got := err == nil; got != expected {
+				t.Fatalf("expected %v, got %v: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("no limits", fn(`{"a": {"b": {"c": "{{x}}{{y}}"}}}`, Limits{}, true))
+	t.Run("file size", fn(`{"a": "hello"}`, Limits{MaxFileSize: 10}, false))
+	t.Run("file size within", fn(`{"a": "hello"}`, Limits{MaxFileSize: 14}, true))
+	t.Run("depth", fn(`{"a": {"b": {"c": "x"}}}`, Limits{MaxDepth: 2}, false))
+	t.Run("depth within", fn(`{"a": {"b": {"c": "x"}}}`, Limits{MaxDepth: 3}, true))
+	t.Run("key length", fn(`{"abc": {"def": "x"}}`, Limits{MaxKeyLength: 6}, false))
+	t.Run("key length within", fn(`{"abc": {"def": "x"}}`, Limits{MaxKeyLength: 7}, true))
+	t.Run("intermediates", fn(`{"a": "{{x}}{{y}}{{z}}"}`, Limits{MaxIntermediates: 2}, false))
+	t.Run("intermediates within", fn(`{"a": "{{x}}{{y}}"}`, Limits{MaxIntermediates: 2}, true))
+}
+
+func TestKeyNormalization(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"User": {"Name": "name", "@Name": {"maxLength": 4}}, "@alias": {"alias": "USER.NAME"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"user": {"name": "Name"}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithKeyNormalization(LowerCaseKeys)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []Key{"user.name", "User.Name", "USER.name", "alias"} {
+		if !translations.Has("en", key) || !translations.Has("de", key) {
+			t.Fatalf("expected %q to be normalized", key)
+		}
+	}
+	if metadata, ok := translations.Metadata("user.NAME"); !ok || metadata.MaxLength != 4 {
+		t.Fatalf("unexpected metadata %+v", metadata)
+	}
+	if got, err := translations.GenerateTranslate("de")("User.Name"); err != nil || got != "Name" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+
+	t.Run("collision", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.json": &fstest.MapFile{Data: []byte(`{"user": {"name": "a"}, "User": {"Name": "b"}}`)},
+		}
+
+		_, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithKeyNormalization(LowerCaseKeys)).Load()
+		if err == nil {
+			t.Fatal("expected collision of normalized keys")
+		}
+	})
+}
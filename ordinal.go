@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// ordinalSuffixes are the suffixes of ordinal numbers of languages using the same suffix
+// for all numbers besides those with their own rules in Ordinal
+var ordinalSuffixes = map[Language]string{
+	"cs": ".", "da": ".", "de": ".", "et": ".", "fi": ".", "hr": ".", "hu": ".", "lv": ".",
+	"nb": ".", "nn": ".", "pl": ".", "sk": ".", "sl": ".", "sr": ".", "tr": ".",
+	"es": ".º", "it": "º", "pt": "º", "nl": "e", "ru": "-й", "uk": "-й", "bg": "-и",
+}
+
+// ordinalPrefixes are the prefixes of ordinal numbers
+var ordinalPrefixes = map[Language]string{
+	"ja": "第", "zh": "第",
+}
+
+// Ordinal renders n as ordinal number of the language, e.g. "1st" in English, "1." in
+// German or "1ᵉʳ" in French. Languages lacking rules render the number as is. Unlike
+// plurals, ordinals denote the position of an item, e.g. within a ranking or a date.
+func Ordinal(lang Language, n int) string {
+	base := lang.Base()
+	number := strconv.Itoa(n)
+
+	abs := n
+	if abs < 0 {
+		abs = -abs
+	}
+
+	switch base {
+	case "en":
+		switch {
+		case abs%100 >= 11 && abs%100 <= 13:
+			return number + "th"
+		case abs%10 == 1:
+			return number + "st"
+		case abs%10 == 2:
+			return number + "nd"
+		case abs%10 == 3:
+			return number + "rd"
+		}
+		return number + "th"
+	case "fr":
+		if abs == 1 {
+			return number + "ᵉʳ"
+		}
+		return number + "ᵉ"
+	case "sv":
+		if (abs%10 == 1 || abs%10 == 2) && abs%100 != 11 && abs%100 != 12 {
+			return number + ":a"
+		}
+		return number + ":e"
+	case "ko":
+		return number + "번째"
+	}
+
+	if prefix, ok := ordinalPrefixes[base]; ok {
+		return prefix + number
+	}
+	return number + ordinalSuffixes[base]
+}
+
+// formatOrdinal formats integer parameter values as ordinal numbers
+func formatOrdinal(lang Language, value interface{}) string {
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return Ordinal(lang, int(v.Int()))
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return Ordinal(lang, int(v.Uint()))
+	case v.Kind() == reflect.String:
+		if n, err := strconv.Atoi(v.String()); err == nil {
+			return Ordinal(lang, n)
+		}
+	}
+	return formatValue(value)
+}
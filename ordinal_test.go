@@ -0,0 +1,53 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestOrdinal(t *testing.T) {
+	fn := func(lang Language, n int, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Ordinal(lang, n); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english first", fn("en", 1, "1st"))
+	t.Run("english second", fn("en-gb", 22, "22nd"))
+	t.Run("english third", fn("en", 103, "103rd"))
+	t.Run("english teens", fn("en", 112, "112th"))
+	t.Run("german", fn("de-at", 2, "2."))
+	t.Run("french first", fn("fr", 1, "1ᵉʳ"))
+	t.Run("french", fn("fr", 2, "2ᵉ"))
+	t.Run("spanish", fn("es", 3, "3.º"))
+	t.Run("swedish", fn("sv", 21, "21:a"))
+	t.Run("swedish teens", fn("sv", 11, "11:e"))
+	t.Run("japanese", fn("ja", 4, "第4"))
+	t.Run("unknown", fn("xx", 5, "5"))
+}
+
+func TestOrdinalFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"rank": "You are {{rank:int, ordinal}}"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"rank": "Du bist {{rank, ordinal}}"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, rank interface{}, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got, err := translations.GenerateTranslate(lang)("rank", "rank", rank); err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", 2, "You are 2nd"))
+	t.Run("german", fn("de", uint8(3), "Du bist 3."))
+	t.Run("string", fn("de", "4", "Du bist 4."))
+	t.Run("not a number", fn("de", "last", "Du bist last"))
+}
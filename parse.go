@@ -0,0 +1,212 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseNumber parses a number entered in the notation of the language, e.g. "1.234,56" in
+// German or "1,234.56" in English. Digits may be grouped by the group separator of the language
+// at every third digit, spaces being accepted for languages grouping by no-break spaces.
+func ParseNumber(lang Language, s string) (float64, error) {
+	symbols := numberSymbolsOf(lang)
+	number := strings.TrimSpace(s)
+
+	sign := ""
+	switch {
+	case strings.HasPrefix(number, "-"):
+		sign, number = "-", number[1:]
+	case strings.HasPrefix(number, "−"):
+		sign, number = "-", number[len("−"):]
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	}
+
+	integer, fraction := number, ""
+	if i := strings.LastIndex(number, symbols.decimal); i != -1 {
+		integer, fraction = number[:i], number[i+len(symbols.decimal):]
+		if !isDigit(fraction) {
+			return 0, fmt.Errorf("invalid number %q", s)
+		}
+	}
+
+	integer, ok := ungroup(integer, groupSeparators(symbols.group))
+	if !ok || integer == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid number %q", s)
+	}
+	if integer == "" {
+		integer = "0"
+	}
+	if fraction != "" {
+		integer += "." + fraction
+	}
+	return strconv.ParseFloat(sign+integer, 64)
+}
+
+// groupSeparators returns the separators accepted for the group separator
+func groupSeparators(group string) []string {
+	switch group {
+	case noBreakSpace, narrowNoBreakSpace:
+		return []string{group, " ", noBreakSpace, narrowNoBreakSpace}
+	case "’":
+		return []string{"’", "'"}
+	}
+	return []string{group}
+}
+
+// ungroup removes the group separators of the integer digits, reporting whether
+// the digits are grouped at every third digit
+func ungroup(integer string, separators []string) (string, bool) {
+	for _, separator := range separators[1:] {
+		integer = strings.Replace(integer, separator, separators[0], -1)
+	}
+	if integer == "" {
+		return "", true
+	}
+
+	groups := strings.Split(integer, separators[0])
+	for i, group := range groups {
+		if !isDigit(group) || len(groups) > 1 && (len(group) > 3 || i > 0 && len(group) != 3) {
+			return "", false
+		}
+	}
+	return strings.Join(groups, ""), true
+}
+
+// ParseDate parses a date entered in the short or wide notation of the language, e.g.
+// "31/12/2025" in British English or "31. Dezember 2025" in German, falling back to
+// ISO 8601. Two digit years denote years from 1969 to 2068 like time.Parse does.
+// The date is returned at midnight UTC.
+func ParseDate(lang Language, s string) (time.Time, error) {
+	calendar := DefaultCalendar(lang)
+	if calendar != Buddhist {
+		calendar = Gregorian
+	}
+
+	input := strings.TrimSpace(s)
+	for _, pattern := range []string{
+		calendarDatePattern(lang, calendar, Short),
+		calendarDatePattern(lang, calendar, Wide),
+	} {
+		if t, ok := parseDate(lang, calendar, pattern, input); ok {
+			return t, nil
+		}
+	}
+	if t, ok := parseDate(lang, Gregorian, isoPatterns.date[0], input); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", s)
+}
+
+// parseDate parses the input following the pattern of the calendar
+func parseDate(lang Language, calendar Calendar, pattern string, input string) (time.Time, bool) {
+	year, month, day := -1, -1, -1
+	for pattern != "" {
+		i := strings.Index(pattern, "{")
+		j := strings.Index(pattern, "}")
+		if i == -1 || j < i {
+			i, j = len(pattern), len(pattern)-1
+		}
+
+		var ok bool
+		if input, ok = consumeLiteral(input, pattern[:i]); !ok {
+			return time.Time{}, false
+		}
+		if i == len(pattern) {
+			break
+		}
+
+		field := pattern[i+1 : j]
+		pattern = pattern[j+1:]
+		switch field {
+		case "y", "yy":
+			var digits string
+			digits, input = consumeDigits(input, 4)
+			year, _ = strconv.Atoi(digits)
+			switch {
+			case len(digits) == 2 && field == "yy":
+				year += 1900
+				if year < 1969 {
+					year += 100
+				}
+			case len(digits) == 0:
+				return time.Time{}, false
+			}
+		case "M", "MM":
+			var digits string
+			digits, input = consumeDigits(input, 2)
+			month, _ = strconv.Atoi(digits)
+		case "MMM", "MMMM":
+			month, input = consumeMonth(lang, input)
+		case "d", "dd":
+			var digits string
+			digits, input = consumeDigits(input, 2)
+			day, _ = strconv.Atoi(digits)
+		case "G":
+			if input, ok = consumeLiteral(input, eraName(lang, calendarDate{era: "be"})); !ok {
+				return time.Time{}, false
+			}
+		default:
+			return time.Time{}, false
+		}
+	}
+	if input != "" || year < 0 || month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+
+	if calendar == Buddhist {
+		year -= 543
+	}
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Day() != day {
+		// the day exceeds the month
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// consumeLiteral consumes the literal of a pattern, spaces of the literal matching any spaces
+func consumeLiteral(input string, literal string) (string, bool) {
+	for _, r := range literal {
+		if unicode.IsSpace(r) {
+			input = strings.TrimLeftFunc(input, unicode.IsSpace)
+			continue
+		}
+		c, size := utf8.DecodeRuneInString(input)
+		if c != r {
+			return input, false
+		}
+		input = input[size:]
+	}
+	return input, true
+}
+
+// consumeDigits consumes up to n leading digits
+func consumeDigits(input string, n int) (string, string) {
+	i := 0
+	for i < len(input) && i < n && input[i] >= '0' && input[i] <= '9' {
+		i++
+	}
+	return input[:i], input[i:]
+}
+
+// consumeMonth consumes the longest wide or abbreviated name of a month, returning -1 if none matches
+func consumeMonth(lang Language, input string) (int, string) {
+	names := namesOf(monthNames, lang)
+
+	month, length := -1, 0
+	for _, candidates := range [][]string{names.wide, names.abbreviated} {
+		for i, name := range candidates {
+			for _, name := range []string{name, strings.TrimSuffix(name, ".")} {
+				if len(name) > length && len(name) <= len(input) && strings.EqualFold(input[:len(name)], name) {
+					month, length = i+1, len(name)
+				}
+			}
+		}
+	}
+	return month, input[length:]
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseNumber(t *testing.T) {
+	fn := func(lang Language, s string, expected float64) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := ParseNumber(lang, s)
+			if err != nil || got != expected {
+				t.Fatalf("expected %v, got %v: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("german", fn("de", "1.234,56", 1234.56))
+	t.Run("english", fn("en", "1,234.56", 1234.56))
+	t.Run("ungrouped", fn("de", "1234,5", 1234.5))
+	t.Run("negative", fn("de", "-12,5", -12.5))
+	t.Run("minus sign", fn("en", "−3", -3))
+	t.Run("fraction only", fn("en", ".5", 0.5))
+	t.Run("french no-break space", fn("fr", "1\u202f234,5", 1234.5))
+	t.Run("french space", fn("fr", " 1 234 567 ", 1234567))
+	t.Run("swiss apostrophe", fn("de-ch", "1'234.5", 1234.5))
+	t.Run("round trip", fn("de", localizeNumber("de", "-1234567.25"), -1234567.25))
+
+	invalid := func(lang Language, s string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got, err := ParseNumber(lang, s); err == nil {
+				t.Fatalf("expected error, got %v", got)
+			}
+		}
+	}
+
+	t.Run("empty", invalid("en", ""))
+	t.Run("letters", invalid("en", "12a"))
+	t.Run("misplaced group", invalid("en", "12,34"))
+	t.Run("decimal of other language", invalid("de", "1,234.5"))
+	t.Run("two decimals", invalid("en", "1.2.3"))
+}
+
+func TestParseDate(t *testing.T) {
+	fn := func(lang Language, s string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := ParseDate(lang, s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Format("2006-01-02") != expected || got.Location() != time.UTC {
+				t.Fatalf("expected %s, got %s", expected, got)
+			}
+		}
+	}
+
+	t.Run("british", fn("en-gb", "31/12/2025", "2025-12-31"))
+	t.Run("american", fn("en", "12/31/25", "2025-12-31"))
+	t.Run("american full year", fn("en", "12/31/2025", "2025-12-31"))
+	t.Run("two digit year pivot", fn("de", "31.12.69", "1969-12-31"))
+	t.Run("german short", fn("de", "31.12.2025", "2025-12-31"))
+	t.Run("german wide", fn("de", "31. Dezember 2025", "2025-12-31"))
+	t.Run("case insensitive", fn("fr", "1 JANVIER 2025", "2025-01-01"))
+	t.Run("abbreviated month", fn("en", "Dec 31, 2025", "2025-12-31"))
+	t.Run("japanese", fn("ja", "2025年12月31日", "2025-12-31"))
+	t.Run("thai buddhist", fn("th", "31/12/2568", "2025-12-31"))
+	t.Run("iso", fn("en", "2025-12-31", "2025-12-31"))
+	t.Run("round trip", fn("es", FormatDate("es", time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC), Wide), "2025-03-04"))
+
+	invalid := func(lang Language, s string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got, err := ParseDate(lang, s); err == nil {
+				t.Fatalf("expected error, got %s", got)
+			}
+		}
+	}
+
+	t.Run("empty", invalid("en", ""))
+	t.Run("day exceeding month", invalid("en-gb", "31/02/2025"))
+	t.Run("month exceeding year", invalid("en-gb", "01/13/2025"))
+	t.Run("trailing text", invalid("en-gb", "31/12/2025 x"))
+	t.Run("unknown month", invalid("en", "Foo 31, 2025"))
+}
@@ -0,0 +1,366 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Edit is a change of a translation made at runtime, e.g. by a copy editor
+type Edit struct {
+	Language Language
+	Key      Key
+	// Message is the new message of the key, it is empty if the key is deleted
+	Message string
+	// Deleted reports whether the translation of the key is deleted
+	Deleted bool
+}
+
+// Persister stores the edits made through a catalog such that they survive restarts.
+// Persisted edits are applied on top of the language files whenever translations are
+// loaded. See WithPersister.
+type Persister interface {
+	// Save stores the edit, replacing any edit of the same key
+	Save(ctx context.Context, edit Edit) error
+	// Edits returns all stored edits
+	Edits(ctx context.Context) ([]Edit, error)
+}
+
+// Set translates the key in the language by the message, persisting the edit if a persister
+// is configured. The edited translations are checked as upon loading, e.g. for keys colliding
+// or intermediates declaring other types than in the default language. The translations are
+// kept if the language is not loaded, the edit fails the checks or persisting fails. Edits
+// apply to the base translations, not to the overrides of tenants.
+func (c *Catalog) Set(ctx context.Context, lang Language, key Key, message string) error {
+	return c.edit(ctx, Edit{Language: lang, Key: key, Message: message})
+}
+
+// Delete removes the translation of the key in the language, persisting the edit if
+// a persister is configured. The translations are kept if persisting fails.
+func (c *Catalog) Delete(ctx context.Context, lang Language, key Key) error {
+	return c.edit(ctx, Edit{Language: lang, Key: key, Deleted: true})
+}
+
+// edit applies the edit to the current translations and persists it
+func (c *Catalog) edit(ctx context.Context, edit Edit) error {
+	return c.update(func(current Translations) (Translations, error) {
+		edit.Language = normalizeLanguage(string(edit.Language))
+		edit.Key = current.normalizeKey(edit.Key)
+
+		// copy the stores on write, the current translations may still be in use
+		translations := make(map[Language]Store, len(current.translations)+1)
+		for lang, store := range current.translations {
+			translations[lang] = store
+		}
+		if edited, ok := translations[edit.Language]; ok {
+			store := make(Store, len(edited)+1)
+			for key, translation := range edited {
+				store[key] = translation
+			}
+			translations[edit.Language] = store
+		}
+
+		if err := current.applyEdit(translations, edit); err != nil {
+			return Translations{}, err
+		}
+		routes, err := current.checkEdited(translations)
+		if err != nil {
+			return Translations{}, err
+		}
+		if current.auditSink != nil {
+			if err := current.audit(ctx, edit); err != nil {
+				return Translations{}, err
+			}
+		}
+		if current.persister != nil {
+			if err := current.persister.Save(ctx, edit); err != nil {
+				return Translations{}, err
+			}
+		}
+		return current.withTranslations(translations, routes), nil
+	})
+}
+
+// checkEdited runs the checks of loading on the edited stores, failing with the first
+// issue found. It returns the route tables of the stores.
+func (trl Translations) checkEdited(translations map[Language]Store) (map[Language]routeTable, error) {
+	l := newLoader(trl)
+	l.translations = translations
+	l.keyMetadata = trl.metadata
+	l.check(trl.defaultLanguage)
+	if len(l.issues) > 0 {
+		return nil, l.issues[0]
+	}
+	return l.routes, nil
+}
+
+// applyEdits applies the edits of the persister to the loaded stores
+func (trl Translations) applyEdits(translations map[Language]Store) error {
+	edits, err := trl.persister.Edits(context.Background())
+	if err != nil {
+		return fmt.Errorf("loading persisted edits failed: %v", err)
+	}
+
+	for _, edit := range edits {
+		edit.Language = normalizeLanguage(string(edit.Language))
+		edit.Key = trl.normalizeKey(edit.Key)
+		if err := trl.applyEdit(translations, edit); err != nil {
+			return fmt.Errorf("invalid persisted edit of %q in %q: %v", edit.Key, edit.Language, err)
+		}
+	}
+	return nil
+}
+
+// applyEdit applies the normalized edit to the store of its language, which must be loaded
+func (trl Translations) applyEdit(translations map[Language]Store, edit Edit) error {
+	if !trl.languageRules.valid(edit.Language) {
+		return fmt.Errorf("invalid language %q, must follow %s", edit.Language, trl.languageRules.describe())
+	}
+	if _, ok := translations[edit.Language]; !ok {
+		return fmt.Errorf("unknown language %q", edit.Language)
+	}
+	if edit.Key == "" {
+		return errors.New("invalid key, should not be empty")
+	}
+	if limits := trl.limits; limits.MaxKeyLength > 0 && len(edit.Key) > limits.MaxKeyLength {
+		return fmt.Errorf("key exceeds maximum length of %d bytes", limits.MaxKeyLength)
+	}
+
+	if edit.Deleted {
+		delete(translations[edit.Language], edit.Key)
+		return nil
+	}
+
+	if limits := trl.limits; limits.MaxIntermediates > 0 && strings.Count(edit.Message, Prefix) > limits.MaxIntermediates {
+		return fmt.Errorf("message exceeds maximum of %d intermediates", limits.MaxIntermediates)
+	}
+	intermediates, segments, err := parseIntermediates(edit.Message)
+	if err != nil {
+		return err
+	}
+	translations[edit.Language][edit.Key] = Translation{
+		Message:       edit.Message,
+		Intermediates: intermediates,
+		segments:      segments,
+	}
+	return nil
+}
+
+// ObjectStore stores objects by their name, e.g. within a bucket of an object storage
+type ObjectStore interface {
+	// Get returns the object, the error wraps fs.ErrNotExist if the object does not exist
+	Get(ctx context.Context, name string) ([]byte, error)
+	// Put stores the object, replacing any existing object
+	Put(ctx context.Context, name string, data []byte) error
+}
+
+// ObjectPersister persists edits as a single JSON object within an object store, mapping
+// the languages to their edited keys. Deleted keys map to null, e.g. {"de": {"title": "Titel",
+// "legacy": null}}. Edits are read, modified and written as a whole by Save.
+type ObjectPersister struct {
+	Store ObjectStore
+	// Name is the name of the object holding the edits, e.g. "i18n/edits.json"
+	Name string
+}
+
+// Save stores the edit within the object
+func (p ObjectPersister) Save(ctx context.Context, edit Edit) error {
+	edits, err := p.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	if edits[edit.Language] == nil {
+		edits[edit.Language] = make(map[Key]*string)
+	}
+	if edit.Deleted {
+		edits[edit.Language][edit.Key] = nil
+	} else {
+		message := edit.Message
+		edits[edit.Language][edit.Key] = &message
+	}
+
+	data, err := json.MarshalIndent(edits, "", "\t")
+	if err != nil {
+		return err
+	}
+	return p.Store.Put(ctx, p.Name, data)
+}
+
+// Edits returns the edits stored within the object, ordered by language and key
+func (p ObjectPersister) Edits(ctx context.Context) ([]Edit, error) {
+	stored, err := p.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var edits []Edit
+	for _, lang := range sortedEditLanguages(stored) {
+		keys := make([]string, 0, len(stored[lang]))
+		for key := range stored[lang] {
+			keys = append(keys, string(key))
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			edit := Edit{Language: lang, Key: Key(key), Deleted: true}
+			if message := stored[lang][Key(key)]; message != nil {
+				edit.Message, edit.Deleted = *message, false
+			}
+			edits = append(edits, edit)
+		}
+	}
+	return edits, nil
+}
+
+// read reads the stored edits, a missing object holds no edits
+func (p ObjectPersister) read(ctx context.Context) (map[Language]map[Key]*string, error) {
+	edits := make(map[Language]map[Key]*string)
+
+	data, err := p.Store.Get(ctx, p.Name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return edits, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &edits); err != nil {
+		return nil, fmt.Errorf("invalid edits %q: %v", p.Name, err)
+	}
+	return edits, nil
+}
+
+// sortedEditLanguages returns the languages of the stored edits in sorted order
+func sortedEditLanguages(edits map[Language]map[Key]*string) []Language {
+	languages := make([]Language, 0, len(edits))
+	for lang := range edits {
+		languages = append(languages, lang)
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i] < languages[j] })
+	return languages
+}
+
+// DirectoryStore is an object store keeping objects as files within the directory
+type DirectoryStore string
+
+// Get reads the file of the object
+func (dir DirectoryStore) Get(ctx context.Context, name string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(dir), filepath.FromSlash(name)))
+}
+
+// Put replaces the file of the object atomically, such that concurrent
+// loading never observes a partially written file
+func (dir DirectoryStore) Put(ctx context.Context, name string, data []byte) error {
+	path := filepath.Join(string(dir), filepath.FromSlash(name))
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// FilePersister returns a persister storing the edits as a JSON file at path,
+// e.g. "edits.json". See ObjectPersister for the format of the file.
+func FilePersister(path string) ObjectPersister {
+	return ObjectPersister{
+		Store: DirectoryStore(filepath.Dir(path)),
+		Name:  filepath.Base(path),
+	}
+}
+
+// SQLPersister persists edits within a database table consisting of the columns
+// language, translation_key and message, holding a row per edited key of a language.
+// The message of deleted keys is NULL. An exemplary schema is:
+//
+//	CREATE TABLE i18n_edits (
+//		language        VARCHAR(35)  NOT NULL,
+//		translation_key VARCHAR(255) NOT NULL,
+//		message         TEXT,
+//		PRIMARY KEY (language, translation_key)
+//	)
+type SQLPersister struct {
+	DB *sql.DB
+	// Table is the name of the table, it is not quoted and must be trusted
+	Table string
+	// Placeholder returns the placeholder of the n-th parameter starting at 1, defaulting
+	// to "?". PostgreSQL requires NumberedPlaceholder.
+	Placeholder func(n int) string
+}
+
+// Save replaces the row of the edited key within a transaction
+func (p SQLPersister) Save(ctx context.Context, edit Edit) error {
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	deleteQuery := "DELETE FROM " + p.Table + " WHERE language = " + p.placeholder(1) + " AND translation_key = " + p.placeholder(2)
+	if _, err := tx.ExecContext(ctx, deleteQuery, string(edit.Language), string(edit.Key)); err != nil {
+		return err
+	}
+
+	message := sql.NullString{String: edit.Message, Valid: !edit.Deleted}
+	insertQuery := "INSERT INTO " + p.Table + " (language, translation_key, message) VALUES (" +
+		p.placeholder(1) + ", " + p.placeholder(2) + ", " + p.placeholder(3) + ")"
+	if _, err := tx.ExecContext(ctx, insertQuery, string(edit.Language), string(edit.Key), message); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Edits returns the edits stored within the table, ordered by language and key
+func (p SQLPersister) Edits(ctx context.Context) ([]Edit, error) {
+	rows, err := p.DB.QueryContext(ctx, "SELECT language, translation_key, message FROM "+p.Table+" ORDER BY language, translation_key")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var edits []Edit
+	for rows.Next() {
+		var lang, key string
+		var message sql.NullString
+		if err := rows.Scan(&lang, &key, &message); err != nil {
+			return nil, err
+		}
+		edits = append(edits, Edit{
+			Language: Language(lang),
+			Key:      Key(key),
+			Message:  message.String,
+			Deleted:  !message.Valid,
+		})
+	}
+	return edits, rows.Err()
+}
+
+// placeholder returns the placeholder of the n-th parameter
+func (p SQLPersister) placeholder(n int) string {
+	if p.Placeholder == nil {
+		return "?"
+	}
+	return p.Placeholder(n)
+}
+
+// NumberedPlaceholder returns numbered placeholders like "$1" as required by PostgreSQL
+func NumberedPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
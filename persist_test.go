@@ -0,0 +1,316 @@
+package i18n
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestCatalogEdits(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"title": "Title", "legacy": "Legacy"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"title": "Titel"}`)},
+		"fr.json": &fstest.MapFile{Data: []byte(`{"legacy": "Héritage"}`)},
+	}
+	persister := FilePersister(filepath.Join(t.TempDir(), "edits.json"))
+	options := []Option{WithFS(fsys), WithDefaultLanguage("en"), WithPersister(persister)}
+
+	translations, err := New(options...).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	catalog := NewCatalog(translations)
+	if err := catalog.Set(ctx, "de", "title", "Überschrift {{name}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Set(ctx, "fr", "title", "Titre"); err != nil {
+		t.Fatal(err)
+	}
+	if err := catalog.Delete(ctx, "en", "legacy"); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(trl Translations) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got, err := trl.GenerateTranslate("de")("title", "name", "Bericht"); err != nil || got != "Überschrift Bericht" {
+				t.Fatalf("unexpected translation %q: %v", got, err)
+			}
+			if got, err := trl.GenerateTranslate("fr")("title"); err != nil || got != "Titre" {
+				t.Fatalf("unexpected translation %q: %v", got, err)
+			}
+			if trl.Has("en", "legacy") {
+				t.Fatal("expected deleted key to be removed")
+			}
+		}
+	}
+
+	t.Run("catalog", fn(catalog.Translations()))
+
+	restarted, err := New(options...).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Run("restart", fn(restarted))
+
+	// the translations loaded before editing remain untouched
+	if got, err := translations.GenerateTranslate("de")("title"); err != nil || got != "Titel" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+}
+
+func TestCatalogInvalidEdit(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"title": "Title", "count": "{{n:int}} items", "b": "B"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"title": "Titel"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithPersister(failingPersister{}), WithLimits(Limits{MaxIntermediates: 2})).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalog := NewCatalog(translations)
+
+	fn := func(edit func() error) func(t *testing.T) {
+		return func(t *testing.T) {
+			if err := edit(); err == nil {
+				t.Fatal("expected error")
+			}
+			if got, _ := catalog.GenerateTranslate("en")("title"); got != "Title" {
+				t.Fatalf("expected translations to be kept, got %q", got)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	t.Run("invalid message", fn(func() error { return catalog.Set(ctx, "en", "title", "{{name") }))
+	t.Run("invalid language", fn(func() error { return catalog.Set(ctx, "english", "title", "Title") }))
+	t.Run("empty key", fn(func() error { return catalog.Set(ctx, "en", "", "Title") }))
+	t.Run("unknown language", fn(func() error { return catalog.Set(ctx, "fr", "title", "Titre") }))
+	t.Run("conflicting type", fn(func() error { return catalog.Set(ctx, "de", "count", "{{n:time}} Einträge") }))
+	t.Run("key collision", fn(func() error { return catalog.Set(ctx, "de", "b.c", "X") }))
+	t.Run("too many intermediates", fn(func() error { return catalog.Set(ctx, "de", "title", "{{a}} {{b}} {{c}}") }))
+	t.Run("persisting fails", fn(func() error { return catalog.Set(ctx, "en", "title", "Heading") }))
+}
+
+func TestCatalogEditRoutes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"routes": {"pricing": "pricing"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"routes": {"pricing": "preise"}}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	if err := catalog.Set(context.Background(), "de", "routes.pricing", "tarife"); err != nil {
+		t.Fatal(err)
+	}
+	if got := catalog.Translations().LocalizePath("de", "/pricing"); got != "/tarife" {
+		t.Fatalf("expected edited route, got %q", got)
+	}
+}
+
+func TestInvalidPersistedEdits(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"count": "{{n:int}} items"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"count": "{{n:int}} Einträge"}`)},
+	}
+
+	fn := func(edit Edit, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			persister := ObjectPersister{Store: make(memoryStore), Name: "edits.json"}
+			if err := persister.Save(context.Background(), edit); err != nil {
+				t.Fatal(err)
+			}
+			_, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithPersister(persister)).Load()
+			if err == nil || !strings.Contains(err.Error(), expected) {
+				t.Fatalf("expected error %q, got %v", expected, err)
+			}
+		}
+	}
+
+	t.Run("conflicting type", fn(Edit{Language: "de", Key: "count", Message: "{{n:time}} Einträge"}, `intermediate "n" is declared as time, but as int for "en"`))
+	t.Run("unknown language", fn(Edit{Language: "fr", Key: "count", Message: "{{n}} éléments"}, `unknown language "fr"`))
+}
+
+// failingPersister fails to save and holds no edits
+type failingPersister struct{}
+
+func (failingPersister) Save(ctx context.Context, edit Edit) error {
+	return errors.New("unavailable")
+}
+
+func (failingPersister) Edits(ctx context.Context) ([]Edit, error) {
+	return nil, nil
+}
+
+// memoryStore is an object store keeping objects in memory
+type memoryStore map[string][]byte
+
+func (s memoryStore) Get(ctx context.Context, name string) ([]byte, error) {
+	data, ok := s[name]
+	if !ok {
+		return nil, fs.ErrNotExist
+	}
+	return data, nil
+}
+
+func (s memoryStore) Put(ctx context.Context, name string, data []byte) error {
+	s[name] = data
+	return nil
+}
+
+func TestPersisters(t *testing.T) {
+	db, err := sql.Open("i18n-edits", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	fn := func(persister Persister) func(t *testing.T) {
+		return func(t *testing.T) {
+			ctx := context.Background()
+			saved := []Edit{
+				{Language: "de", Key: "title", Message: "Titel"},
+				{Language: "de", Key: "legacy", Deleted: true},
+				{Language: "de", Key: "title", Message: "Überschrift"},
+				{Language: "en", Key: "title", Message: "Heading"},
+			}
+			for _, edit := range saved {
+				if err := persister.Save(ctx, edit); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			edits, err := persister.Edits(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := []Edit{
+				{Language: "de", Key: "legacy", Deleted: true},
+				{Language: "de", Key: "title", Message: "Überschrift"},
+				{Language: "en", Key: "title", Message: "Heading"},
+			}
+			if len(edits) != len(expected) {
+				t.Fatalf("expected %+v, got %+v", expected, edits)
+			}
+			for i := range expected {
+				if edits[i] != expected[i] {
+					t.Fatalf("expected %+v, got %+v", expected[i], edits[i])
+				}
+			}
+		}
+	}
+
+	t.Run("file", fn(FilePersister(filepath.Join(t.TempDir(), "edits.json"))))
+	t.Run("object", fn(ObjectPersister{Store: memoryStore{}, Name: "i18n/edits.json"}))
+	t.Run("sql", fn(SQLPersister{DB: db, Table: "i18n_edits", Placeholder: NumberedPlaceholder}))
+}
+
+func init() {
+	sql.Register("i18n-edits", &editsDriver{rows: make(map[[2]string]*string)})
+}
+
+// editsDriver is a database driver understanding the statements of SQLPersister only
+type editsDriver struct {
+	rows map[[2]string]*string
+}
+
+func (d *editsDriver) Open(name string) (driver.Conn, error) {
+	return editsConn{d}, nil
+}
+
+type editsConn struct {
+	driver *editsDriver
+}
+
+func (c editsConn) Prepare(query string) (driver.Stmt, error) {
+	return editsStmt{c.driver, query}, nil
+}
+
+func (c editsConn) Close() error { return nil }
+
+func (c editsConn) Begin() (driver.Tx, error) { return editsTx{}, nil }
+
+type editsTx struct{}
+
+func (editsTx) Commit() error { return nil }
+
+func (editsTx) Rollback() error { return nil }
+
+type editsStmt struct {
+	driver *editsDriver
+	query  string
+}
+
+func (s editsStmt) Close() error { return nil }
+
+func (s editsStmt) NumInput() int { return strings.Count(s.query, "$") }
+
+func (s editsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	key := [2]string{args[0].(string), args[1].(string)}
+	switch {
+	case strings.HasPrefix(s.query, "DELETE FROM i18n_edits WHERE language = $1 AND translation_key = $2"):
+		delete(s.driver.rows, key)
+	case strings.HasPrefix(s.query, "INSERT INTO i18n_edits (language, translation_key, message) VALUES ($1, $2, $3)"):
+		if message, ok := args[2].(string); ok {
+			s.driver.rows[key] = &message
+		} else {
+			s.driver.rows[key] = nil
+		}
+	default:
+		return nil, errors.New("unexpected statement " + s.query)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s editsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if s.query != "SELECT language, translation_key, message FROM i18n_edits ORDER BY language, translation_key" {
+		return nil, errors.New("unexpected query " + s.query)
+	}
+
+	keys := make([][2]string, 0, len(s.driver.rows))
+	for key := range s.driver.rows {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i][0] < keys[j][0] || keys[i][0] == keys[j][0] && keys[i][1] < keys[j][1]
+	})
+
+	rows := &editsRows{}
+	for _, key := range keys {
+		var message driver.Value
+		if m := s.driver.rows[key]; m != nil {
+			message = *m
+		}
+		rows.values = append(rows.values, []driver.Value{key[0], key[1], message})
+	}
+	return rows, nil
+}
+
+type editsRows struct {
+	values [][]driver.Value
+}
+
+func (r *editsRows) Columns() []string { return []string{"language", "translation_key", "message"} }
+
+func (r *editsRows) Close() error { return nil }
+
+func (r *editsRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
@@ -0,0 +1,304 @@
+package i18n
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// IntermediateType is the type an intermediate may declare for its parameter
+// by suffixing its name, e.g. {{count:int}}
+type IntermediateType string
+
+// Supported intermediate types
+const (
+	TypeString IntermediateType = "string"
+	TypeInt    IntermediateType = "int"
+	TypeFloat  IntermediateType = "float"
+	TypeBool   IntermediateType = "bool"
+	TypeTime   IntermediateType = "time"
+)
+
+// TypeSeparator separates the name of an intermediate from its declared type
+const TypeSeparator = ":"
+
+// FormatSeparator separates the name of an intermediate from the formats applied
+// to its parameter value in order, e.g. {{name, upper}}
+const FormatSeparator = ","
+
+// DefaultPrefix prefixes the default value of an intermediate used if no parameter is
+// passed for it, e.g. {{name, default:Gast}}. Defaults of typed intermediates must be
+// of their type, times in the RFC 3339 format.
+const DefaultPrefix = "default:"
+
+// formatFunc formats the parameter value of an intermediate within the language using the
+// options of the format. Options may reference other parameters, e.g. the zone of a user.
+// Formats applied after another format are passed the formatted string.
+type formatFunc func(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error)
+
+// formatDefinition defines a format and the options it accepts
+type formatDefinition struct {
+	format  formatFunc
+	options []string
+}
+
+// formatOptions are the options passed to a format, e.g. {{ts, datetime(zone=tz)}}
+type formatOptions map[string]string
+
+// format is a format applied by an intermediate
+type format struct {
+	name    string
+	options formatOptions
+}
+
+// formats are the formats intermediates may apply by their name
+var formats = map[string]formatDefinition{
+	"upper":     {format: stringFormat(Upper)},
+	"lower":     {format: stringFormat(Lower)},
+	"title":     {format: stringFormat(Title)},
+	"sentence":  {format: stringFormat(Sentence)},
+	"quote":     {format: stringFormat(Quote)},
+	"punctuate": {format: stringFormat(Punctuate)},
+	"ordinal":   {format: valueFormat(formatOrdinal)},
+	"bytes":     {format: valueFormat(formatBytes)},
+	"number":    {format: formatNumber, options: []string{"skeleton"}},
+	"measure":   {format: formatMeasure, options: measureOptions},
+	"date":      {format: timeFormat(formatDate), options: dateOptions},
+	"time":      {format: timeFormat(formatTime), options: timeOptions},
+	"datetime":  {format: timeFormat(formatDateTime), options: dateTimeOptions},
+	"week":      {format: timeFormat(formatWeek), options: []string{"zone"}},
+}
+
+// stringFormat adapts a function formatting strings to a format
+func stringFormat(format func(lang Language, s string) string) formatFunc {
+	return func(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error) {
+		return format(lang, formatValue(value)), nil
+	}
+}
+
+// valueFormat adapts a function formatting values without options to a format
+func valueFormat(format func(lang Language, value interface{}) string) formatFunc {
+	return func(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error) {
+		return format(lang, value), nil
+	}
+}
+
+// applyFormats formats the value by the formats in order
+func applyFormats(lang Language, value interface{}, formatList []format, params intermediateLookup) (string, error) {
+	var formatted string
+	for _, f := range formatList {
+		var err error
+		formatted, err = formats[f.name].format(lang, value, f.options, params)
+		if err != nil {
+			return "", fmt.Errorf("format %s: %v", f.name, err)
+		}
+		value = formatted
+	}
+	return formatted, nil
+}
+
+// parseFormat parses a format and its options, e.g. "datetime(zone=tz; style=short)".
+// Options are separated by ";" or "," and their values by "=" or ":".
+func parseFormat(s string) (format, error) {
+	s = strings.TrimSpace(s)
+	f := format{name: s}
+	if i := strings.Index(s, "("); i != -1 {
+		if !strings.HasSuffix(s, ")") {
+			return format{}, fmt.Errorf("invalid format %q, options must end with )", s)
+		}
+		f.name = strings.TrimSpace(s[:i])
+		f.options = make(formatOptions)
+
+		options := s[i+1 : len(s)-1]
+		for _, option := range strings.FieldsFunc(options, func(r rune) bool { return r == ';' || r == ',' }) {
+			j := strings.IndexAny(option, "=:")
+			if j == -1 {
+				return format{}, fmt.Errorf("invalid option %q of format %q, must be name=value", strings.TrimSpace(option), f.name)
+			}
+			f.options[strings.TrimSpace(option[:j])] = strings.TrimSpace(option[j+1:])
+		}
+	}
+
+	definition, ok := formats[f.name]
+	if !ok {
+		return format{}, fmt.Errorf("unknown format %q", f.name)
+	}
+	for name := range f.options {
+		if !containsString(definition.options, name) {
+			return format{}, fmt.Errorf("unknown option %q of format %q", name, f.name)
+		}
+	}
+	return f, nil
+}
+
+// splitPlaceholder splits the placeholder at the format separators outside of parentheses
+func splitPlaceholder(placeholder string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range placeholder {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0 && strings.HasPrefix(placeholder[i:], FormatSeparator):
+			parts = append(parts, placeholder[start:i])
+			start = i + len(FormatSeparator)
+		}
+	}
+	return append(parts, placeholder[start:])
+}
+
+// containsString reports whether s contains v
+func containsString(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
+
+// timeType is the reflected type of time values
+var timeType = reflect.TypeOf(time.Time{})
+
+// Accepts reports whether the parameter value is of the type. An intermediate without
+// a declared type accepts any value. Floats accept integers as well.
+func (typ IntermediateType) Accepts(value interface{}) bool {
+	if typ == "" {
+		return true
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() {
+		return false
+	}
+
+	switch typ {
+	case TypeString:
+		if _, ok := value.(fmt.Stringer); ok {
+			return true
+		}
+		return v.Kind() == reflect.String
+	case TypeInt:
+		return isInteger(v.Kind())
+	case TypeFloat:
+		return isInteger(v.Kind()) || v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+	case TypeBool:
+		return v.Kind() == reflect.Bool
+	case TypeTime:
+		return v.Type() == timeType
+	}
+	return false
+}
+
+// valid reports whether the type is known
+func (typ IntermediateType) valid() bool {
+	switch typ {
+	case TypeString, TypeInt, TypeFloat, TypeBool, TypeTime:
+		return true
+	}
+	return false
+}
+
+func isInteger(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// parsePlaceholder parses the content of a placeholder between Prefix and Suffix
+// into an intermediate segment. Names containing TypeSeparator or FormatSeparator
+// remain plain names unless followed by a known type or known formats and defaults.
+func parsePlaceholder(placeholder string) (segment, error) {
+	parts := splitPlaceholder(placeholder)
+	if !annotated(parts[1:]) {
+		parts = []string{placeholder}
+	}
+	name := strings.TrimSpace(parts[0])
+
+	var typ IntermediateType
+	if i := strings.Index(name, TypeSeparator); i != -1 {
+		if declared := IntermediateType(strings.TrimSpace(name[i+len(TypeSeparator):])); declared.valid() {
+			typ = declared
+			name = strings.TrimSpace(name[:i])
+		}
+	}
+
+	if name == "" {
+		return segment{}, fmt.Errorf("empty intermediate")
+	}
+
+	var formatList []format
+	var fallback interface{}
+	for _, part := range parts[1:] {
+		if value := strings.TrimLeft(part, " "); strings.HasPrefix(value, DefaultPrefix) {
+			if fallback != nil {
+				return segment{}, fmt.Errorf("invalid default of intermediate %q, must be declared once", name)
+			}
+			var err error
+			if fallback, err = parseDefault(typ, strings.TrimPrefix(value, DefaultPrefix)); err != nil {
+				return segment{}, fmt.Errorf("invalid default of intermediate %q: %v", name, err)
+			}
+			continue
+		}
+
+		f, err := parseFormat(part)
+		if err != nil {
+			return segment{}, fmt.Errorf("invalid format of intermediate %q: %v", name, err)
+		}
+		formatList = append(formatList, f)
+	}
+
+	return segment{
+		intermediate: Intermediate(name),
+		typ:          typ,
+		formats:      formatList,
+		fallback:     fallback,
+	}, nil
+}
+
+// annotated reports whether all parts following the name of a placeholder are
+// defaults or name known formats, otherwise they are part of the name
+func annotated(parts []string) bool {
+	for _, part := range parts {
+		value := strings.TrimSpace(part)
+		if strings.HasPrefix(value, DefaultPrefix) {
+			continue
+		}
+		if i := strings.Index(value, "("); i != -1 {
+			value = strings.TrimSpace(value[:i])
+		}
+		if _, ok := formats[value]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// parseDefault parses the default value of an intermediate of the type
+func parseDefault(typ IntermediateType, s string) (interface{}, error) {
+	var value interface{}
+	var err error
+	switch typ {
+	case TypeInt:
+		value, err = strconv.ParseInt(s, 10, 64)
+	case TypeFloat:
+		value, err = strconv.ParseFloat(s, 64)
+	case TypeBool:
+		value, err = strconv.ParseBool(s)
+	case TypeTime:
+		value, err = time.Parse(time.RFC3339, s)
+	default:
+		value = s
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%q is not of type %s", s, typ)
+	}
+	return value, nil
+}
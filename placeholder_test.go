@@ -0,0 +1,193 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestIntermediateType(t *testing.T) {
+	fn := func(typ IntermediateType, value interface{}, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := typ.Accepts(value); got != expected {
+				t.Fatalf("expected %v for %T as %s, got %v", expected, value, typ, got)
+			}
+		}
+	}
+
+	t.Run("untyped", fn("", nil, true))
+	t.Run("string", fn(TypeString, "a", true))
+	t.Run("stringer", fn(TypeString, stringer{}, true))
+	t.Run("string as int", fn(TypeString, 1, false))
+	t.Run("int", fn(TypeInt, 1, true))
+	t.Run("uint8", fn(TypeInt, uint8(1), true))
+	t.Run("float as int", fn(TypeInt, 1.5, false))
+	t.Run("float", fn(TypeFloat, 1.5, true))
+	t.Run("int as float", fn(TypeFloat, 1, true))
+	t.Run("bool", fn(TypeBool, true, true))
+	t.Run("time", fn(TypeTime, time.Now(), true))
+	t.Run("string as time", fn(TypeTime, "now", false))
+	t.Run("nil", fn(TypeString, nil, false))
+}
+
+func TestTypedIntermediates(t *testing.T) {
+	fn := func(en string, de string, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(en)},
+				"de.json": &fstest.MapFile{Data: []byte(de)},
+			}
+
+			issues := Validate(fsys, "en")
+			if got := len(issues) == 0; got != expected {
+				t.Fatalf("expected %v, got %v: %v", expected, got, issues)
+			}
+		}
+	}
+
+	t.Run("consistent", fn(`{"a": "{{n:int}} items"}`, `{"a": "{{ n : int }} Artikel"}`, true))
+	t.Run("undeclared", fn(`{"a": "{{n:int}} items"}`, `{"a": "{{n}} Artikel"}`, true))
+	t.Run("inconsistent", fn(`{"a": "{{n:int}} items"}`, `{"a": "{{n:string}} Artikel"}`, false))
+	t.Run("conflicting", fn(`{"a": "{{n:int}} of {{n:float}}"}`, `{"a": "x"}`, false))
+	t.Run("unknown type", fn(`{"a": "{{n:integer}}"}`, `{"a": "{{n:integer}}"}`, true))
+	t.Run("empty name", fn(`{"a": "{{:int}}"}`, `{"a": "x"}`, false))
+
+	t.Run("translate", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"en.json": &fstest.MapFile{Data: []byte(`{"a": "{{n:int}} items since {{d:time}}"}`)},
+		}
+
+		translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		translation := translations.translations["en"]["a"]
+		if typ := translation.Type("n"); typ != TypeInt {
+			t.Fatalf("expected int, got %q", typ)
+		}
+
+		translate := translations.GenerateDefaultTranslate()
+		if _, err := translate("a", "n", 3, "d", time.Now()); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := translate("a", "n", "3", "d", time.Now()); err == nil {
+			t.Fatal("expected error for parameter of wrong type")
+		}
+	})
+}
+
+func TestFormattedIntermediates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"welcome": "Welcome {{name, title}} from {{city:string, upper}}!",
+			"chained": "{{name, lower, sentence}}"
+		}`)},
+		"tr.json": &fstest.MapFile{Data: []byte(`{
+			"welcome": "{{city, upper}} şehrinden hoş geldin {{name, title}}!"
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", "welcome", "Welcome Ian Smith from IZMIR!", "name", "ian smith", "city", "izmir"))
+	t.Run("turkish", fn("tr", "welcome", "İZMİR şehrinden hoş geldin İrem!", "name", "irem", "city", "izmir"))
+	t.Run("chained", fn("en", "chained", "Hello world", "name", "HELLO WORLD"))
+	t.Run("escaped", fn("en", "welcome", "Welcome &lt;B&gt; from A&amp;B!", "name", "<b>", "city", "a&b"))
+
+	issues := Validate(fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "{{name, upper(case=x)}}"}`)},
+	}, "en")
+	if len(issues) != 1 || issues[0].Message != `invalid format of intermediate "name": unknown option "case" of format "upper"` {
+		t.Fatalf("expected unknown option issue, got %v", issues)
+	}
+}
+
+func TestSeparatorsInNames(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"ratio": "{{a:b}}", "name": "{{last, first}}", "shout": "{{name, shout}}", "typed": "{{n:int}}"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("en")(key, params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("unknown type", fn("ratio", "16:9", "a:b", "16:9"))
+	t.Run("unknown format", fn("name", "Smith, Ian", "last, first", "Smith, Ian"))
+	t.Run("unknown format name", fn("shout", "hey", "name, shout", "hey"))
+	t.Run("known type", fn("typed", "5", "n", 5))
+}
+
+func TestDefaultIntermediates(t *testing.T) {
+	fsys := fstest.MapFS{
+		"de.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hallo {{name, default:Gast}}", "items": "{{n:int, default:0}} Artikel", "loud": "{{name, upper, default:Gast}}", "empty": "[{{name, default:}}]"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("de")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(key string, params []interface{}, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("de")(key, params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("default", fn("greeting", nil, "Hallo Gast"))
+	t.Run("passed", fn("greeting", []interface{}{"name", "Bob"}, "Hallo Bob"))
+	t.Run("typed", fn("items", nil, "0 Artikel"))
+	t.Run("formatted", fn("loud", nil, "GAST"))
+	t.Run("empty", fn("empty", nil, "[]"))
+
+	invalid := func(message string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "` + message + `"}`)}}
+			if issues := Validate(fsys, "en"); len(issues) == 0 {
+				t.Fatal("expected issue for invalid default")
+			}
+		}
+	}
+
+	t.Run("mistyped", invalid("{{n:int, default:many}}"))
+	t.Run("invalid time", invalid("{{ts:time, default:today}}"))
+	t.Run("declared twice", invalid("{{name, default:a, default:b}}"))
+}
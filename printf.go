@@ -0,0 +1,165 @@
+package i18n
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// printfDirective is a literal or a verb of a printf-style message, e.g. "%5.2f"
+type printfDirective struct {
+	literal string
+	// spec is the verb including its flags, width and precision, but without argument index
+	spec string
+	verb rune
+	// arg is the zero based index of the parameter formatted by the verb
+	arg int
+}
+
+// parsePrintf splits the printf-style message into literals and verbs, resolving the
+// parameter of each verb including explicit argument indexes as in "%[2]s". It returns
+// the number of parameters the message expects.
+func parsePrintf(message string) ([]printfDirective, int, error) {
+	var directives []printfDirective
+	var literal []byte
+	arg, args := 0, 0
+
+	for i := 0; i < len(message); i++ {
+		if message[i] != '%' {
+			literal = append(literal, message[i])
+			continue
+		}
+		if i+1 < len(message) && message[i+1] == '%' {
+			literal = append(literal, '%')
+			i++
+			continue
+		}
+		if len(literal) > 0 {
+			directives = append(directives, printfDirective{literal: string(literal)})
+			literal = nil
+		}
+
+		spec := []byte{'%'}
+		for i++; i < len(message); i++ {
+			c := message[i]
+			switch {
+			case c == '[':
+				end := strings.IndexByte(message[i:], ']')
+				if end == -1 {
+					return nil, 0, errors.New("invalid printf verb, argument index must end with ]")
+				}
+				n, err := strconv.Atoi(message[i+1 : i+end])
+				if err != nil || n < 1 {
+					return nil, 0, fmt.Errorf("invalid printf argument index %q", message[i:i+end+1])
+				}
+				arg = n - 1
+				i += end
+				continue
+			case c == '*':
+				return nil, 0, errors.New("invalid printf verb, width and precision must not be parameters")
+			case c == '+' || c == '-' || c == '#' || c == ' ' || c == '.' || '0' <= c && c <= '9':
+				spec = append(spec, c)
+				continue
+			case 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z':
+			default:
+				return nil, 0, fmt.Errorf("invalid printf verb %q", string(spec)+string(c))
+			}
+
+			directives = append(directives, printfDirective{spec: string(append(spec, c)), verb: rune(c), arg: arg})
+			arg++
+			if arg > args {
+				args = arg
+			}
+			break
+		}
+		if i == len(message) {
+			return nil, 0, errors.New("invalid printf verb, missing verb at end of message")
+		}
+	}
+
+	if len(literal) > 0 {
+		directives = append(directives, printfDirective{literal: string(literal)})
+	}
+	return directives, args, nil
+}
+
+// sprintf renders the printf-style message of the translation using the positional
+// parameters, escaping the formatted parameters using escape unless nil
+func (trl Translations) sprintf(key Key, translation Translation, params []interface{}, escape func(string) string) (string, error) {
+	directives, _, err := parsePrintf(translation.Message)
+	if err != nil {
+		return "", fmt.Errorf("translation %q: %v", key, err)
+	}
+
+	b := buffers.Get().(*bytes.Buffer)
+	defer releaseBuffer(b)
+
+	for _, directive := range directives {
+		if directive.verb == 0 {
+			b.WriteString(directive.literal)
+			continue
+		}
+		if directive.arg >= len(params) {
+			return "", fmt.Errorf("parameter %d required in translation %q", directive.arg+1, key)
+		}
+
+		formatted := fmt.Sprintf(directive.spec, params[directive.arg])
+		if escape != nil {
+			formatted = escape(formatted)
+		}
+		b.WriteString(formatted)
+	}
+	return b.String(), nil
+}
+
+// checkPrintf reports printf-style messages whose verbs can not be parsed or whose
+// parameters differ from the translation of the default language in number or verb
+func (l *loader) checkPrintf(defaultLanguage Language) {
+	type expectation struct {
+		args  int
+		verbs map[int]rune
+	}
+	expected := make(map[Key]expectation)
+
+	languages := sortedLanguages(l.translations)
+	sort.SliceStable(languages, func(i, j int) bool { return languages[i] == defaultLanguage })
+
+	for _, lang := range languages {
+		store := l.translations[lang]
+		for _, key := range sortedKeys(store) {
+			directives, args, err := parsePrintf(store[key].Message)
+			if err != nil {
+				l.report(l.origins[lang][key], lang, key, err.Error())
+				continue
+			}
+
+			verbs := make(map[int]rune)
+			for _, directive := range directives {
+				if _, ok := verbs[directive.arg]; directive.verb != 0 && !ok {
+					verbs[directive.arg] = directive.verb
+				}
+			}
+
+			other, ok := expected[key]
+			if !ok {
+				if lang == defaultLanguage {
+					expected[key] = expectation{args: args, verbs: verbs}
+				}
+				continue
+			}
+			if args != other.args {
+				l.report(l.origins[lang][key], lang, key, fmt.Sprintf("printf verbs expect %d parameters, but %d for %q", args, other.args, defaultLanguage))
+				continue
+			}
+			for arg := 0; arg < args; arg++ {
+				verb, ok := verbs[arg]
+				if defaultVerb, declared := other.verbs[arg]; ok && declared && verb != defaultVerb {
+					l.report(l.origins[lang][key], lang, key, fmt.Sprintf("parameter %d is formatted as %%%c, but as %%%c for %q", arg+1, verb, defaultVerb, defaultLanguage))
+				}
+			}
+		}
+	}
+}
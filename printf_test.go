@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestPrintfMessages(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"items": "%s has %d items", "share": "100%% of %.1f", "swap": "%s from %s", "tag": "<b>%s</b>"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"items": "%s hat %d Artikel", "swap": "aus %[2]s: %[1]s"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithPrintfMessages()).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, params []interface{}, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("positional", fn("de", "items", []interface{}{"Bob", 3}, "Bob hat 3 Artikel"))
+	t.Run("percent and precision", fn("en", "share", []interface{}{2.45}, "100% of 2.5"))
+	t.Run("argument index", fn("de", "swap", []interface{}{"a", "b"}, "aus b: a"))
+	t.Run("escaped", fn("en", "tag", []interface{}{"<i>"}, "<b>&lt;i&gt;</b>"))
+
+	if _, err := translations.GenerateTranslate("en")("items", "Bob"); err == nil {
+		t.Fatal("expected error for missing parameter")
+	}
+}
+
+func TestCheckPrintf(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"count": "%s has %d items", "verb": "%d items", "broken": "ok"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"count": "%s hat Artikel", "verb": "%s Artikel", "broken": "50%"}`)},
+	}
+
+	issues := Validate(fsys, "en", WithPrintfMessages())
+	expected := []string{
+		`invalid printf verb, missing verb at end of message with key "broken" for "de" in "de.json"`,
+		`printf verbs expect 1 parameters, but 2 for "en" with key "count" for "de" in "de.json"`,
+		`parameter 1 is formatted as %s, but as %d for "en" with key "verb" for "de" in "de.json"`,
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %v", len(expected), issues)
+	}
+	for i, issue := range issues {
+		if issue.Error() != expected[i] {
+			t.Fatalf("expected %q, got %q", expected[i], issue.Error())
+		}
+	}
+}
+
+func TestParsePrintf(t *testing.T) {
+	fn := func(message string, expected int, valid bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			_, args, err := parsePrintf(message)
+			if valid != (err == nil) {
+				t.Fatalf("expected valid %t, got %v", valid, err)
+			}
+			if args != expected {
+				t.Fatalf("expected %d parameters, got %d", expected, args)
+			}
+		}
+	}
+
+	t.Run("literal", fn("no verbs, 100%%", 0, true))
+	t.Run("flags", fn("%-5s %+.2f %#x", 3, true))
+	t.Run("argument index", fn("%[3]s %[1]s", 3, true))
+	t.Run("unterminated index", fn("%[1s", 0, false))
+	t.Run("star", fn("%*d", 0, false))
+	t.Run("missing verb", fn("%", 0, false))
+	t.Run("invalid verb", fn("%!", 0, false))
+}
@@ -0,0 +1,114 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// narrowNoBreakSpace is the space French typography puts before "!", "?" and ";"
+// and within guillemets
+const narrowNoBreakSpace = "\u202f"
+
+// noBreakSpace is the space French typography puts before ":"
+const noBreakSpace = "\u00a0"
+
+// quotationMarks are the opening and closing quotation marks per language,
+// following the CLDR delimiters. Languages not listed use “…”.
+var quotationMarks = map[Language][2]string{
+	"bg":      {"„", "“"},
+	"cs":      {"„", "“"},
+	"da":      {"“", "”"},
+	"de":      {"„", "“"},
+	"de-ch":   {"«", "»"},
+	"el":      {"«", "»"},
+	"es":      {"«", "»"},
+	"et":      {"„", "“"},
+	"fi":      {"”", "”"},
+	"fr":      {"«" + narrowNoBreakSpace, narrowNoBreakSpace + "»"},
+	"fr-ch":   {"«", "»"},
+	"hr":      {"„", "“"},
+	"hu":      {"„", "”"},
+	"it":      {"«", "»"},
+	"ja":      {"「", "」"},
+	"lt":      {"„", "“"},
+	"nb":      {"«", "»"},
+	"nl":      {"‘", "’"},
+	"nn":      {"«", "»"},
+	"pl":      {"„", "”"},
+	"pt-pt":   {"«", "»"},
+	"ro":      {"„", "”"},
+	"ru":      {"«", "»"},
+	"sk":      {"„", "“"},
+	"sl":      {"„", "“"},
+	"sr":      {"„", "“"},
+	"sv":      {"”", "”"},
+	"uk":      {"«", "»"},
+	"zh-hant": {"「", "」"},
+}
+
+// Quote wraps s in the quotation marks of the language, e.g. „…“ in German,
+// « … » in French or 「…」 in Japanese
+func Quote(lang Language, s string) string {
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if marks, ok := quotationMarks[candidate]; ok {
+			return marks[0] + s + marks[1]
+		}
+	}
+	return "“" + s + "”"
+}
+
+// Punctuate applies the punctuation spacing of the language to s. In French a narrow
+// no-break space is put before "!", "?" and ";" and a no-break space before ":",
+// replacing any space typed instead, e.g. "Merci !" becomes "Merci\u202f!". Swiss
+// French only spaces colons. Marks within URLs and the colons of times are kept as
+// they are. Text of other languages is returned as is.
+func Punctuate(lang Language, s string) string {
+	if lang.Base() != "fr" {
+		return s
+	}
+
+	marks := map[rune]string{':': noBreakSpace, '!': narrowNoBreakSpace, '?': narrowNoBreakSpace, ';': narrowNoBreakSpace}
+	if lang.Region() == "ch" {
+		marks = map[rune]string{':': noBreakSpace}
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	start := 0 // start of the text following the last space written
+	for i, r := range s {
+		space, ok := marks[r]
+		if !ok || i == 0 || isURLColon(s, i) || inURL(s, i) {
+			continue
+		}
+
+		text := strings.TrimRight(s[start:i], " "+noBreakSpace+narrowNoBreakSpace)
+		b.WriteString(text)
+		// marks following each other like "?!" are spaced only once
+		if last, _ := utf8.DecodeLastRuneInString(text); text != "" && marks[last] == "" {
+			b.WriteString(space)
+		}
+		start = i
+	}
+	b.WriteString(s[start:])
+	return b.String()
+}
+
+// inURL reports whether the mark at i lies within a URL, e.g. the "?" of
+// "https://example.com/?q=1", whose marks must not be spaced
+func inURL(s string, i int) bool {
+	word := s[strings.LastIndexFunc(s[:i], unicode.IsSpace)+1 : i]
+	return strings.Contains(word, "://")
+}
+
+// isURLColon reports whether the colon at i belongs to a URL scheme or a time, e.g.
+// "https://" or "10:30", which must not be spaced
+func isURLColon(s string, i int) bool {
+	if s[i] != ':' {
+		return false
+	}
+	if strings.HasPrefix(s[i:], "://") {
+		return true
+	}
+	return i > 0 && i+1 < len(s) && isDigit(s[i-1:i]) && isDigit(s[i+1:i+2])
+}
@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestQuote(t *testing.T) {
+	fn := func(lang Language, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Quote(lang, "Hallo"); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("english", fn("en", "“Hallo”"))
+	t.Run("german", fn("de-at", "„Hallo“"))
+	t.Run("swiss german", fn("de-ch", "«Hallo»"))
+	t.Run("french", fn("fr", "«\u202fHallo\u202f»"))
+	t.Run("japanese", fn("ja", "「Hallo」"))
+	t.Run("traditional chinese", fn("zh-tw", "「Hallo」"))
+	t.Run("simplified chinese", fn("zh", "“Hallo”"))
+	t.Run("portuguese", fn("pt-br", "“Hallo”"))
+	t.Run("european portuguese", fn("pt-pt", "«Hallo»"))
+}
+
+func TestPunctuate(t *testing.T) {
+	fn := func(lang Language, s string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Punctuate(lang, s); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("french", fn("fr", "Merci ! Vraiment?", "Merci\u202f! Vraiment\u202f?"))
+	t.Run("colon", fn("fr-ca", "Note : voir https://example.com à 10:30", "Note\u00a0: voir https://example.com à 10:30"))
+	t.Run("url", fn("fr", "Voir http://x.y/a?b=c;d=e#f!g:8080 ou https://x.y/?q=1 ?", "Voir http://x.y/a?b=c;d=e#f!g:8080 ou https://x.y/?q=1\u202f?"))
+	t.Run("repeated", fn("fr", "Quoi ?!", "Quoi\u202f?!"))
+	t.Run("swiss french", fn("fr-ch", "Note: merci!", "Note\u00a0: merci!"))
+	t.Run("english", fn("en", "Hello !", "Hello !"))
+	t.Run("leading", fn("fr", "?", "?"))
+}
+
+func TestQuoteFormat(t *testing.T) {
+	fsys := fstest.MapFS{
+		"de.json": &fstest.MapFile{Data: []byte(`{"said": "Sie sagte {{quote, quote}}"}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("de")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := translations.GenerateTranslate("de")("said", "quote", "Hallo"); err != nil || got != "Sie sagte „Hallo“" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+}
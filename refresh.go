@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// maxRefreshBackoff limits the backoff of failing refreshes to a multiple of the refresh interval
+const maxRefreshBackoff = 8
+
+// refreshJitter is the fraction the delay between refreshes randomly varies by,
+// preventing the instances of an application from refreshing in lockstep
+const refreshJitter = 0.1
+
+// Source provides updated translations, e.g. by reloading the language files,
+// downloading a bundle or querying a database
+type Source interface {
+	Load(ctx context.Context, current Translations) (Translations, error)
+}
+
+// SourceFunc is a function implementing Source
+type SourceFunc func(ctx context.Context, current Translations) (Translations, error)
+
+// Load calls f
+func (f SourceFunc) Load(ctx context.Context, current Translations) (Translations, error) {
+	return f(ctx, current)
+}
+
+// reloadSource reloads the language files of the current translations
+var reloadSource = SourceFunc(func(ctx context.Context, current Translations) (Translations, error) {
+	return current.Load()
+})
+
+// Load downloads the bundle, verifies its signature and loads its translations
+// with the options of the current translations
+func (remote RemoteBundle) Load(ctx context.Context, current Translations) (Translations, error) {
+	bundle, signature, err := remote.fetch(ctx)
+	if err != nil {
+		return Translations{}, err
+	}
+	return current.LoadBundle(bundle, signature, remote.PublicKey)
+}
+
+// Refresh loads the translations from the source of the catalog and swaps them in if
+// they changed. Without a source configured upon creating the catalog the language
+// files are reloaded. The current
+// translations are kept if loading fails. See WithRefreshSource.
+func (c *Catalog) Refresh(ctx context.Context) error {
+	err := c.Translations().traced(ctx, "i18n.Refresh", func(ctx context.Context, span Span) error {
+		err := c.reload(ctx)
+		if err == nil && span != nil {
+			span.SetAttributes(Attribute{Key: AttributeVersion, Value: c.Translations().Version()})
+		}
+		return err
+	})
+	c.health.record(err, time.Now())
+	return err
+}
+
+// reload loads the translations from the source of the catalog as Refresh
+func (c *Catalog) reload(ctx context.Context) error {
+	source := c.source
+	if source == nil {
+		source = reloadSource
+	}
+
+	trl, err := source.Load(ctx, c.Translations())
+	if err != nil {
+		return err
+	}
+
+	return c.update(func(current Translations) (Translations, error) {
+		if trl.Version() == current.Version() {
+			// keep the warm caches of the current translations
+			return current, nil
+		}
+		return trl, nil
+	})
+}
+
+// refresh refreshes the catalog within the interval until the catalog is closed.
+// Failing refreshes are logged and retried with an exponential backoff.
+func (c *Catalog) refresh(interval time.Duration) {
+	defer close(c.done)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.stop:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	failures := 0
+	for {
+		timer := time.NewTimer(refreshDelay(interval, failures, rand.Float64()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := c.Refresh(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			if logger := c.Translations().logger; logger != nil {
+				logger.Printf("i18n: refreshing translations failed: %v", err)
+			}
+			failures++
+			continue
+		}
+		failures = 0
+	}
+}
+
+// refreshDelay returns the delay until the next refresh after the number of consecutive
+// failures, varying the interval by the random number r within [0, 1)
+func refreshDelay(interval time.Duration, failures int, r float64) time.Duration {
+	delay := interval
+	for i := 0; i < failures && delay < maxRefreshBackoff*interval; i++ {
+		delay *= 2
+	}
+	if delay > maxRefreshBackoff*interval {
+		delay = maxRefreshBackoff * interval
+	}
+	return delay + time.Duration((2*r-1)*refreshJitter*float64(delay))
+}
+
+// Close stops refreshing the translations of the catalog. It is safe to call
+// Close multiple times and for catalogs not refreshing at all.
+func (c *Catalog) Close() {
+	c.closeOnce.Do(func() {
+		if c.stop == nil {
+			return
+		}
+		close(c.stop)
+		<-c.done
+	})
+}
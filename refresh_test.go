@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestRefresh(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+	}
+
+	var loads int32
+	source := SourceFunc(func(ctx context.Context, current Translations) (Translations, error) {
+		if atomic.AddInt32(&loads, 1) == 1 {
+			return Translations{}, errors.New("unavailable")
+		}
+		return New(WithFS(fstest.MapFS{
+			"en.json": &fstest.MapFile{Data: []byte(`{"a": "hi"}`)},
+		}), WithDefaultLanguage("en")).Load()
+	})
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"),
+		WithRefreshInterval(time.Millisecond), WithRefreshSource(source)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	defer catalog.Close()
+
+	translate := catalog.GenerateTranslate("en")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if got, _ := translate("a"); got == "hi" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("translations were not refreshed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	catalog.Close()
+	catalog.Close()
+	if atomic.LoadInt32(&loads) < 2 {
+		t.Fatal("expected failing refresh to be retried")
+	}
+}
+
+func TestRefreshUnchanged(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithCache(8)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	catalog := NewCatalog(translations)
+	defer catalog.Close()
+
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if catalog.Translations().cache != translations.cache {
+		t.Fatal("expected unchanged translations to be kept")
+	}
+
+	fsys["en.json"] = &fstest.MapFile{Data: []byte(`{"a": "hi"}`)}
+	if err := catalog.Refresh(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := catalog.GenerateTranslate("en")("a"); err != nil || got != "hi" {
+		t.Fatalf("unexpected translation %q: %v", got, err)
+	}
+}
+
+func TestRefreshDelay(t *testing.T) {
+	fn := func(failures int, r float64, expected time.Duration) func(t *testing.T) {
+		return func(t *testing.T) {
+			if delay := refreshDelay(time.Minute, failures, r); delay != expected {
+				t.Fatalf("expected %v, got %v", expected, delay)
+			}
+		}
+	}
+
+	t.Run("interval", fn(0, 0.5, time.Minute))
+	t.Run("jitter below", fn(0, 0, 54*time.Second))
+	t.Run("jitter above", fn(0, 1, 66*time.Second))
+	t.Run("backoff", fn(2, 0.5, 4*time.Minute))
+	t.Run("maximum backoff", fn(10, 0.5, 8*time.Minute))
+}
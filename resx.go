@@ -0,0 +1,116 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// resxData is a resource of a .resx file
+type resxData struct {
+	Name     string `xml:"name,attr"`
+	Type     string `xml:"type,attr"`
+	MimeType string `xml:"mimetype,attr"`
+	Value    string `xml:"value"`
+	Comment  string `xml:"comment"`
+}
+
+// resxLanguage derives the language of a resource file named by the convention of .NET, e.g.
+// "de" for "Strings.de.resx". Files lacking a culture hold the neutral resources of the
+// default language, e.g. "Strings.resx".
+func resxLanguage(name string, defaultLanguage Language) string {
+	i := strings.LastIndex(name, ".")
+	if i == -1 {
+		return string(defaultLanguage)
+	}
+	return name[i+1:]
+}
+
+// decodeResx decodes a .NET XML resource file, e.g. "Strings.de.resx". Each string resource is
+// loaded with its name as key, its comment as description of its metadata. Resources of
+// other types, e.g. images, are skipped. Placeholders are taken as they are, composite
+// formats like {0} must be renamed to intermediates.
+func (d *decoder) decodeResx() (Store, error) {
+	tokens := xml.NewDecoder(d.r)
+	tokens.CharsetReader = utf8CharsetReader
+
+	root := true
+	for {
+		token, err := tokens.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != "root" {
+				return nil, errors.New("invalid resx file, must consist of a root element")
+			}
+			root = false
+			continue
+		}
+		if start.Name.Local != "data" {
+			if err := tokens.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var data resxData
+		if err := tokens.DecodeElement(&data, &start); err != nil {
+			return nil, err
+		}
+		if data.MimeType != "" || data.Type != "" && !strings.HasPrefix(data.Type, "System.String") {
+			continue
+		}
+		d.resource("", data.Name, data.Value, data.Comment)
+	}
+
+	if root {
+		return nil, errors.New("invalid resx file, must consist of a root element")
+	}
+	return d.store, nil
+}
+
+// resource adds a message named within the context, e.g. a class, decoded of a file
+// not nesting keys, reporting invalid names
+func (d *decoder) resource(context string, name string, message string, description string) {
+	rootKey := d.key("", context)
+	if name == "" {
+		d.report(rootKey, "invalid key, should not be empty")
+		return
+	}
+
+	key := d.key(rootKey, name)
+	if d.limits.MaxKeyLength > 0 && len(key) > d.limits.MaxKeyLength {
+		d.report(rootKey, fmt.Sprintf("key exceeds maximum length of %d bytes", d.limits.MaxKeyLength))
+		return
+	}
+
+	source := "\x00" + name
+	if context != "" {
+		source = "\x00" + context + source
+	}
+	d.message(key, source, message)
+	if description != "" {
+		d.metadata[key] = d.metadata[key].merge(Metadata{Description: description})
+	}
+}
+
+// utf8CharsetReader reads XML declaring UTF-16 as UTF-8, files being decoded by
+// decodeUnicode before, see loadFile
+func utf8CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "utf-16", "utf-16le", "utf-16be", "unicode":
+		return input, nil
+	}
+	return nil, fmt.Errorf("unsupported charset %q", charset)
+}
@@ -0,0 +1,68 @@
+package i18n
+
+import (
+	"encoding/binary"
+	"testing"
+	"testing/fstest"
+)
+
+const resxStrings = `<?xml version="1.0" encoding="utf-8"?>
+<root>
+	<resheader name="resmimetype"><value>text/microsoft-resx</value></resheader>
+	<data name="greeting" xml:space="preserve">
+		<value>Hello {{name}}</value>
+		<comment>Greets the user on the dashboard</comment>
+	</data>
+	<data name="logo" type="System.Resources.ResXFileRef, System.Windows.Forms">
+		<value>logo.png;System.Byte[]</value>
+	</data>
+	<data name="icon" mimetype="application/x-microsoft.net.object.binary.base64">
+		<value>AAEAAAD/////AQAAAAAAAAAMAgAAAA==</value>
+	</data>
+</root>`
+
+func TestDecodeResx(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Strings.resx": &fstest.MapFile{Data: []byte(resxStrings)},
+		"Strings.de.resx": &fstest.MapFile{Data: encodeUTF16(`<?xml version="1.0" encoding="utf-16"?>
+<root><data name="greeting"><value>Hallo {{name}}</value></data></root>`, binary.LittleEndian, utf16LEBOM)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)("greeting", "name", "Anna")
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("neutral resources", fn("en", "Hello Anna"))
+	t.Run("culture", fn("de", "Hallo Anna"))
+
+	for _, key := range []string{"logo", "icon"} {
+		if _, err := translations.GenerateTranslate("en")(key); err == nil {
+			t.Fatalf("expected resource %q not being a string to be skipped", key)
+		}
+	}
+
+	if metadata, ok := translations.Metadata("greeting"); !ok || metadata.Description != "Greets the user on the dashboard" {
+		t.Fatalf("expected comment as description, got %+v", metadata)
+	}
+
+	validate := func(data string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fstest.MapFS{"en.resx": &fstest.MapFile{Data: []byte(data)}}, "en")
+			if len(issues) == 0 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("invalid root", validate(`<resources/>`, "invalid resx file, must consist of a root element"))
+	t.Run("empty name", validate(`<root><data><value>Hello</value></data></root>`, "invalid key, should not be empty"))
+}
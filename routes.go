@@ -0,0 +1,101 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RoutesKey is the key prefix of translated URL path segments, e.g. the key
+// "routes.pricing" translates the segment "pricing" into "preise"
+const RoutesKey Key = "routes"
+
+// routeTable maps the path segments of a language in both directions
+type routeTable struct {
+	// localized maps canonical segments to their translation
+	localized map[string]string
+	// canonical maps translated segments to their canonical segment
+	canonical map[string]string
+}
+
+// buildRoutes collects the translated path segments of the store,
+// reporting invalid and ambiguous translations
+func buildRoutes(store Store) (routeTable, []Issue) {
+	routes := routeTable{
+		localized: make(map[string]string),
+		canonical: make(map[string]string),
+	}
+
+	prefix := string(RoutesKey) + "."
+	var keys []Key
+	for key := range store {
+		if strings.HasPrefix(string(key), prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var issues []Issue
+	for _, key := range keys {
+		segment := strings.TrimPrefix(string(key), prefix)
+		slug := store[key].Message
+		switch {
+		case len(store[key].Intermediates) > 0:
+			issues = append(issues, Issue{Key: key, Message: "invalid route, must not contain intermediates"})
+		case slug == "" || strings.Contains(slug, "/"):
+			issues = append(issues, Issue{Key: key, Message: "invalid route, must be a single non-empty path segment"})
+		case routes.canonical[slug] != "":
+			issues = append(issues, Issue{Key: key, Message: fmt.Sprintf("ambiguous route %q, already used by %q", slug, routes.canonical[slug])})
+		default:
+			routes.localized[segment] = slug
+			routes.canonical[slug] = segment
+		}
+	}
+	return routes, issues
+}
+
+// checkRoutes builds the route tables of all languages of the stores, reporting
+// the issues within the files returned by origin
+func (l *loader) checkRoutes(stores map[Language]Store, origin func(Language, Key) string) map[Language]routeTable {
+	tables := make(map[Language]routeTable, len(stores))
+	for _, lang := range sortedLanguages(stores) {
+		routes, issues := buildRoutes(stores[lang])
+		for _, issue := range issues {
+			l.report(origin(lang, issue.Key), lang, issue.Key, issue.Message)
+		}
+		if len(routes.localized) > 0 {
+			tables[lang] = routes
+		}
+	}
+	return tables
+}
+
+// LocalizePath translates the segments of the canonical path into the language using the
+// route translations, e.g. "/preise/pro" for "/pricing/pro". Segments without translation,
+// such as identifiers, are kept as is.
+func (trl Translations) LocalizePath(lang string, canonicalPath string) string {
+	chain := trl.languageChain(trl.resolveLanguage(lang))
+	return trl.mapPath(chain, canonicalPath, func(routes routeTable) map[string]string { return routes.localized })
+}
+
+// CanonicalPath translates the segments of the localized path of the language back into
+// their canonical segments, e.g. "/pricing/pro" for "/preise/pro". Segments without
+// translation are kept as is.
+func (trl Translations) CanonicalPath(lang string, localizedPath string) string {
+	chain := trl.languageChain(trl.resolveLanguage(lang))
+	return trl.mapPath(chain, localizedPath, func(routes routeTable) map[string]string { return routes.canonical })
+}
+
+// mapPath maps each segment of the path by the first language of the chain mapping it
+func (trl Translations) mapPath(chain languageChain, p string, mapping func(routeTable) map[string]string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		for _, lang := range chain.languages {
+			if mapped, ok := mapping(trl.routes[lang])[segment]; ok {
+				segments[i] = mapped
+				break
+			}
+		}
+	}
+	return strings.Join(segments, "/")
+}
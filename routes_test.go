@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestRoutes(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":    &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json":    &fstest.MapFile{Data: []byte(`{"a": "hallo", "routes": {"pricing": "preise", "about": "ueber-uns"}}`)},
+		"de-at.json": &fstest.MapFile{Data: []byte(`{"routes": {"about": "ueber"}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, canonical string, localized string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.LocalizePath(lang, canonical); got != localized {
+				t.Fatalf("expected localized %q, got %q", localized, got)
+			}
+			if got := translations.CanonicalPath(lang, localized); got != canonical {
+				t.Fatalf("expected canonical %q, got %q", canonical, got)
+			}
+		}
+	}
+
+	t.Run("segment", fn("de", "/pricing", "/preise"))
+	t.Run("multiple segments", fn("de", "/about/pricing/", "/ueber-uns/preise/"))
+	t.Run("untranslated segment", fn("de", "/pricing/1234", "/preise/1234"))
+	t.Run("overlay", fn("de-AT", "/about/pricing", "/ueber/preise"))
+	t.Run("no routes", fn("en", "/pricing", "/pricing"))
+	t.Run("unknown language", fn("fr", "/pricing", "/pricing"))
+}
+
+func TestInvalidRoutes(t *testing.T) {
+	fn := func(routes string) func(t *testing.T) {
+		return func(t *testing.T) {
+			fsys := fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(`{"routes": ` + routes + `}`)},
+			}
+			if issues := Validate(fsys, "en"); len(issues) != 1 {
+				t.Fatalf("expected a single issue, got %v", issues)
+			}
+		}
+	}
+
+	t.Run("ambiguous", fn(`{"pricing": "prices", "plans": "prices"}`))
+	t.Run("multiple segments", fn(`{"pricing": "our/prices"}`))
+	t.Run("empty", fn(`{"pricing": ""}`))
+	t.Run("intermediates", fn(`{"pricing": "{{name}}"}`))
+}
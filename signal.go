@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+)
+
+// ReloadOnSignal refreshes the catalog as Refresh whenever the process receives one of the
+// signals, SIGHUP and SIGUSR2 on Unix systems if none are given, as daemons reload their
+// configuration. Other platforms such as Windows do not deliver these signals, the catalog is
+// never reloaded there unless signals are given. The outcome is logged to the logger of the
+// translations. Reloading stops once the returned function is called.
+func (c *Catalog) ReloadOnSignal(signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = reloadSignals
+	}
+	// notifying without signals would relay every signal
+	if len(signals) == 0 {
+		return func() {}
+	}
+
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-done:
+				return
+			case sig := <-received:
+				err := c.Refresh(context.Background())
+				logger := c.Translations().logger
+				if logger == nil {
+					continue
+				}
+				if err != nil {
+					logger.Printf("i18n: reloading translations on %v failed: %v", sig, err)
+				} else {
+					logger.Printf("i18n: reloaded translations on %v, version %s", sig, c.Translations().Version())
+				}
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(received)
+			close(done)
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !illumos && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!darwin,!dragonfly,!freebsd,!illumos,!linux,!netbsd,!openbsd,!solaris
+
+package i18n
+
+import "os"
+
+// reloadSignals are the signals ReloadOnSignal reloads upon by default,
+// none as platforms such as Windows do not deliver reload signals
+var reloadSignals []os.Signal
@@ -0,0 +1,57 @@
+//go:build aix || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type channelLogger chan string
+
+func (l channelLogger) Printf(format string, v ...interface{}) {
+	l <- fmt.Sprintf(format, v...)
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+	}
+
+	logger := make(channelLogger, 1)
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithLogger(logger)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the file system must not be modified once reloading may read it
+	fsys["en.json"] = &fstest.MapFile{Data: []byte(`{"a": "hi"}`)}
+
+	catalog := NewCatalog(translations)
+	stop := catalog.ReloadOnSignal(syscall.SIGUSR1)
+	defer stop()
+
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case message := <-logger:
+		if !strings.HasPrefix(message, "i18n: reloaded translations on user defined signal 1") {
+			t.Fatalf("expected reload to be logged, got %q", message)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("translations were not reloaded")
+	}
+	if got, _ := catalog.GenerateTranslate("en")("a"); got != "hi" {
+		t.Fatalf("expected reloaded translation, got %q", got)
+	}
+
+	stop()
+	stop()
+}
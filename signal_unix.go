@@ -0,0 +1,12 @@
+//go:build aix || darwin || dragonfly || freebsd || illumos || linux || netbsd || openbsd || solaris
+// +build aix darwin dragonfly freebsd illumos linux netbsd openbsd solaris
+
+package i18n
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals are the signals ReloadOnSignal reloads upon by default
+var reloadSignals = []os.Signal{syscall.SIGHUP, syscall.SIGUSR2}
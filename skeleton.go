@@ -0,0 +1,335 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commonSkeletons are the patterns of date skeletons shared by most languages
+var commonSkeletons = map[string]string{
+	"y":    "{y}",
+	"MMM":  "{MMM}",
+	"MMMM": "{MMMM}",
+	"d":    "{d}",
+	"E":    "{E}",
+}
+
+// skeletonsByLanguage lists the patterns of date skeletons following the CLDR available
+// formats. Skeletons list their fields in the order year, month, weekday and day.
+var skeletonsByLanguage = map[Language]map[string]string{
+	"de": {
+		"yM": "{M}/{y}", "yMd": "{d}.{M}.{y}", "yMEd": "{E}, {d}.{M}.{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d}. {MMM} {y}", "yMMMEd": "{E}, {d}. {MMM} {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{d}. {MMMM} {y}",
+		"Md": "{d}.{M}.", "MEd": "{E}, {d}.{M}.", "MMMd": "{d}. {MMM}", "MMMEd": "{E}, {d}. {MMM}", "MMMMd": "{d}. {MMMM}",
+	},
+	"en": {
+		"yM": "{M}/{y}", "yMd": "{M}/{d}/{y}", "yMEd": "{E}, {M}/{d}/{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{MMM} {d}, {y}", "yMMMEd": "{E}, {MMM} {d}, {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{MMMM} {d}, {y}",
+		"Md": "{M}/{d}", "MEd": "{E}, {M}/{d}", "MMMd": "{MMM} {d}", "MMMEd": "{E}, {MMM} {d}", "MMMMd": "{MMMM} {d}",
+	},
+	"en-gb": {
+		"yM": "{MM}/{y}", "yMd": "{dd}/{MM}/{y}", "yMEd": "{E}, {dd}/{MM}/{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d} {MMM} {y}", "yMMMEd": "{E}, {d} {MMM} {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{d} {MMMM} {y}",
+		"Md": "{dd}/{MM}", "MEd": "{E} {dd}/{MM}", "MMMd": "{d} {MMM}", "MMMEd": "{E} {d} {MMM}", "MMMMd": "{d} {MMMM}",
+	},
+	"es": {
+		"yM": "{M}/{y}", "yMd": "{d}/{M}/{y}", "yMEd": "{E}, {d}/{M}/{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d} {MMM} {y}", "yMMMEd": "{E}, {d} {MMM} {y}",
+		"yMMMM": "{MMMM} de {y}", "yMMMMd": "{d} de {MMMM} de {y}",
+		"Md": "{d}/{M}", "MEd": "{E}, {d}/{M}", "MMMd": "{d} {MMM}", "MMMEd": "{E}, {d} {MMM}", "MMMMd": "{d} de {MMMM}",
+	},
+	"fr": {
+		"yM": "{MM}/{y}", "yMd": "{dd}/{MM}/{y}", "yMEd": "{E} {dd}/{MM}/{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d} {MMM} {y}", "yMMMEd": "{E} {d} {MMM} {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{d} {MMMM} {y}",
+		"Md": "{dd}/{MM}", "MEd": "{E} {dd}/{MM}", "MMMd": "{d} {MMM}", "MMMEd": "{E} {d} {MMM}", "MMMMd": "{d} {MMMM}",
+	},
+	"it": {
+		"yM": "{M}/{y}", "yMd": "{d}/{M}/{y}", "yMEd": "{E} {d}/{M}/{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d} {MMM} {y}", "yMMMEd": "{E} {d} {MMM} {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{d} {MMMM} {y}",
+		"Md": "{d}/{M}", "MEd": "{E} {d}/{M}", "MMMd": "{d} {MMM}", "MMMEd": "{E} {d} {MMM}", "MMMMd": "{d} {MMMM}",
+	},
+	"ja": {
+		"y": "{y}年", "yM": "{y}/{M}", "yMd": "{y}/{M}/{d}", "yMEd": "{y}/{M}/{d}({E})",
+		"yMMM": "{y}年{M}月", "yMMMd": "{y}年{M}月{d}日", "yMMMEd": "{y}年{M}月{d}日({E})",
+		"yMMMM": "{y}年{M}月", "yMMMMd": "{y}年{M}月{d}日",
+		"MMM": "{M}月", "MMMM": "{M}月", "d": "{d}日",
+		"Md": "{M}/{d}", "MEd": "{M}/{d}({E})", "MMMd": "{M}月{d}日", "MMMEd": "{M}月{d}日({E})", "MMMMd": "{M}月{d}日",
+	},
+	"nl": {
+		"yM": "{M}-{y}", "yMd": "{d}-{M}-{y}", "yMEd": "{E} {d}-{M}-{y}",
+		"yMMM": "{MMM} {y}", "yMMMd": "{d} {MMM} {y}", "yMMMEd": "{E} {d} {MMM} {y}",
+		"yMMMM": "{MMMM} {y}", "yMMMMd": "{d} {MMMM} {y}",
+		"Md": "{d}-{M}", "MEd": "{E} {d}-{M}", "MMMd": "{d} {MMM}", "MMMEd": "{E} {d} {MMM}", "MMMMd": "{d} {MMMM}",
+	},
+	"pt": {
+		"yM": "{MM}/{y}", "yMd": "{dd}/{MM}/{y}", "yMEd": "{E}, {dd}/{MM}/{y}",
+		"yMMM": "{MMM} de {y}", "yMMMd": "{d} de {MMM} de {y}", "yMMMEd": "{E}, {d} de {MMM} de {y}",
+		"yMMMM": "{MMMM} de {y}", "yMMMMd": "{d} de {MMMM} de {y}",
+		"Md": "{d}/{M}", "MEd": "{E}, {dd}/{MM}", "MMMd": "{d} de {MMM}", "MMMEd": "{E}, {d} de {MMM}", "MMMMd": "{d} de {MMMM}",
+	},
+	"zh": {
+		"y": "{y}年", "yM": "{y}/{M}", "yMd": "{y}/{M}/{d}", "yMEd": "{y}/{M}/{d}{E}",
+		"yMMM": "{y}年{M}月", "yMMMd": "{y}年{M}月{d}日", "yMMMEd": "{y}年{M}月{d}日{E}",
+		"yMMMM": "{y}年{M}月", "yMMMMd": "{y}年{M}月{d}日",
+		"MMM": "{M}月", "MMMM": "{M}月", "d": "{d}日",
+		"Md": "{M}/{d}", "MEd": "{M}/{d}{E}", "MMMd": "{M}月{d}日", "MMMEd": "{M}月{d}日{E}", "MMMMd": "{M}月{d}日",
+	},
+}
+
+// FormatSkeleton formats t in the language following a CLDR skeleton listing the fields to
+// include regardless of their order, e.g. "yMMMd" for "Mar 4, 2021" or "Hm" for "15:04".
+// Supported fields are y, M, d and E of dates and H, h and j, m, s and z of times, j denoting
+// the preferred hour cycle of the language. Dates are formatted in the gregorian calendar.
+func FormatSkeleton(lang Language, t time.Time, skeleton string) (string, error) {
+	pattern, err := skeletonPattern(lang, skeleton, PreferredHourCycle(lang))
+	if err != nil {
+		return "", err
+	}
+	return renderDateTime(lang, Gregorian, t, pattern), nil
+}
+
+// skeletonPattern returns the pattern of the skeleton, j denoting the hour cycle
+func skeletonPattern(lang Language, skeleton string, cycle HourCycle) (string, error) {
+	widths := make(map[byte]int)
+	for i := 0; i < len(skeleton); i++ {
+		field := skeleton[i]
+		switch field {
+		case 'L':
+			field = 'M'
+		case 'y', 'M', 'd', 'E', 'H', 'h', 'j', 'm', 's', 'z':
+		default:
+			return "", fmt.Errorf("unsupported field %q of skeleton %q", skeleton[i], skeleton)
+		}
+		widths[field]++
+	}
+
+	var date string
+	if widths['y'] > 0 {
+		date += "y"
+	}
+	switch n := widths['M']; {
+	case n >= 4:
+		date += "MMMM"
+	case n == 3:
+		date += "MMM"
+	case n > 0:
+		date += "M"
+	}
+	if widths['E'] > 0 {
+		date += "E"
+	}
+	if widths['d'] > 0 {
+		date += "d"
+	}
+
+	var datePattern string
+	if date != "" {
+		var ok bool
+		replacements := []string{}
+		if datePattern, ok = dateSkeletonPattern(lang, date); !ok && widths['M'] >= 4 {
+			// widen the abbreviated month of patterns lacking the wide month
+			datePattern, ok = dateSkeletonPattern(lang, strings.Replace(date, "MMMM", "MMM", 1))
+			replacements = append(replacements, "{MMM}", "{MMMM}")
+		}
+		if !ok {
+			return "", fmt.Errorf("unsupported skeleton %q", skeleton)
+		}
+		if widths['y'] == 2 {
+			replacements = append(replacements, "{y}", "{yy}")
+		}
+		if widths['M'] == 2 {
+			replacements = append(replacements, "{M}", "{MM}")
+		}
+		if widths['d'] == 2 {
+			replacements = append(replacements, "{d}", "{dd}")
+		}
+		if widths['E'] >= 4 {
+			replacements = append(replacements, "{E}", "{EEEE}")
+		}
+		datePattern = strings.NewReplacer(replacements...).Replace(datePattern)
+	}
+
+	hours := widths['H'] + widths['h'] + widths['j']
+	if hours == 0 && widths['m']+widths['s'] > 0 || widths['s'] > 0 && widths['m'] == 0 {
+		return "", fmt.Errorf("unsupported skeleton %q", skeleton)
+	}
+
+	var timePattern string
+	if hours > 0 {
+		timePattern = "{HH}"
+		if widths['m'] > 0 {
+			timePattern += ":{mm}"
+		}
+		if widths['s'] > 0 {
+			timePattern += ":{ss}"
+		}
+
+		switch {
+		case widths['H'] > 0:
+			cycle = Hour24
+		case widths['h'] > 0:
+			cycle = Hour12
+		}
+		timePattern = convertHourCycle(lang, timePattern, cycle)
+	}
+	if widths['z'] > 0 {
+		timePattern = strings.TrimPrefix(timePattern+" {z}", " ")
+	}
+
+	switch {
+	case datePattern == "":
+		return timePattern, nil
+	case timePattern == "":
+		return datePattern, nil
+	}
+	return strings.NewReplacer("{date}", datePattern, "{time}", timePattern).Replace(dateTimePatternsOf(lang).dateTime[1]), nil
+}
+
+// dateSkeletonPattern returns the pattern of the canonical date skeleton, languages lacking
+// patterns following English
+func dateSkeletonPattern(lang Language, skeleton string) (string, bool) {
+	patterns := skeletonsByLanguage["en"]
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if listed, ok := skeletonsByLanguage[candidate]; ok {
+			patterns = listed
+			break
+		}
+	}
+
+	if pattern, ok := patterns[skeleton]; ok {
+		return pattern, true
+	}
+	pattern, ok := commonSkeletons[skeleton]
+	return pattern, ok
+}
+
+// numberSkeleton selects how numbers are formatted following a subset of ICU number skeletons
+type numberSkeleton struct {
+	// minFraction and maxFraction are the numbers of fraction digits,
+	// maxFraction being -1 for as many digits as required
+	minFraction int
+	maxFraction int
+	grouping    bool
+	percent     bool
+	signAlways  bool
+}
+
+// percentSigns are the percent signs of languages separating them from the number
+var percentSigns = map[Language]string{
+	"de": noBreakSpace + "%",
+	"es": noBreakSpace + "%",
+	"fr": narrowNoBreakSpace + "%",
+	"nb": noBreakSpace + "%",
+	"sv": noBreakSpace + "%",
+}
+
+// parseNumberSkeleton parses the space separated tokens of a number skeleton
+func parseNumberSkeleton(skeleton string) (numberSkeleton, error) {
+	parsed := numberSkeleton{maxFraction: -1, grouping: true}
+	for _, token := range strings.Fields(skeleton) {
+		switch {
+		case token == "precision-integer":
+			parsed.minFraction, parsed.maxFraction = 0, 0
+		case token == "group-off":
+			parsed.grouping = false
+		case token == "percent", token == "%":
+			parsed.percent = true
+		case token == "sign-always", token == "+!":
+			parsed.signAlways = true
+		case strings.HasPrefix(token, ".") && strings.Trim(token[1:], "0#") == "" && !strings.Contains(strings.TrimLeft(token[1:], "0"), "0"):
+			digits := token[1:]
+			parsed.minFraction = len(digits) - len(strings.TrimLeft(digits, "0"))
+			parsed.maxFraction = len(digits)
+		default:
+			return numberSkeleton{}, fmt.Errorf("unsupported token %q of number skeleton %q", token, skeleton)
+		}
+	}
+	return parsed, nil
+}
+
+// FormatNumber formats the number in the notation of the language following an ICU number
+// skeleton of space separated tokens, e.g. ".00" for exactly two fraction digits, ".0#" for
+// one or two, "precision-integer" for none, "group-off" to not group digits, "percent" to
+// format the number as percentage and "sign-always" to sign positive numbers. An empty
+// skeleton formats the number with as many fraction digits as required.
+func FormatNumber(lang Language, number float64, skeleton string) (string, error) {
+	parsed, err := parseNumberSkeleton(skeleton)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(number) || math.IsInf(number, 0) {
+		return "", fmt.Errorf("number %v can not be formatted", number)
+	}
+
+	if parsed.percent {
+		number *= 100
+	}
+	formatted := strconv.FormatFloat(number, 'f', parsed.maxFraction, 64)
+	if i := strings.IndexByte(formatted, '.'); i != -1 {
+		// drop optional trailing zeros
+		minimum := i + 1 + parsed.minFraction
+		for len(formatted) > minimum && formatted[len(formatted)-1] == '0' {
+			formatted = formatted[:len(formatted)-1]
+		}
+		formatted = strings.TrimSuffix(formatted, ".")
+	}
+	if formatted == "-0" {
+		formatted = "0"
+	}
+
+	if parsed.grouping {
+		formatted = localizeNumber(lang, formatted)
+	} else {
+		formatted = strings.Replace(formatted, ".", numberSymbolsOf(lang).decimal, 1)
+	}
+	if parsed.signAlways && !strings.HasPrefix(formatted, "-") {
+		formatted = "+" + formatted
+	}
+	if parsed.percent {
+		sign, ok := percentSigns[lang.Base()]
+		if !ok {
+			sign = "%"
+		}
+		formatted += sign
+	}
+	return formatted, nil
+}
+
+// formatNumber formats numeric parameter values following the skeleton option
+func formatNumber(lang Language, value interface{}, options formatOptions, params intermediateLookup) (string, error) {
+	number, err := numberOf(value)
+	if err != nil {
+		return "", err
+	}
+	return FormatNumber(lang, number, options["skeleton"])
+}
+
+// numberOf converts numeric parameter values and numeric strings into a float
+func numberOf(value interface{}) (float64, error) {
+	v := reflect.ValueOf(value)
+	switch {
+	case !v.IsValid():
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return float64(v.Int()), nil
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return float64(v.Uint()), nil
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float(), nil
+	case v.Kind() == reflect.String:
+		number, err := strconv.ParseFloat(v.String(), 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected number, got %q", v.String())
+		}
+		return number, nil
+	}
+	return 0, fmt.Errorf("expected number, got %T", value)
+}
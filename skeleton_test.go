@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFormatSkeleton(t *testing.T) {
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	fn := func(lang Language, skeleton string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := FormatSkeleton(lang, ts, skeleton)
+			if err != nil || got != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("english yMMMd", fn("en", "yMMMd", "Mar 4, 2021"))
+	t.Run("german yMMMd", fn("de", "yMMMd", "4. März 2021"))
+	t.Run("field order", fn("en", "dMMMy", "Mar 4, 2021"))
+	t.Run("weekday", fn("fr", "yMMMEd", "jeu. 4 mars 2021"))
+	t.Run("wide weekday", fn("en", "MMMMEEEEd", "Thursday, March 4"))
+	t.Run("padded numbers", fn("de", "yMMdd", "04.03.2021"))
+	t.Run("two digit year", fn("en", "yyMd", "3/4/21"))
+	t.Run("year", fn("ja", "y", "2021年"))
+	t.Run("fallback", fn("xx", "yMMMd", "Mar 4, 2021"))
+	t.Run("24 hours", fn("en", "Hm", "15:04"))
+	t.Run("12 hours", fn("de", "hm", "3:04 PM"))
+	t.Run("preferred hours", fn("en", "jms", "3:04:05 PM"))
+	t.Run("zone", fn("de", "Hmz", "15:04 CET"))
+	t.Run("date and time", fn("en", "yMdjm", "3/4/2021, 3:04 PM"))
+
+	for _, skeleton := range []string{"yQQQ", "ms", "Hs", "yE"} {
+		if got, err := FormatSkeleton("en", ts, skeleton); err == nil {
+			t.Fatalf("expected error for skeleton %q, got %q", skeleton, got)
+		}
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	fn := func(lang Language, number float64, skeleton string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := FormatNumber(lang, number, skeleton)
+			if err != nil || got != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("default", fn("en", 1234.5, "", "1,234.5"))
+	t.Run("german", fn("de", 1234.5, "", "1.234,5"))
+	t.Run("exact fraction", fn("en", 1234.5, ".00", "1,234.50"))
+	t.Run("optional fraction", fn("en", 1234.5, ".0#", "1,234.5"))
+	t.Run("rounded fraction", fn("en", 1.005, ".##", "1"))
+	t.Run("integer", fn("de", 1234.5, "precision-integer", "1.234"))
+	t.Run("group off", fn("de", 1234.5, "group-off .00", "1234,50"))
+	t.Run("percent", fn("en", 0.256, "percent .0", "25.6%"))
+	t.Run("german percent", fn("de", 0.25, "percent", "25\u00a0%"))
+	t.Run("sign always", fn("en", 3, "sign-always", "+3"))
+	t.Run("negative zero", fn("en", -0.001, "precision-integer", "0"))
+
+	if got, err := FormatNumber("en", 1, "scientific"); err == nil {
+		t.Fatalf("expected error for unsupported token, got %q", got)
+	}
+}
+
+func TestSkeletonFormats(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"date": "Due {{ts, date(skeleton=yMMMEd)}}",
+			"time": "At {{ts, time(skeleton=jm; hours=24)}}",
+			"price": "Costs {{amount, number(skeleton=.00)}}",
+			"share": "Share {{ratio, number(skeleton=percent)}}"
+		}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2021, time.March, 4, 15, 4, 5, 0, time.UTC)
+
+	fn := func(key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("en")(key, params...)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("date", fn("date", "Due Thu, Mar 4, 2021", "ts", ts))
+	t.Run("time", fn("time", "At 15:04", "ts", ts))
+	t.Run("price", fn("price", "Costs 1,234.50", "amount", 1234.5))
+	t.Run("integer price", fn("price", "Costs 12.00", "amount", 12))
+	t.Run("share", fn("share", "Share 50%", "ratio", 0.5))
+
+	if _, err := translations.GenerateTranslate("en")("price", "amount", "cheap"); err == nil {
+		t.Fatal("expected error for value not being a number")
+	}
+}
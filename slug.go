@@ -0,0 +1,75 @@
+package i18n
+
+import "strings"
+
+// transliterations map letters of non-latin scripts and latin letters lacking a decomposition
+// to ASCII. Cyrillic follows the common romanization of Russian, Greek the ELOT 743 standard.
+var transliterations = map[rune]string{
+	'ð': "d", 'þ': "th", 'ŋ': "ng",
+
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'є': "ye", 'і': "i", 'ї': "yi", 'ґ': "g", 'ђ': "dj", 'ј': "j", 'љ': "lj",
+	'њ': "nj", 'ћ': "c", 'џ': "dz", 'ў': "w",
+
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th",
+	'ι': "i", 'κ': "k", 'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p",
+	'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t", 'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps",
+	'ω': "o",
+}
+
+// languageTransliterations are the transliterations deviating from the defaults per language
+var languageTransliterations = map[Language]map[rune]string{
+	"bg": {'ж': "zh", 'х': "h", 'ц': "ts", 'щ': "sht", 'ъ': "a", 'ю': "yu", 'я': "ya"},
+	"da": {'æ': "ae", 'ø': "oe", 'å': "aa"},
+	"de": {'ä': "ae", 'ö': "oe", 'ü': "ue"},
+	"nb": {'æ': "ae", 'ø': "oe", 'å': "aa"},
+	"nn": {'æ': "ae", 'ø': "oe", 'å': "aa"},
+	"sr": {'х': "h", 'ц': "c", 'ч': "c", 'ш': "s", 'ж': "z"},
+	"uk": {'г': "h", 'и': "y", 'х': "kh", 'щ': "shch"},
+}
+
+// Slug converts s into an ASCII slug for URLs following the transliteration rules of
+// the language, e.g. "Grüße aus Köln" into "gruesse-aus-koeln" in German. Diacritics are
+// removed, cyrillic and greek letters are transliterated and all other characters are
+// replaced by dashes. Letters lacking a transliteration, e.g. CJK, are omitted.
+func Slug(lang Language, s string) string {
+	s = Lower(lang, s)
+	overrides := languageTransliterations[lang.Base()]
+
+	var b strings.Builder
+	b.Grow(len(s))
+	dash := false
+	write := func(ascii string) {
+		if ascii == "" {
+			return
+		}
+		if dash && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		b.WriteString(ascii)
+		dash = false
+	}
+
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			write(string(r))
+		case overrides[r] != "":
+			write(overrides[r])
+		case transliterations[r] != "" || r == 'ъ' || r == 'ь':
+			write(transliterations[r])
+		case expansions[r] != "":
+			write(Slug(lang, expansions[r]))
+		case folded[r].base != 0:
+			write(Slug(lang, string(folded[r].base)))
+		case r == '\'' || r == '’':
+			// apostrophes do not separate words, e.g. "l'été"
+		default:
+			dash = true
+		}
+	}
+	return b.String()
+}
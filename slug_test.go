@@ -0,0 +1,28 @@
+package i18n
+
+import "testing"
+
+func TestSlug(t *testing.T) {
+	fn := func(lang Language, s string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Slug(lang, s); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("ascii", fn("en", "Hello, World!", "hello-world"))
+	t.Run("german", fn("de", "Grüße aus Köln", "gruesse-aus-koeln"))
+	t.Run("german umlauts elsewhere", fn("fi", "Hyvää päivää", "hyvaa-paivaa"))
+	t.Run("french", fn("fr", "L'été à Paris", "lete-a-paris"))
+	t.Run("danish", fn("da", "Blåbærgrød", "blaabaergroed"))
+	t.Run("polish", fn("pl", "Łódź", "lodz"))
+	t.Run("turkish", fn("tr", "İSTANBUL Şehri", "istanbul-sehri"))
+	t.Run("russian", fn("ru", "Привет, мир", "privet-mir"))
+	t.Run("ukrainian", fn("uk", "Київ", "kyyiv"))
+	t.Run("hard sign", fn("ru", "объект", "obekt"))
+	t.Run("greek", fn("el", "Καλημέρα κόσμε", "kalimera-kosme"))
+	t.Run("greek final sigma", fn("el", "ΟΔΥΣΣΕΥΣ", "odysseys"))
+	t.Run("cjk omitted", fn("ja", "東京 2020", "2020"))
+	t.Run("trimmed", fn("en", "  --a  b--  ", "a-b"))
+}
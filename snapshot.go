@@ -0,0 +1,54 @@
+package i18n
+
+// Snapshot is a read-only view of all translation stores at a point in time.
+// It does not share any state with the Translations it was taken from, hence it
+// stays valid and consistent while the translations are loaded anew.
+type Snapshot struct {
+	defaultLanguage Language
+	translations    map[Language]Store
+}
+
+// Snapshot returns a copy of all currently loaded translations
+func (trl Translations) Snapshot() Snapshot {
+	translations := make(map[Language]Store, len(trl.translations))
+	for lang, store := range trl.translations {
+		copied := make(Store, len(store))
+		for key, translation := range store {
+			translation.Intermediates = append([]Intermediate(nil), translation.Intermediates...)
+			copied[key] = translation
+		}
+		translations[lang] = copied
+	}
+
+	return Snapshot{
+		defaultLanguage: trl.defaultLanguage,
+		translations:    translations,
+	}
+}
+
+// DefaultLanguage returns the default language at the time of the snapshot
+func (s Snapshot) DefaultLanguage() Language {
+	return s.defaultLanguage
+}
+
+// Languages returns the sorted list of languages contained in the snapshot
+func (s Snapshot) Languages() []Language {
+	return sortedLanguages(s.translations)
+}
+
+// Keys returns the sorted list of keys available in the given language
+func (s Snapshot) Keys(lang Language) []Key {
+	return sortedKeys(s.translations[lang])
+}
+
+// Lookup returns the translation of key in the given language.
+// The returned translation may be modified without affecting the snapshot.
+func (s Snapshot) Lookup(lang Language, key Key) (Translation, bool) {
+	translation, ok := s.translations[lang][key]
+	if !ok {
+		return Translation{}, false
+	}
+
+	translation.Intermediates = append([]Intermediate(nil), translation.Intermediates...)
+	return translation, true
+}
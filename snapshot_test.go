@@ -0,0 +1,40 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSnapshot(t *testing.T) {
+	translations, err := NewTranslations(Count+"first", "en").Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	snapshot := translations.Snapshot()
+
+	if languages := snapshot.Languages(); !reflect.DeepEqual(languages, []Language{"en"}) {
+		t.Fatalf("expected only english, got %v", languages)
+	}
+	if l := len(snapshot.Keys("en")); l != 9 {
+		t.Fatalf("expected 9 keys, got %d", l)
+	}
+
+	// modifications of the source must not be visible within the snapshot
+	translations.translations["en"]["whoami"] = Translation{Message: "changed"}
+	delete(translations.translations["en"], "expired")
+
+	translation, ok := snapshot.Lookup("en", "whoami")
+	if !ok || translation.Message != "you are {{whoami}}" {
+		t.Fatalf("snapshot was modified: %v", translation)
+	}
+	if _, ok := snapshot.Lookup("en", "expired"); !ok {
+		t.Fatal("snapshot lost key")
+	}
+
+	// modifications of looked up translations must not be visible either
+	translation.Intermediates[0] = "changed"
+	if translation, _ := snapshot.Lookup("en", "whoami"); translation.Intermediates[0] != "whoami" {
+		t.Fatalf("snapshot was modified: %v", translation)
+	}
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import "unsafe"
+
+// Stats summarizes the loaded translations per language
+type Stats struct {
+	Languages map[Language]LanguageStats
+	// HotKeys are the keys translated most often if counted, see WithKeyStats
+	HotKeys []KeyCount
+}
+
+// LanguageStats summarizes the translations of a single language
+type LanguageStats struct {
+	// Keys is the number of translated keys
+	Keys int
+	// MessageBytes is the total length of all messages in bytes
+	MessageBytes int
+	// Intermediates is the total number of intermediates in all messages
+	Intermediates int
+	// MemoryBytes approximates the memory retained by the store of the language.
+	// Keys and messages shared with other languages are accounted in each language.
+	MemoryBytes int
+}
+
+const (
+	// entryOverhead approximates the memory of a store entry without
+	// its strings and slices, accounting also for the map bookkeeping
+	entryOverhead = int(unsafe.Sizeof(Key(""))+unsafe.Sizeof(Translation{})) + 8
+	// intermediateOverhead is the memory of an intermediate without its name
+	intermediateOverhead = int(unsafe.Sizeof(Intermediate("")))
+	// segmentOverhead is the memory of a segment without its text
+	segmentOverhead = int(unsafe.Sizeof(segment{}))
+)
+
+// Stats returns statistics about the loaded translations, enabling to track the catalog growth
+func (trl Translations) Stats() Stats {
+	stats := Stats{
+		Languages: make(map[Language]LanguageStats, len(trl.translations)),
+	}
+
+	for lang, store := range trl.translations {
+		var s LanguageStats
+		for key, translation := range store {
+			s.Keys++
+			s.MessageBytes += len(translation.Message)
+			s.Intermediates += len(translation.Intermediates)
+
+			s.MemoryBytes += entryOverhead + len(key) + len(translation.Message)
+			for _, intermediate := range translation.Intermediates {
+				s.MemoryBytes += intermediateOverhead + len(intermediate)
+			}
+			// literals of segments refer to the message and do not retain memory on their own
+			s.MemoryBytes += segmentOverhead * len(translation.segments)
+		}
+		stats.Languages[lang] = s
+	}
+
+	stats.HotKeys = trl.HotKeys(statsHotKeys)
+	return stats
+}
+
+// Total sums up the statistics of all languages
+func (s Stats) Total() LanguageStats {
+	var total LanguageStats
+	for _, l := range s.Languages {
+		total.Keys += l.Keys
+		total.MessageBytes += l.MessageBytes
+		total.Intermediates += l.Intermediates
+		total.MemoryBytes += l.MemoryBytes
+	}
+	return total
+}
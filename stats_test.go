@@ -0,0 +1,37 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestStats(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello", "b": {"c": "{{x}} and {{y}}"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats := translations.Stats()
+	en := stats.Languages["en"]
+	if en.Keys != 2 || en.MessageBytes != 20 || en.Intermediates != 2 {
+		t.Fatalf("unexpected stats for en: %+v", en)
+	}
+
+	de := stats.Languages["de"]
+	if de.Keys != 1 || de.MessageBytes != 5 || de.Intermediates != 0 {
+		t.Fatalf("unexpected stats for de: %+v", de)
+	}
+	if de.MemoryBytes <= de.MessageBytes || en.MemoryBytes <= de.MemoryBytes {
+		t.Fatalf("implausible memory usage: en %d, de %d", en.MemoryBytes, de.MemoryBytes)
+	}
+
+	total := stats.Total()
+	if total.Keys != 3 || total.MemoryBytes != en.MemoryBytes+de.MemoryBytes {
+		t.Fatalf("unexpected total: %+v", total)
+	}
+}
@@ -0,0 +1,43 @@
+package i18n
+
+// SubstituteParam is the name of the parameter overriding the substitute set by WithSubstitute
+// for a single translation, e.g. translate("greeting", "name", nil, SubstituteParam, SubstituteText("-"))
+const SubstituteParam = "$substitute"
+
+// Substitute selects what nil parameters and intermediates without parameter render as.
+// By default nil parameters render as "<nil>" and missing parameters fail the translation.
+type Substitute struct {
+	text        string
+	placeholder bool
+	set         bool
+}
+
+// SubstituteText renders nil and missing parameters as the text, e.g. "" or "-"
+func SubstituteText(text string) Substitute {
+	return Substitute{text: text, set: true}
+}
+
+// SubstitutePlaceholder renders nil and missing parameters as the placeholder of their
+// intermediate, e.g. "{{name}}", such that untranslated parameters remain visible
+func SubstitutePlaceholder() Substitute {
+	return Substitute{placeholder: true, set: true}
+}
+
+// render returns the substitute of the intermediate
+func (s Substitute) render(intermediate Intermediate) string {
+	if s.placeholder {
+		return Prefix + string(intermediate) + Suffix
+	}
+	return s.text
+}
+
+// substitute returns the substitute applying to the lookup, the parameter named by
+// SubstituteParam taking precedence over the substitute of the translations
+func (trl Translations) substitute(lookup intermediateLookup) Substitute {
+	if value, ok := lookup.get(SubstituteParam); ok {
+		if s, ok := value.(Substitute); ok {
+			return s
+		}
+	}
+	return trl.substitution
+}
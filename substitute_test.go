@@ -0,0 +1,42 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestSubstitute(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"greeting": "hi {{name}}", "typed": "{{count:int}} items", "formatted": "hi {{name, upper}}"}`)},
+	}
+
+	fn := func(options []Option, key string, params []interface{}, expected string, valid bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			translations, err := New(append(options, WithFS(fsys), WithDefaultLanguage("en"))...).Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := translations.GenerateTranslate("en")(key, params...)
+			if valid != (err == nil) {
+				t.Fatalf("expected valid %t, got %v", valid, err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	dash := []Option{WithSubstitute(SubstituteText("-"))}
+	t.Run("nil by default", fn(nil, "greeting", []interface{}{"name", nil}, "hi &lt;nil&gt;", true))
+	t.Run("missing by default", fn(nil, "greeting", nil, "", false))
+	t.Run("nil", fn(dash, "greeting", []interface{}{"name", nil}, "hi -", true))
+	t.Run("missing", fn(dash, "greeting", []interface{}{"other", "Bob"}, "hi -", true))
+	t.Run("typed", fn(dash, "typed", []interface{}{"count", nil}, "- items", true))
+	t.Run("formatted", fn(dash, "formatted", []interface{}{"name", nil}, "hi -", true))
+	t.Run("given", fn(dash, "greeting", []interface{}{"name", "Bob"}, "hi Bob", true))
+	t.Run("empty", fn([]Option{WithSubstitute(SubstituteText(""))}, "greeting", []interface{}{"name", nil}, "hi ", true))
+	t.Run("placeholder", fn([]Option{WithSubstitute(SubstitutePlaceholder())}, "formatted", []interface{}{"name", nil}, "hi {{name}}", true))
+	t.Run("per call", fn(dash, "greeting", []interface{}{"name", nil, SubstituteParam, SubstitutePlaceholder()}, "hi {{name}}", true))
+	t.Run("per call without option", fn(nil, "greeting", []interface{}{SubstituteParam, SubstituteText("?")}, "hi ?", true))
+}
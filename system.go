@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"os"
+	"strings"
+)
+
+// localeVariables are the environment variables denoting the locale of messages
+// in order of precedence. LANGUAGE may list multiple languages separated by colons.
+var localeVariables = []string{"LANGUAGE", "LC_ALL", "LC_MESSAGES", "LANG"}
+
+// isPOSIXLocale reports whether the locale is the C or POSIX locale, e.g. "C.UTF-8"
+func isPOSIXLocale(locale string) bool {
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	return locale == "C" || locale == "POSIX"
+}
+
+// DetectSystemLanguage returns the loaded language best matching the locale of the operating
+// system, e.g. for command line applications. The locale environment variables are consulted
+// first, followed by the user locale on Windows. The default language is returned if no
+// loaded language matches.
+func (trl Translations) DetectSystemLanguage() Language {
+	return trl.detectLanguage(append(environmentLocales(os.Getenv), platformLocales()...))
+}
+
+// detectLanguage returns the loaded language matching the first of the locales possible
+func (trl Translations) detectLanguage(locales []string) Language {
+	for _, locale := range locales {
+		if lang, ok := trl.match(locale); ok {
+			return lang
+		}
+	}
+	return trl.defaultLanguage
+}
+
+// environmentLocales returns the languages of the locale environment variables
+// in order of precedence, e.g. "de_AT" for "de_AT.UTF-8@euro". As gettext does,
+// LANGUAGE is ignored if the locale of messages is the C or POSIX locale.
+func environmentLocales(getenv func(string) string) []string {
+	var locales []string
+	for _, variable := range localeVariables {
+		if variable == "LANGUAGE" && isPOSIXLocale(messagesLocale(getenv)) {
+			continue
+		}
+
+		for _, locale := range strings.Split(getenv(variable), ":") {
+			if i := strings.IndexAny(locale, ".@"); i >= 0 {
+				locale = locale[:i]
+			}
+
+			// the C and POSIX locales do not denote a language
+			if locale == "" || isPOSIXLocale(locale) {
+				continue
+			}
+			locales = append(locales, locale)
+		}
+	}
+	return locales
+}
+
+// messagesLocale returns the effective locale of messages, the first of LC_ALL,
+// LC_MESSAGES and LANG set
+func messagesLocale(getenv func(string) string) string {
+	for _, variable := range localeVariables[1:] {
+		if locale := getenv(variable); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+// +build !windows
+
+package i18n
+
+// platformLocales returns no locales since the environment variables denote the locale
+func platformLocales() []string {
+	return nil
+}
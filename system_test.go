@@ -0,0 +1,55 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEnvironmentLocales(t *testing.T) {
+	fn := func(env map[string]string, expected []string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got := environmentLocales(func(variable string) string { return env[variable] })
+			if !reflect.DeepEqual(got, expected) {
+				t.Fatalf("expected %v, got %v", expected, got)
+			}
+		}
+	}
+
+	t.Run("lang", fn(map[string]string{"LANG": "de_AT.UTF-8"}, []string{"de_AT"}))
+	t.Run("modifier", fn(map[string]string{"LANG": "de_DE@euro"}, []string{"de_DE"}))
+	t.Run("precedence", fn(map[string]string{"LANG": "en_US.UTF-8", "LC_ALL": "fr_FR.UTF-8"}, []string{"fr_FR", "en_US"}))
+	t.Run("language list", fn(map[string]string{"LANGUAGE": "gl:es"}, []string{"gl", "es"}))
+	t.Run("posix", fn(map[string]string{"LC_ALL": "C", "LANG": "POSIX"}, nil))
+	t.Run("language ignored for posix", fn(map[string]string{"LANGUAGE": "gl:es", "LC_ALL": "C"}, nil))
+	t.Run("language ignored for c utf-8", fn(map[string]string{"LANGUAGE": "gl", "LANG": "C.UTF-8"}, nil))
+	t.Run("language with locale", fn(map[string]string{"LANGUAGE": "gl", "LANG": "es_ES.UTF-8"}, []string{"gl", "es_ES"}))
+	t.Run("unset", fn(map[string]string{}, nil))
+}
+
+func TestDetectSystemLanguage(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(env map[string]string, expected Language) func(t *testing.T) {
+		return func(t *testing.T) {
+			locales := environmentLocales(func(variable string) string { return env[variable] })
+			if got := translations.detectLanguage(locales); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("exact", fn(map[string]string{"LANG": "de.UTF-8"}, "de"))
+	t.Run("region", fn(map[string]string{"LANG": "de_AT.UTF-8"}, "de"))
+	t.Run("first loaded", fn(map[string]string{"LANGUAGE": "fr:de", "LANG": "fr_FR.UTF-8"}, "de"))
+	t.Run("unloaded", fn(map[string]string{"LANG": "fr_FR.UTF-8"}, "en"))
+	t.Run("posix", fn(map[string]string{"LANG": "C"}, "en"))
+}
@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package i18n
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// localeNameMaxLength is the maximum length of a locale name including the terminating null character
+const localeNameMaxLength = 85
+
+var getUserDefaultLocaleName = syscall.NewLazyDLL("kernel32.dll").NewProc("GetUserDefaultLocaleName")
+
+// platformLocales returns the user locale, e.g. "de-AT"
+func platformLocales() []string {
+	if getUserDefaultLocaleName.Find() != nil {
+		return nil
+	}
+
+	buf := make([]uint16, localeNameMaxLength)
+	n, _, _ := getUserDefaultLocaleName.Call(uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		return nil
+	}
+	return []string{syscall.UTF16ToString(buf)}
+}
@@ -0,0 +1,98 @@
+package i18n
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// loadTenants loads the overrides of each tenant from the files below the directory of the
+// tenant within the tenant directory, e.g. "tenants/acme/de.json", composing them with the
+// base translations
+func (l *loader) loadTenants() {
+	dir := path.Clean(l.trl.tenantDirectory)
+	entries, err := fs.ReadDir(l.fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		l.report(dir, "", "", err.Error())
+		return
+	}
+
+	l.tenants = make(map[string]map[Language]Store)
+	l.tenantRoutes = make(map[string]map[Language]routeTable)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		tenant := entry.Name()
+		tl := newLoader(l.trl)
+		tl.fsys = l.fsys
+		tl.interned = l.interned
+		tl.checksums = l.checksums
+		if err := tl.walk(path.Join(dir, tenant)); err != nil {
+			l.report(path.Join(dir, tenant), "", "", err.Error())
+			continue
+		}
+		tl.resolveConstants(l.constants)
+		l.issues = append(l.issues, tl.issues...)
+
+		composed := composeTenant(l.translations, tl.translations)
+		l.tenants[tenant] = composed
+		l.tenantRoutes[tenant] = l.checkRoutes(composed, func(Language, Key) string { return path.Join(dir, tenant) })
+	}
+}
+
+// composeTenant overlays the base stores with the overrides of a tenant. The overrides of a
+// language apply to its regional languages as well, taking precedence over the base
+// translations of the regional language, e.g. the overrides of "de" apply to "de-at".
+func composeTenant(base map[Language]Store, overrides map[Language]Store) map[Language]Store {
+	languages := make(map[Language]Store, len(base)+len(overrides))
+	for lang := range base {
+		languages[lang] = nil
+	}
+	for lang := range overrides {
+		languages[lang] = nil
+	}
+
+	composed := make(map[Language]Store, len(languages))
+	for lang := range languages {
+		store := make(Store, len(base[lang]))
+		for key, translation := range base[lang] {
+			store[key] = translation
+		}
+
+		// least specific overrides first, such that more specific ones take precedence
+		candidates := languageCandidates(lang)
+		for i := len(candidates) - 1; i >= 0; i-- {
+			for key, translation := range overrides[candidates[i]] {
+				store[key] = translation
+			}
+		}
+		composed[lang] = store
+	}
+	return composed
+}
+
+// ForTenant returns the translations of the tenant, consisting of the base translations
+// overlaid with the overrides of the tenant loaded from the tenant directory. The base
+// translations are returned for tenants without overrides.
+func (trl Translations) ForTenant(tenant string) Translations {
+	if t, ok := trl.tenants[tenant]; ok {
+		return t
+	}
+	return trl
+}
+
+// Tenants returns the sorted names of the tenants with overrides
+func (trl Translations) Tenants() []string {
+	tenants := make([]string, 0, len(trl.tenants))
+	for tenant := range trl.tenants {
+		tenants = append(tenants, tenant)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestTenants(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":                 &fstest.MapFile{Data: []byte(`{"customer": "Customer", "order": "Order", "routes": {"pricing": "pricing"}}`)},
+		"de.json":                 &fstest.MapFile{Data: []byte(`{"customer": "Kunde", "order": "Bestellung"}`)},
+		"de-at.json":              &fstest.MapFile{Data: []byte(`{"order": "Auftrag"}`)},
+		"tenants/acme/en.json":    &fstest.MapFile{Data: []byte(`{"customer": "Partner", "routes": {"pricing": "plans"}}`)},
+		"tenants/acme/de.json":    &fstest.MapFile{Data: []byte(`{"customer": "Partner", "order": "Buchung"}`)},
+		"tenants/initech/fr.json": &fstest.MapFile{Data: []byte(`{"customer": "Client"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithTenantDirectory("tenants"), WithCache(8)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tenants := translations.Tenants(); !reflect.DeepEqual(tenants, []string{"acme", "initech"}) {
+		t.Fatalf("unexpected tenants %v", tenants)
+	}
+
+	fn := func(trl Translations, lang string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := trl.GenerateTranslate(lang)(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	acme := translations.ForTenant("acme")
+	t.Run("base", fn(translations, "de", "customer", "Kunde"))
+	t.Run("base is unaffected", fn(translations, "en", "customer", "Customer"))
+	t.Run("override", fn(acme, "en", "customer", "Partner"))
+	t.Run("base of tenant", fn(acme, "en", "order", "Order"))
+	t.Run("override of regional language", fn(acme, "de-AT", "order", "Buchung"))
+	t.Run("tenant language", fn(translations.ForTenant("initech"), "fr", "customer", "Client"))
+	t.Run("tenant without overrides", fn(translations.ForTenant("unknown"), "en", "customer", "Customer"))
+
+	if got := acme.LocalizePath("en", "/pricing"); got != "/plans" {
+		t.Fatalf("unexpected tenant route %q", got)
+	}
+
+	if _, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load(); err == nil {
+		t.Fatal("expected tenant files to collide with the base translations without tenant directory")
+	}
+}
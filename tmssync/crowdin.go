@@ -0,0 +1,94 @@
+package tmssync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Crowdin is a provider of a file of a Crowdin project
+type Crowdin struct {
+	// BaseURL is the URL of the API, "https://api.crowdin.com/api/v2" if empty
+	BaseURL   string
+	Token     string
+	ProjectID int
+	// FileID is the source file of the project synchronized
+	FileID int
+
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+func (c Crowdin) client() client {
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.crowdin.com/api/v2"
+	}
+	return newClient(c.HTTPClient, baseURL, http.Header{"Authorization": {"Bearer " + c.Token}})
+}
+
+// crowdinResponse is the envelope of API responses
+type crowdinResponse struct {
+	Data struct {
+		ID  int    `json:"id"`
+		URL string `json:"url"`
+	} `json:"data"`
+}
+
+// request sends the body to the path, decoding the response envelope
+func (c Crowdin) request(ctx context.Context, method string, path string, contentType string, body []byte, header http.Header) (crowdinResponse, error) {
+	cl := c.client()
+	for name, values := range header {
+		cl.header[name] = values
+	}
+
+	var resp crowdinResponse
+	data, err := cl.do(ctx, method, path, contentType, bytes.NewReader(body))
+	if err != nil {
+		return resp, err
+	}
+	return resp, json.Unmarshal(data, &resp)
+}
+
+// filePath returns the path of the source file below the project
+func (c Crowdin) filePath() string {
+	return "/projects/" + strconv.Itoa(c.ProjectID) + "/files/" + strconv.Itoa(c.FileID)
+}
+
+// Upload replaces the content of the source file by the language file,
+// transferring it through the storage of Crowdin
+func (c Crowdin) Upload(ctx context.Context, lang string, file []byte) error {
+	storage, err := c.request(ctx, http.MethodPost, "/storages", "application/octet-stream", file,
+		http.Header{"Crowdin-Api-Filename": {url.PathEscape(lang + ".json")}})
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"storageId": storage.Data.ID})
+	if err != nil {
+		return err
+	}
+	_, err = c.request(ctx, http.MethodPut, c.filePath(), "application/json", body, nil)
+	return err
+}
+
+// Download returns the translations of the source file into the language
+func (c Crowdin) Download(ctx context.Context, lang string) ([]byte, error) {
+	body, err := json.Marshal(map[string]interface{}{"targetLanguageId": lang})
+	if err != nil {
+		return nil, err
+	}
+
+	build, err := c.request(ctx, http.MethodPost, "/projects/"+strconv.Itoa(c.ProjectID)+"/translations/builds/files/"+strconv.Itoa(c.FileID), "application/json", body, nil)
+	if err != nil {
+		return nil, err
+	}
+	if build.Data.URL == "" {
+		return nil, fmt.Errorf("no translations built for language %q", lang)
+	}
+	return c.client().do(ctx, http.MethodGet, build.Data.URL, "", nil)
+}
@@ -0,0 +1,74 @@
+package tmssync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxResponseSize limits the size of responses read from providers
+const maxResponseSize = 64 << 20
+
+// client performs authenticated requests against the API of a provider
+type client struct {
+	http    *http.Client
+	baseURL string
+	header  http.Header
+}
+
+// newClient creates a client sending the header with each request, using
+// http.DefaultClient if httpClient is nil
+func newClient(httpClient *http.Client, baseURL string, header http.Header) client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return client{http: httpClient, baseURL: baseURL, header: header}
+}
+
+// do sends the request to the path relative to the base URL, returning the response body
+// of successful requests. Absolute URLs, e.g. of download links, are requested without the
+// authentication header, never leaking credentials to other hosts.
+func (c client) do(ctx context.Context, method string, path string, contentType string, body io.Reader) ([]byte, error) {
+	relative := len(path) > 0 && path[0] == '/'
+	url := path
+	if relative {
+		url = c.baseURL + path
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	if relative {
+		for name, values := range c.header {
+			req.Header[name] = values
+		}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("%s %s failed with status %d: %s", method, path, resp.StatusCode, truncate(data, 200))
+	}
+	return data, nil
+}
+
+// truncate returns at most n bytes of data for error messages
+func truncate(data []byte, n int) string {
+	if len(data) > n {
+		return string(data[:n]) + "..."
+	}
+	return string(data)
+}
@@ -0,0 +1,106 @@
+package tmssync
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Lokalise is a provider of a Lokalise project
+type Lokalise struct {
+	// BaseURL is the URL of the API, "https://api.lokalise.com/api2" if empty
+	BaseURL   string
+	Token     string
+	ProjectID string
+
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+func (l Lokalise) client() client {
+	baseURL := l.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.lokalise.com/api2"
+	}
+	return newClient(l.HTTPClient, baseURL, http.Header{"X-Api-Token": {l.Token}})
+}
+
+// post sends the JSON encoded request to the path of the project, decoding the response into v
+func (l Lokalise) post(ctx context.Context, path string, request interface{}, v interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	data, err := l.client().do(ctx, http.MethodPost, "/projects/"+url.PathEscape(l.ProjectID)+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Upload replaces the source strings by the language file. Lokalise processes uploads
+// asynchronously, such that the strings may not be available immediately.
+func (l Lokalise) Upload(ctx context.Context, lang string, file []byte) error {
+	return l.post(ctx, "/files/upload", map[string]interface{}{
+		"data":             base64.StdEncoding.EncodeToString(file),
+		"filename":         lang + ".json",
+		"lang_iso":         lang,
+		"replace_modified": true,
+	}, nil)
+}
+
+// Download returns the translation file of the language, extracted from the bundle built by Lokalise
+func (l Lokalise) Download(ctx context.Context, lang string) ([]byte, error) {
+	var bundle struct {
+		BundleURL string `json:"bundle_url"`
+	}
+	err := l.post(ctx, "/files/download", map[string]interface{}{
+		"format":           "json",
+		"filter_langs":     []string{lang},
+		"bundle_structure": "%LANG_ISO%.json",
+	}, &bundle)
+	if err != nil {
+		return nil, err
+	}
+	if bundle.BundleURL == "" {
+		return nil, fmt.Errorf("no bundle built for language %q", lang)
+	}
+
+	archive, err := l.client().do(ctx, http.MethodGet, bundle.BundleURL, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	return extract(archive, lang+".json")
+}
+
+// extract returns the file of the zip archive
+func extract(archive []byte, name string) ([]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(io.LimitReader(rc, maxResponseSize))
+	}
+	return nil, fmt.Errorf("file %q not found in bundle", name)
+}
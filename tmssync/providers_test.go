@@ -0,0 +1,139 @@
+package tmssync
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWeblate(t *testing.T) {
+	var uploaded []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Token secret" || r.URL.Path != "/api/translations/web/app/"+map[string]string{http.MethodGet: "de", http.MethodPost: "en"}[r.Method]+"/file/" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+
+		if r.Method == http.MethodPost {
+			if r.FormValue("method") != "source" {
+				http.Error(w, "unexpected method", http.StatusBadRequest)
+				return
+			}
+			file, _, err := r.FormFile("file")
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			uploaded, _ = io.ReadAll(file)
+			return
+		}
+		w.Write([]byte(`{"a": "hallo"}`))
+	}))
+	defer server.Close()
+
+	provider := Weblate{BaseURL: server.URL + "/api", Token: "secret", Project: "web", Component: "app"}
+	if err := provider.Upload(context.Background(), "en", []byte(`{"a": "hello"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if string(uploaded) != `{"a": "hello"}` {
+		t.Fatalf("unexpected upload %q", uploaded)
+	}
+
+	file, err := provider.Download(context.Background(), "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(file) != `{"a": "hallo"}` {
+		t.Fatalf("unexpected download %q", file)
+	}
+}
+
+func TestLokalise(t *testing.T) {
+	var bundle bytes.Buffer
+	archive := zip.NewWriter(&bundle)
+	f, _ := archive.Create("de.json")
+	f.Write([]byte(`{"a": "hallo"}`))
+	archive.Close()
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bundle.zip":
+			if r.Header.Get("X-Api-Token") != "" {
+				http.Error(w, "token leaked", http.StatusBadRequest)
+				return
+			}
+			w.Write(bundle.Bytes())
+		case "/projects/p1/files/download":
+			if r.Header.Get("X-Api-Token") != "secret" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]string{"bundle_url": server.URL + "/bundle.zip"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := Lokalise{BaseURL: server.URL, Token: "secret", ProjectID: "p1"}
+	file, err := provider.Download(context.Background(), "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(file) != `{"a": "hallo"}` {
+		t.Fatalf("unexpected download %q", file)
+	}
+	if _, err := provider.Download(context.Background(), "fr"); err == nil {
+		t.Fatal("expected error for language missing in bundle")
+	}
+}
+
+func TestCrowdin(t *testing.T) {
+	var storageID interface{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/download/de.json" && r.Header.Get("Authorization") != "Bearer secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method + " " + r.URL.Path {
+		case "POST /storages":
+			w.Write([]byte(`{"data": {"id": 42}}`))
+		case "PUT /projects/1/files/2":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			storageID = body["storageId"]
+			w.Write([]byte(`{"data": {"id": 2}}`))
+		case "POST /projects/1/translations/builds/files/2":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]string{"url": server.URL + "/download/de.json"}})
+		case "GET /download/de.json":
+			w.Write([]byte(`{"a": "hallo"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	provider := Crowdin{BaseURL: server.URL, Token: "secret", ProjectID: 1, FileID: 2}
+	if err := provider.Upload(context.Background(), "en", []byte(`{"a": "hello"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if storageID != float64(42) {
+		t.Fatalf("unexpected storage %v", storageID)
+	}
+
+	file, err := provider.Download(context.Background(), "de")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(file) != `{"a": "hallo"}` {
+		t.Fatalf("unexpected download %q", file)
+	}
+}
@@ -0,0 +1,126 @@
+// Package tmssync synchronizes the language files of a catalog with translation
+// management systems such as Crowdin, Lokalise or Weblate. Source strings are pushed
+// to the platform and completed translations are pulled back into the catalog.
+// Language files are exchanged as i18next JSON as loaded by the i18n package.
+package tmssync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Provider transfers language files from and to a translation management system
+type Provider interface {
+	// Upload replaces the source strings of the project by the language file of the source language
+	Upload(ctx context.Context, lang string, file []byte) error
+	// Download returns the language file of the translations into the language
+	Download(ctx context.Context, lang string) ([]byte, error)
+}
+
+// Catalog reads and writes language files of the catalog
+type Catalog interface {
+	ReadFile(lang string) ([]byte, error)
+	WriteFile(lang string, file []byte) error
+}
+
+// Directory is a catalog of language files named by their language, e.g. "de.json"
+type Directory string
+
+// ReadFile reads the language file of lang
+func (dir Directory) ReadFile(lang string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(string(dir), lang+".json"))
+}
+
+// WriteFile replaces the language file of lang atomically, such that concurrent
+// loading never observes a partially written file. The mode of the replaced file
+// is retained, new files are created with mode 0644.
+func (dir Directory) WriteFile(lang string, file []byte) error {
+	name := filepath.Join(string(dir), lang+".json")
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(name); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	tmp, err := os.CreateTemp(string(dir), "."+lang+".json.*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(file); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Syncer synchronizes a catalog with a translation management system
+type Syncer struct {
+	Provider Provider
+	Catalog  Catalog
+
+	// SourceLanguage is the language the source strings are written in
+	SourceLanguage string
+	// Languages are the languages pulled from the provider
+	Languages []string
+}
+
+// Push uploads the language file of the source language
+func (s Syncer) Push(ctx context.Context) error {
+	file, err := s.Catalog.ReadFile(s.SourceLanguage)
+	if err != nil {
+		return fmt.Errorf("failed to read source language %q: %v", s.SourceLanguage, err)
+	}
+	if err := validate(file); err != nil {
+		return fmt.Errorf("invalid source language %q: %v", s.SourceLanguage, err)
+	}
+
+	if err := s.Provider.Upload(ctx, s.SourceLanguage, file); err != nil {
+		return fmt.Errorf("failed to push source language %q: %v", s.SourceLanguage, err)
+	}
+	return nil
+}
+
+// Pull downloads the language files of all languages, writing them into the catalog.
+// Languages are pulled in order, aborting on the first failure. Language files are
+// verified before being written, such that an invalid download never replaces a file.
+func (s Syncer) Pull(ctx context.Context) error {
+	for _, lang := range s.Languages {
+		file, err := s.Provider.Download(ctx, lang)
+		if err != nil {
+			return fmt.Errorf("failed to pull language %q: %v", lang, err)
+		}
+		if err := validate(file); err != nil {
+			return fmt.Errorf("invalid language %q pulled: %v", lang, err)
+		}
+
+		if err := s.Catalog.WriteFile(lang, file); err != nil {
+			return fmt.Errorf("failed to write language %q: %v", lang, err)
+		}
+	}
+	return nil
+}
+
+// validate verifies that the language file is a JSON object
+func validate(file []byte) error {
+	trimmed := bytes.TrimSpace(file)
+	if !json.Valid(trimmed) {
+		return errors.New("language file is no valid JSON")
+	}
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return errors.New("language file must be an object")
+	}
+	return nil
+}
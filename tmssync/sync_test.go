@@ -0,0 +1,105 @@
+package tmssync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type memoryProvider struct {
+	uploaded map[string][]byte
+	files    map[string][]byte
+}
+
+func (p *memoryProvider) Upload(ctx context.Context, lang string, file []byte) error {
+	p.uploaded[lang] = file
+	return nil
+}
+
+func (p *memoryProvider) Download(ctx context.Context, lang string) ([]byte, error) {
+	file, ok := p.files[lang]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return file, nil
+}
+
+func TestSyncer(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en.json"), []byte(`{"a": "hello"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &memoryProvider{
+		uploaded: make(map[string][]byte),
+		files: map[string][]byte{
+			"de": []byte(`{"a": "hallo"}`),
+			"fr": []byte(`["bonjour"]`),
+		},
+	}
+	syncer := Syncer{Provider: provider, Catalog: Directory(dir), SourceLanguage: "en", Languages: []string{"de"}}
+
+	if err := syncer.Push(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if string(provider.uploaded["en"]) != `{"a": "hello"}` {
+		t.Fatalf("unexpected upload %q", provider.uploaded["en"])
+	}
+
+	if err := syncer.Pull(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "de.json")); err != nil || string(data) != `{"a": "hallo"}` {
+		t.Fatalf("unexpected language file %q: %v", data, err)
+	}
+
+	fn := func(languages ...string) func(t *testing.T) {
+		return func(t *testing.T) {
+			syncer := syncer
+			syncer.Languages = languages
+			if err := syncer.Pull(context.Background()); err == nil {
+				t.Fatal("expected error")
+			}
+			for _, lang := range languages {
+				if _, err := os.Stat(filepath.Join(dir, lang+".json")); !os.IsNotExist(err) {
+					t.Fatalf("expected no language file of %q", lang)
+				}
+			}
+		}
+	}
+
+	t.Run("invalid download", fn("fr"))
+	t.Run("failed download", fn("it"))
+
+	syncer.SourceLanguage = "nl"
+	if err := syncer.Push(context.Background()); err == nil {
+		t.Fatal("expected error for missing source language")
+	}
+}
+
+func TestDirectoryWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "de.json"), []byte(`{"a": "hallo"}`), 0640); err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, expected os.FileMode) func(t *testing.T) {
+		return func(t *testing.T) {
+			if err := Directory(dir).WriteFile(lang, []byte(`{"a": "b"}`)); err != nil {
+				t.Fatal(err)
+			}
+			info, err := os.Stat(filepath.Join(dir, lang+".json"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mode := info.Mode().Perm(); mode != expected {
+				t.Fatalf("expected mode %v, got %v", expected, mode)
+			}
+		}
+	}
+
+	t.Run("retained", fn("de", 0640))
+	t.Run("created", fn("fr", 0644))
+}
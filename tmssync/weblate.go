@@ -0,0 +1,57 @@
+package tmssync
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// Weblate is a provider of a component of a Weblate project using monolingual JSON files
+type Weblate struct {
+	// BaseURL is the URL of the API, e.g. "https://hosted.weblate.org/api"
+	BaseURL   string
+	Token     string
+	Project   string
+	Component string
+
+	// HTTPClient is used for requests, http.DefaultClient if nil
+	HTTPClient *http.Client
+}
+
+func (w Weblate) client() client {
+	return newClient(w.HTTPClient, w.BaseURL, http.Header{"Authorization": {"Token " + w.Token}})
+}
+
+// path returns the path of the translation file of the language
+func (w Weblate) path(lang string) string {
+	return "/translations/" + url.PathEscape(w.Project) + "/" + url.PathEscape(w.Component) + "/" + url.PathEscape(lang) + "/file/"
+}
+
+// Upload replaces the source strings by the language file
+func (w Weblate) Upload(ctx context.Context, lang string, file []byte) error {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	if err := form.WriteField("method", "source"); err != nil {
+		return err
+	}
+	part, err := form.CreateFormFile("file", lang+".json")
+	if err != nil {
+		return err
+	}
+	if _, err := part.Write(file); err != nil {
+		return err
+	}
+	if err := form.Close(); err != nil {
+		return err
+	}
+
+	_, err = w.client().do(ctx, http.MethodPost, w.path(lang), form.FormDataContentType(), &body)
+	return err
+}
+
+// Download returns the translation file of the language
+func (w Weblate) Download(ctx context.Context, lang string) ([]byte, error) {
+	return w.client().do(ctx, http.MethodGet, w.path(lang), "", nil)
+}
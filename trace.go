@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+)
+
+// Tracer starts spans of the operations of the translations, e.g. by adapting the tracer
+// of an OpenTelemetry provider. Spans are started for loading and refreshing the translations
+// and for a sample of the translations made through TranslateCtx. See WithTracer.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+}
+
+// Span is a traced operation started by a Tracer
+type Span interface {
+	SetAttributes(attributes ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Attribute describes a traced operation, e.g. the language translated into
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Attributes set on spans
+const (
+	// AttributeLanguage is the language translated into
+	AttributeLanguage = "i18n.language"
+	// AttributeNamespace is the first fragment of the key translated, e.g. "checkout" for "checkout.title"
+	AttributeNamespace = "i18n.namespace"
+	// AttributeCacheHit reports whether the translation was served from the render cache
+	AttributeCacheHit = "i18n.cache_hit"
+	// AttributeLanguages is the number of languages loaded
+	AttributeLanguages = "i18n.languages"
+	// AttributeVersion is the version of the translations loaded
+	AttributeVersion = "i18n.version"
+)
+
+// spanKey is the context key of the span of a translation
+type spanKey struct{}
+
+// traced runs the operation within a span of the tracer if set, recording its error
+func (trl Translations) traced(ctx context.Context, name string, operation func(ctx context.Context, span Span) error, attributes ...Attribute) error {
+	if trl.tracer == nil {
+		return operation(ctx, nil)
+	}
+
+	ctx, span := trl.tracer.Start(ctx, name, attributes...)
+	defer span.End()
+
+	err := operation(ctx, span)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+// sampled reports whether a translation is traced according to the sample rate
+func (trl Translations) sampled() bool {
+	return trl.tracer != nil && trl.traceSampling > 0 && (trl.traceSampling >= 1 || rand.Float64() < trl.traceSampling)
+}
+
+// translateSpan returns the span of the translation within the context, nil if not traced
+func translateSpan(ctx context.Context) Span {
+	span, _ := ctx.Value(spanKey{}).(Span)
+	return span
+}
+
+// namespace returns the first fragment of the key
+func namespace(key string) string {
+	if i := strings.IndexByte(key, '.'); i != -1 {
+		return key[:i]
+	}
+	return key
+}
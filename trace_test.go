@@ -0,0 +1,110 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttributes(attributes ...Attribute) {
+	for _, attribute := range attributes {
+		s.attributes[attribute.Key] = attribute.Value
+	}
+}
+
+func (s *recordedSpan) RecordError(err error) { s.err = err }
+func (s *recordedSpan) End()                  { s.ended = true }
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordedSpan
+}
+
+func (tr *recordingTracer) Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span) {
+	span := &recordedSpan{name: name, attributes: make(map[string]interface{})}
+	span.SetAttributes(attributes...)
+
+	tr.mu.Lock()
+	tr.spans = append(tr.spans, span)
+	tr.mu.Unlock()
+	return ctx, span
+}
+
+func TestTracing(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"checkout": {"title": "hi {{name}}"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"checkout": {"title": "hallo {{name}}"}}`)},
+	}
+
+	tracer := &recordingTracer{}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithCache(8), WithTracer(tracer, 1)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := translations.TranslateCtx(context.Background(), "de", "checkout.title", "name", "Bob"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	translations.TranslateCtx(context.Background(), "de", "missing")
+
+	failing := SourceFunc(func(ctx context.Context, current Translations) (Translations, error) {
+		return Translations{}, errors.New("unavailable")
+	})
+	catalog := NewCatalog(translations)
+	catalog.source = failing
+	catalog.Refresh(context.Background())
+
+	expected := []struct {
+		name       string
+		attributes map[string]interface{}
+		failed     bool
+	}{
+		{"i18n.Load", map[string]interface{}{AttributeLanguages: 2, AttributeVersion: translations.Version()}, false},
+		{"i18n.Translate", map[string]interface{}{AttributeLanguage: "de", AttributeNamespace: "checkout", AttributeCacheHit: false}, false},
+		{"i18n.Translate", map[string]interface{}{AttributeLanguage: "de", AttributeNamespace: "checkout", AttributeCacheHit: true}, false},
+		{"i18n.Translate", map[string]interface{}{AttributeLanguage: "de", AttributeNamespace: "missing"}, true},
+		{"i18n.Refresh", map[string]interface{}{}, true},
+	}
+	if len(tracer.spans) != len(expected) {
+		t.Fatalf("expected %d spans, got %d", len(expected), len(tracer.spans))
+	}
+	for i, span := range tracer.spans {
+		if span.name != expected[i].name || !span.ended || (span.err != nil) != expected[i].failed {
+			t.Fatalf("expected span %d to be %s, got %+v", i, expected[i].name, span)
+		}
+		if len(span.attributes) != len(expected[i].attributes) {
+			t.Fatalf("expected attributes %v of span %d, got %v", expected[i].attributes, i, span.attributes)
+		}
+		for key, value := range expected[i].attributes {
+			if span.attributes[key] != value {
+				t.Fatalf("expected attributes %v of span %d, got %v", expected[i].attributes, i, span.attributes)
+			}
+		}
+	}
+}
+
+func TestTraceSampling(t *testing.T) {
+	fsys := fstest.MapFS{"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)}}
+
+	tracer := &recordingTracer{}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithTracer(tracer, 0)).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	translations.TranslateCtx(context.Background(), "en", "a")
+
+	if len(tracer.spans) != 1 {
+		t.Fatalf("expected only loading to be traced, got %d spans", len(tracer.spans))
+	}
+}
@@ -1,15 +1,17 @@
 package i18n
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"encoding"
 	"errors"
 	"fmt"
-	"html"
 	"html/template"
-	"io/ioutil"
-	"os"
-	"path/filepath"
+	"io/fs"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
 )
 
@@ -18,16 +20,54 @@ const (
 	Prefix = "{{"
 	// Suffix marks the end of a placeholder being used for i18n interpolation
 	Suffix = "}}"
+	// ChannelSeparator separates a key from the channel of its variant, e.g. "greeting@sms"
+	ChannelSeparator = "@"
+	// VariantSeparator separates a key from the name of its experiment variant, e.g. "cta#short"
+	VariantSeparator = "#"
 )
 
 // Translations are a collection of language translations represented by key value structure
 // Upon translating it will attempt to retrieve the target language from a given source function,
 // rolling back to the default language on failure. The translations are loaded during intialization
-// from a defined directory
+// from a defined directory or file system
 type Translations struct {
 	directory       string
+	fsys            fs.FS
 	defaultLanguage Language
+	fallbackChain   []Language
+	fallbacks       map[Language][]Language
+	limits          Limits
+	normalize       func(Key) Key
+	languageRules   languageRules
+	languageAliases map[string]string
+	escape          func(string) string
+	substitution    Substitute
+	logger          Logger
+	cacheSize       int
+	cache           *renderCache
+	keyCounter      *keyCounter
+	missing         *missingReporter
+	prerender       bool
+	printf          bool
+	prerendered     map[Key]template.HTML
 	translations    map[Language]Store
+	metadata        map[Key]Metadata
+	modified        map[Language]map[Key]time.Time
+	routes          map[Language]routeTable
+	labels          Labels
+	machine         *machineTranslator
+	tenantDirectory string
+	tenants         map[string]Translations
+	version         string
+	experimentHook  func(unit string, key Key, variant string)
+	refreshInterval time.Duration
+	refreshSource   Source
+	maxStaleness    time.Duration
+	persister       Persister
+	auditSink       AuditSink
+	manifest        string
+	tracer          Tracer
+	traceSampling   float64
 }
 
 // Language is the code abbreviation of language
@@ -72,6 +112,32 @@ func (k Key) String() string {
 type Translation struct {
 	Message       string
 	Intermediates []Intermediate
+
+	// segments is the message split into literals and intermediates
+	// enabling the interpolation within a single pass
+	segments []segment
+}
+
+// segment is a part of a translation message being either
+// a literal text or a named intermediate
+type segment struct {
+	literal      string
+	intermediate Intermediate
+	typ          IntermediateType
+	formats      []format
+	// fallback is the default value of the intermediate if declared
+	fallback interface{}
+}
+
+// Type returns the type declared for the intermediate within the translation
+// message. It is empty if no type is declared.
+func (t Translation) Type(intermediate Intermediate) IntermediateType {
+	for _, segment := range t.segments {
+		if segment.intermediate == intermediate && segment.typ != "" {
+			return segment.typ
+		}
+	}
+	return ""
 }
 
 // Intermediate is a named placeholder within
@@ -85,15 +151,13 @@ func (i Intermediate) Format() string {
 	return Prefix + string(i) + Suffix
 }
 
-// NewTranslations initializes a new translations object
-func NewTranslations(directory string, defaultLanguage string) Translations {
-	return Translations{
-		directory:       directory,
-		defaultLanguage: Language(defaultLanguage),
-	}
+// NewTranslations initializes a new translations object loading the language files
+// from the given directory. Further options may be passed, see New.
+func NewTranslations(directory string, defaultLanguage string, options ...Option) Translations {
+	return New(append([]Option{WithDirectory(directory), WithDefaultLanguage(defaultLanguage)}, options...)...)
 }
 
-// Load processes all language files of the defined directory and parses it into
+// Load processes all language files of the defined directory (or file system) and parses it into
 // a kv structure keyed by the language code. It fetches all files in the directory
 // using their base name as language identifier. The files are expected to be of JSON format.
 // Load allows nested translations in the file meaning the key must not be denoted
@@ -101,160 +165,167 @@ func NewTranslations(directory string, defaultLanguage string) Translations {
 // It will recursively summarize these keys into a full one, saving each value under the appropriate
 // full key and return a flattened structure.
 func (trl Translations) Load() (Translations, error) {
-	if !trl.defaultLanguage.Valid() {
-		return Translations{}, errors.New("invalid default language, must follow two letter code")
-	}
-
-	trl.translations = make(map[Language]Store)
-
-	err := filepath.Walk(trl.directory, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		if info.IsDir() {
-			return nil
+	loaded := trl
+	err := trl.traced(context.Background(), "i18n.Load", func(ctx context.Context, span Span) error {
+		var err error
+		if loaded, err = trl.load(); err == nil && span != nil {
+			span.SetAttributes(Attribute{Key: AttributeLanguages, Value: len(loaded.translations)}, Attribute{Key: AttributeVersion, Value: loaded.version})
 		}
+		return err
+	})
+	return loaded, err
+}
 
-		extension := filepath.Ext(path)
-		if extension != ".json" {
-			return nil
-		}
+// load loads the translations as Load
+func (trl Translations) load() (Translations, error) {
+	l := newLoader(trl)
+	l.load()
+	if len(l.issues) > 0 {
+		return Translations{}, l.issues[0]
+	}
 
-		_, file := filepath.Split(path)
+	trl.metadata = l.keyMetadata
+	trl.modified = l.modifiedTimes()
+	trl.tenants = nil
+	trl = trl.withTranslations(l.translations, l.routes)
 
-		// allow only 2-letter language code file name
-		lang := Language(strings.ToLower(strings.TrimSuffix(file, extension)))
-		if !lang.Valid() {
-			return fmt.Errorf("invalid file naming scheme %q, allowed are only two letter codes", lang)
+	if len(l.tenants) > 0 {
+		trl.tenants = make(map[string]Translations, len(l.tenants))
+		for tenant, translations := range l.tenants {
+			trl.tenants[tenant] = trl.withTranslations(translations, l.tenantRoutes[tenant])
 		}
+	}
+	return trl, nil
+}
 
-		b, err := ioutil.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("%v for %q", err, lang)
-		}
+// withTranslations returns a copy of the translations using the stores and routes,
+// setting up the render cache and prerendered translations for them
+func (trl Translations) withTranslations(translations map[Language]Store, routes map[Language]routeTable) Translations {
+	trl.translations = translations
+	trl.routes = routes
+	trl.version = catalogVersion(translations)
+	trl.cache = nil
+	if trl.cacheSize > 0 {
+		trl.cache = newRenderCache(trl.cacheSize)
+	}
 
-		var deserialized map[string]interface{}
-		err = json.Unmarshal(b, &deserialized)
-		if err != nil {
-			return fmt.Errorf("%v for %q", err, lang)
+	trl.prerendered = nil
+	if trl.prerender {
+		trl.prerendered = make(map[Key]template.HTML)
+		for key, translation := range trl.translations[trl.defaultLanguage] {
+			if len(translation.Intermediates) == 0 {
+				trl.prerendered[key] = template.HTML(translation.Message)
+			}
 		}
+	}
+	return trl
+}
 
-		store := make(Store)
+// interner deduplicates equal strings such that only a single copy is retained
+type interner map[string]string
 
-		// flatten the nested json objects & combining the key fragments into a complete key string
-		var flatten func(Key, map[string]interface{}) error
+// intern returns the retained copy of s, retaining s itself if it is not yet known
+func (in interner) intern(s string) string {
+	if retained, ok := in[s]; ok {
+		return retained
+	}
 
-		flatten = func(rootKey Key, data map[string]interface{}) error {
-			if len(data) == 0 {
-				return fmt.Errorf("invalid translation for %q", rootKey)
-			}
+	in[s] = s
+	return s
+}
 
-			for key, value := range data {
-				if key == "" {
-					return errors.New("invalid key, should not be empty")
-				}
+// parseIntermediates extracts the intermediates in the given translation message
+// and splits the message into literal and intermediate segments.
+// It allows arbitrary names, prohibiting only empty names. Names may contain
+// TypeSeparator and FormatSeparator, only a known type or known formats following
+// them annotate the intermediate. An intermediate used multiple times must not
+// declare different types.
+func parseIntermediates(message string) ([]Intermediate, []segment, error) {
+	var intermediates []Intermediate
 
-				// append key fragment to root key
-				rootKey := rootKey.Append(key)
-
-				switch t := value.(type) {
-				case string:
-					message := value.(string)
-
-					// parse the intermediates (if existing) of message string
-					// for fail-safety
-					intermediates, err := parseIntermediates(message)
-					if err != nil {
-						return fmt.Errorf("%v with key %q", err, rootKey)
-					}
-
-					store[rootKey] = Translation{
-						Message:       message,
-						Intermediates: intermediates,
-					}
-
-				case map[string]interface{}:
-					err := flatten(rootKey, value.(map[string]interface{}))
-					if err != nil {
-						return err
-					}
-
-				default:
-					return fmt.Errorf("invalid type %T in translation file, only string or objects as values allowed", t)
-				}
-			}
+	if strings.Count(message, Prefix) != strings.Count(message, Suffix) {
+		return []Intermediate{}, nil, errors.New("invalid format of intermediates")
+	}
 
-			return nil
+	parts := strings.Split(message, Prefix)
+	segments := []segment{{literal: parts[0]}}
+	for _, part := range parts[1:] {
+		i := strings.Index(part, Suffix)
+		if i == -1 {
+			return []Intermediate{}, nil, errors.New("invalid format of intermediates, must end with " + Suffix)
 		}
-		var k Key
-		err = flatten(k, deserialized)
+
+		placeholder, err := parsePlaceholder(part[:i])
 		if err != nil {
-			return fmt.Errorf("%v for %q", err, lang)
+			return []Intermediate{}, nil, err
 		}
 
-		// within the translations file, there must be at least one translation
-		if len(store) == 0 {
-			return fmt.Errorf("no translations found for %q", lang)
+		for _, other := range segments {
+			if other.intermediate == placeholder.intermediate && other.typ != "" && placeholder.typ != "" && other.typ != placeholder.typ {
+				return []Intermediate{}, nil, fmt.Errorf("conflicting types %q and %q of intermediate %q", other.typ, placeholder.typ, placeholder.intermediate)
+			}
 		}
 
-		trl.translations[lang] = store
-		return nil
-	})
-	if err != nil {
-		return Translations{}, err
+		intermediates = append(intermediates, placeholder.intermediate)
+		segments = append(segments, placeholder)
+		if literal := part[i+len(Suffix):]; literal != "" {
+			segments = append(segments, segment{literal: literal})
+		}
 	}
+	return intermediates, segments, nil
+}
 
-	if _, ok := trl.translations[trl.defaultLanguage]; !ok {
-		return Translations{}, fmt.Errorf("no translations found for default language")
-	}
+// maxScannedParameters is the number of key value pairs up to which the
+// parameters are scanned upon lookup instead of being put into a map
+const maxScannedParameters = 8
 
-	return trl, nil
+// intermediateLookup resolves intermediates to their parameter values
+type intermediateLookup struct {
+	parameter []interface{}
+	dict      map[Intermediate]interface{}
 }
 
-// parseIntermediates extracts the intermediates in the given translation message
-// It allows arbitrary names, prohibiting only empty names.
-func parseIntermediates(message string) ([]Intermediate, error) {
-	var intermediates []Intermediate
-
-	if strings.Count(message, Prefix) != strings.Count(message, Suffix) {
-		return []Intermediate{}, errors.New("invalid format of intermediates")
+// get returns the parameter value for the given intermediate. As for a map, later
+// parameters take precedence over earlier parameters with the same key.
+func (l intermediateLookup) get(intermediate Intermediate) (interface{}, bool) {
+	if l.dict != nil {
+		value, ok := l.dict[intermediate]
+		return value, ok
 	}
 
-	parts := strings.Split(message, Prefix)[1:]
-	for _, part := range parts {
-		i := strings.Index(part, Suffix)
-		if i == -1 {
-			return []Intermediate{}, errors.New("invalid format of intermediates, must end with " + Suffix)
-		}
-
-		intermediate := Intermediate(strings.TrimSpace(part[:i]))
-		if intermediate == "" {
-			return []Intermediate{}, errors.New("empty intermediate")
+	for i := len(l.parameter) - 2; i >= 0; i -= 2 {
+		if Intermediate(l.parameter[i].(string)) == intermediate {
+			return l.parameter[i+1], true
 		}
-
-		intermediates = append(intermediates, intermediate)
 	}
-	return intermediates, nil
+	return nil, false
 }
 
 // createIntermediateLookup attempts to resolve a list non-typed parameters
 // into a lookup structure putting each odd indexed parameter as key (assuming it to be string)
-// and each even indexed non-typed parameter as value
-func createIntermediateLookup(parameter []interface{}) (map[Intermediate]interface{}, error) {
+// and each even indexed non-typed parameter as value.
+// Only for more than maxScannedParameters pairs a map is allocated, small parameter lists
+// are scanned directly.
+func createIntermediateLookup(parameter []interface{}) (intermediateLookup, error) {
 	if len(parameter)%2 != 0 {
-		return nil, errors.New("invalid dict call")
+		return intermediateLookup{}, errors.New("invalid dict call")
 	}
-	dict := make(map[Intermediate]interface{}, len(parameter)/2)
 	for i := 0; i < len(parameter); i += 2 {
-		_, ok := parameter[i].(string)
-		if !ok {
-			return nil, errors.New("dict keys must be strings")
+		if _, ok := parameter[i].(string); !ok {
+			return intermediateLookup{}, errors.New("dict keys must be strings")
 		}
+	}
+
+	if len(parameter)/2 <= maxScannedParameters {
+		return intermediateLookup{parameter: parameter}, nil
+	}
+
+	dict := make(map[Intermediate]interface{}, len(parameter)/2)
+	for i := 0; i < len(parameter); i += 2 {
 		key := Intermediate(parameter[i].(string))
 		dict[key] = parameter[i+1]
 	}
-	return dict, nil
+	return intermediateLookup{dict: dict}, nil
 }
 
 // GenerateDefaultTranslate returns a translate function for the default language.
@@ -266,51 +337,412 @@ func (trl Translations) GenerateDefaultTranslate() func(k string, params ...inte
 // the passed parameter values assuming the intermediates
 // match the parameter keys injectively.
 func (trl Translations) GenerateTranslate(targetLang string) func(k string, params ...interface{}) (template.HTML, error) {
-	lang := Language(targetLang)
-	if !lang.Valid() {
-		lang = trl.defaultLanguage
+	return trl.generateTranslate(context.Background(), targetLang, "", "")
+}
+
+// GenerateChannelTranslate returns a translate function for a specific language preferring the
+// variants of keys for the channel, e.g. "greeting@sms" for the key "greeting" and the channel "sms".
+// Keys without variant for the channel are translated as by GenerateTranslate.
+func (trl Translations) GenerateChannelTranslate(targetLang string, channel string) func(k string, params ...interface{}) (template.HTML, error) {
+	return trl.generateTranslate(context.Background(), targetLang, channel, "")
+}
+
+// GenerateExperimentTranslate returns a translate function for a specific language serving
+// variants of keys declaring weighted variants in their metadata, e.g. "cta#short" for the key
+// "cta" declaring {"variants": {"short": 1, "long": 1}}. The variant is selected deterministically
+// by the experiment unit, e.g. the ID of a user, such that a unit is always served the same variant.
+// The variant served is reported to the hook set by WithExperimentHook.
+func (trl Translations) GenerateExperimentTranslate(targetLang string, unit string) func(k string, params ...interface{}) (template.HTML, error) {
+	return trl.generateTranslate(context.Background(), targetLang, "", unit)
+}
+
+// TranslateCtx translates the key into the language as the function returned by GenerateTranslate,
+// passing the context to backends consulted for the translation, e.g. the provider set by
+// WithMachineTranslation. Translations of cancelled or expired contexts fail with their error.
+func (trl Translations) TranslateCtx(ctx context.Context, lang string, key string, params ...interface{}) (template.HTML, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if !trl.sampled() {
+		return trl.generateTranslate(ctx, lang, "", "")(key, params...)
 	}
 
+	var rendered template.HTML
+	err := trl.traced(ctx, "i18n.Translate", func(ctx context.Context, span Span) error {
+		var err error
+		rendered, err = trl.generateTranslate(context.WithValue(ctx, spanKey{}, span), lang, "", "")(key, params...)
+		return err
+	}, Attribute{Key: AttributeLanguage, Value: string(trl.resolveLanguage(lang))}, Attribute{Key: AttributeNamespace, Value: namespace(key)})
+	return rendered, err
+}
+
+// generateTranslate returns a translate function for the language, serving variants of
+// the channel or selected for the experiment unit if not empty. The context is passed
+// to the backends consulted for translations.
+func (trl Translations) generateTranslate(ctx context.Context, targetLang string, channel string, unit string) func(k string, params ...interface{}) (template.HTML, error) {
+	lang := trl.resolveLanguage(targetLang)
+	chain := trl.languageChain(lang)
+
 	return func(k string, params ...interface{}) (template.HTML, error) {
-		key := Key(k)
+		key := trl.normalizeKey(Key(k))
 
-		lookup, err := createIntermediateLookup(params)
+		var variant Key
+		if channel != "" {
+			variant = trl.normalizeKey(Key(k + ChannelSeparator + channel))
+		} else if unit != "" {
+			variant = trl.experimentVariant(key, unit)
+		}
+
+		if trl.logger != nil {
+			trl.warnDeprecated(key)
+		}
+
+		if len(params) == 0 && lang == trl.defaultLanguage && variant == "" && !trl.printf {
+			if rendered, ok := trl.prerendered[key]; ok {
+				if trl.keyCounter != nil {
+					trl.keyCounter.count(key)
+				}
+				return rendered, nil
+			}
+		}
+
+		translation, served, err := trl.resolve(ctx, chain, key, variant)
 		if err != nil {
 			return "", err
 		}
+		// only known keys are counted, such that unknown keys of callers do not grow the counts
+		if trl.keyCounter != nil {
+			trl.keyCounter.count(key)
+		}
+		if unit != "" && variant != "" && trl.experimentHook != nil {
+			trl.reportExperiment(unit, key, variant, served)
+		}
 
-		if _, ok := trl.translations[lang]; !ok {
-			return "", fmt.Errorf("unknown language %q", lang)
+		if trl.printf {
+			message, err := trl.sprintf(key, translation, params, trl.escape)
+			return template.HTML(message), err
 		}
-		if _, ok := trl.translations[lang][key]; !ok {
-			return "", fmt.Errorf("unknown key %q", key)
+
+		// static translations are returned as they are,
+		// skipping the creation of the parameter lookup
+		if len(params) == 0 && len(translation.Intermediates) == 0 {
+			return template.HTML(translation.Message), nil
 		}
-		translation := trl.translations[lang][key]
-		message := translation.Message
 
-		// replace intermediates with passed params
-		for _, intermediate := range translation.Intermediates {
-			if _, ok := lookup[intermediate]; !ok {
-				return "", fmt.Errorf("parameter required for intermediate in translation %q: %q", key, intermediate)
+		var cacheKey renderKey
+		cacheable := false
+		if trl.cache != nil {
+			if variant != "" {
+				cacheKey, cacheable = newRenderKey(lang, variant, params)
+			} else {
+				cacheKey, cacheable = newRenderKey(lang, key, params)
 			}
+		}
+		if cacheable {
+			rendered, ok := trl.cache.get(cacheKey)
+			if span := translateSpan(ctx); span != nil {
+				span.SetAttributes(Attribute{Key: AttributeCacheHit, Value: ok})
+			}
+			if ok {
+				return rendered, nil
+			}
+		}
 
-			// escape content of intermediates
-			value := html.EscapeString(fmt.Sprintf("%v", lookup[intermediate]))
-			message = strings.Replace(message, intermediate.Format(), value, -1)
+		lookup, err := createIntermediateLookup(params)
+		if err != nil {
+			return "", err
+		}
+
+		message, err := trl.interpolate(lang, key, translation, lookup, trl.escape)
+		if err != nil {
+			return "", err
 		}
 
 		// interpret message string as plain HTML allowing tags
-		return template.HTML(message), nil
+		rendered := template.HTML(message)
+		if cacheable {
+			trl.cache.add(cacheKey, rendered)
+		}
+		return rendered, nil
+	}
+}
+
+// interpolate renders the message of the translation, replacing its intermediates
+// with the parameter values of the lookup within a single pass. The values are
+// escaped using escape unless nil.
+func (trl Translations) interpolate(lang Language, key Key, translation Translation, lookup intermediateLookup, escape func(string) string) (string, error) {
+	if len(translation.segments) <= 1 && len(translation.Intermediates) == 0 {
+		return translation.Message, nil
+	}
+
+	b := buffers.Get().(*bytes.Buffer)
+	defer releaseBuffer(b)
+
+	substitute := trl.substitute(lookup)
+	for _, segment := range translation.segments {
+		if segment.intermediate == "" {
+			b.WriteString(segment.literal)
+			continue
+		}
+
+		value, ok := lookup.get(segment.intermediate)
+		if !ok && segment.fallback != nil {
+			value, ok = segment.fallback, true
+		}
+		if (!ok || value == nil) && substitute.set {
+			b.WriteString(substitute.render(segment.intermediate))
+			continue
+		}
+		if !ok {
+			return "", fmt.Errorf("parameter required for intermediate in translation %q: %q", key, segment.intermediate)
+		}
+		if !segment.typ.Accepts(value) {
+			return "", fmt.Errorf("parameter for intermediate %q in translation %q must be of type %s, got %T", segment.intermediate, key, segment.typ, value)
+		}
+
+		var formatted string
+		if len(segment.formats) > 0 {
+			var err error
+			if formatted, err = applyFormats(lang, value, segment.formats, lookup); err != nil {
+				return "", fmt.Errorf("parameter for intermediate %q in translation %q: %v", segment.intermediate, key, err)
+			}
+		} else {
+			formatted = formatParameter(lang, value)
+		}
+
+		// escape content of intermediates
+		if escape != nil {
+			b.WriteString(escape(formatted))
+		} else {
+			b.WriteString(formatted)
+		}
+	}
+	return b.String(), nil
+}
+
+// maxPooledBuffer is the capacity up to which interpolation buffers are reused
+const maxPooledBuffer = 64 << 10
+
+// buffers pools the buffers used for interpolation
+var buffers = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// releaseBuffer returns b to the pool unless it grew too large to be retained
+func releaseBuffer(b *bytes.Buffer) {
+	if b.Cap() > maxPooledBuffer {
+		return
+	}
+
+	b.Reset()
+	buffers.Put(b)
+}
+
+// formatParameter formats a parameter value interpolated without format in the language.
+// Times are formatted in the short style of the language, e.g. "1/2/06, 3:04 PM" in English,
+// and floats in its notation, e.g. "1.234,5" in German. Integers are formatted as they are
+// since they often are identifiers or years.
+func formatParameter(lang Language, value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return FormatDateTime(lang, v, Short)
+	case float64:
+		return localizeNumber(lang, strconv.FormatFloat(v, 'f', -1, 64))
+	case float32:
+		return localizeNumber(lang, strconv.FormatFloat(float64(v), 'f', -1, 32))
 	}
+	return formatValue(value)
+}
+
+// formatValue formats a parameter value in its default format, avoiding fmt for the most
+// common types. Values implementing fmt.Stringer or encoding.TextMarshaler render as such.
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case fmt.Stringer:
+		return v.String()
+	case encoding.TextMarshaler:
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+	case error:
+		return v.Error()
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// lookup retrieves the translation of key in the languages of the chain, resolving aliases. If
+// the key is not available, the languages of the fallback chain are consulted in order.
+// The variant of the key, if not empty, precedes the key within each language. Keys missing
+// in the chain are machine translated from the default language if configured.
+func (trl Translations) lookup(chain languageChain, key Key, variant Key) (Translation, error) {
+	translation, _, err := trl.resolve(context.Background(), chain, key, variant)
+	return translation, err
+}
+
+// resolve retrieves the translation as lookup, reporting whether the variant was served.
+// Machine translations are requested within the context, failing with its error if done.
+func (trl Translations) resolve(ctx context.Context, chain languageChain, key Key, variant Key) (Translation, bool, error) {
+	canonical := trl.canonical(key)
+	if variant != "" {
+		variant = trl.canonical(variant)
+	}
+
+	for _, lang := range chain.languages {
+		if translation, served, ok := trl.translations[lang].variant(canonical, variant); ok {
+			return translation, served, nil
+		}
+	}
+
+	if trl.machine != nil {
+		target, ok := trl.machineTarget(chain.requested)
+		if source, served, found := trl.translations[trl.defaultLanguage].variant(canonical, variant); ok && found {
+			if translation, ok := trl.machine.translate(ctx, source, trl.defaultLanguage, target); ok {
+				return translation, served, nil
+			}
+			if err := ctx.Err(); err != nil {
+				return Translation{}, false, err
+			}
+		}
+	}
+
+	for _, fallback := range trl.fallbackChain {
+		if translation, served, ok := trl.translations[fallback].variant(canonical, variant); ok {
+			return translation, served, nil
+		}
+	}
+
+	if len(chain.languages) == 0 {
+		return Translation{}, false, fmt.Errorf("unknown language %q", chain.requested)
+	}
+	trl.reportMissing(chain.requested, key)
+	return Translation{}, false, fmt.Errorf("unknown key %q", key)
+}
+
+// reportMissing reports the key missing in the language to the missing key hook if configured
+func (trl Translations) reportMissing(lang Language, key Key) {
+	if trl.missing != nil {
+		trl.missing.report(lang, key, time.Now())
+	}
+}
+
+// exists reports whether resolve may find a translation of the normalized key without
+// reporting it missing, for probing optional keys. Keys of the default language are
+// assumed to be available if they may be machine translated.
+func (trl Translations) exists(chain languageChain, key Key) bool {
+	canonical := trl.canonical(key)
+	for _, lang := range chain.languages {
+		if _, ok := trl.translations[lang][canonical]; ok {
+			return true
+		}
+	}
+	if trl.machine != nil {
+		if _, ok := trl.machineTarget(chain.requested); ok {
+			if _, ok := trl.translations[trl.defaultLanguage][canonical]; ok {
+				return true
+			}
+		}
+	}
+	for _, fallback := range trl.fallbackChain {
+		if _, ok := trl.translations[fallback][canonical]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// variant returns the translation of the variant of key if available, falling back to the
+// translation of key itself. It reports whether the variant was served and any was found.
+func (s Store) variant(key Key, variant Key) (Translation, bool, bool) {
+	if variant != "" {
+		if translation, ok := s[variant]; ok {
+			return translation, true, true
+		}
+	}
+
+	translation, ok := s[key]
+	return translation, false, ok
+}
+
+// normalizeKey applies the configured key normalization
+func (trl Translations) normalizeKey(key Key) Key {
+	if trl.normalize == nil {
+		return key
+	}
+	return trl.normalize(key)
+}
+
+// Has reports whether a translation for the given key exists in the given language.
+// The language is resolved as by Translate, so "de-AT" has the keys of "de" and of
+// configured fallbacks. It performs no interpolation and is therefore cheap enough
+// to be used for conditionally rendering optional translations. Aliases are resolved.
+func (trl Translations) Has(lang Language, key Key) bool {
+	return trl.exists(trl.languageChain(trl.resolveLanguage(string(lang))), trl.normalizeKey(key))
+}
+
+// Tree returns all translation messages of the given language whose key lies
+// below the given prefix as a nested structure, which is the inverse of the
+// flattening performed by Load. The prefix itself is not part of the returned
+// keys. An empty prefix returns the whole store of the language.
+func (trl Translations) Tree(lang Language, prefix Key) map[string]interface{} {
+	tree := make(map[string]interface{})
+	prefix = trl.normalizeKey(prefix)
+
+	for key, translation := range trl.translations[lang] {
+		rel := string(key)
+		if prefix != "" {
+			if !strings.HasPrefix(rel, string(prefix)+".") {
+				continue
+			}
+			rel = strings.TrimPrefix(rel, string(prefix)+".")
+		}
+
+		// walk down the nesting levels, creating them as required
+		node := tree
+		fragments := strings.Split(rel, ".")
+		for _, fragment := range fragments[:len(fragments)-1] {
+			child, ok := node[fragment].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[fragment] = child
+			}
+			node = child
+		}
+		node[fragments[len(fragments)-1]] = translation.Message
+	}
+
+	return tree
 }
 
 // AvailableLanguages returns a list of available languages
 // that were discovered in the language file directory.
+// The default language comes first, followed by the other languages in sorted order.
 func (trl Translations) AvailableLanguages() []string {
 	availableLanguages := []string{}
-	for lang := range trl.translations {
+	for _, lang := range trl.orderedLanguages() {
 		availableLanguages = append(availableLanguages, string(lang))
 	}
 
 	return availableLanguages
 }
+
+// orderedLanguages returns the loaded languages with the default language first
+// and the other languages in sorted order
+func (trl Translations) orderedLanguages() []Language {
+	languages := sortedLanguages(trl.translations)
+	for i, lang := range languages {
+		if lang == trl.defaultLanguage {
+			copy(languages[1:i+1], languages[:i])
+			languages[0] = lang
+			break
+		}
+	}
+	return languages
+}
@@ -1,7 +1,13 @@
 package i18n
 
 import (
+	"fmt"
+	"html/template"
+	"reflect"
 	"testing"
+	"testing/fstest"
+	"time"
+	"unsafe"
 )
 
 const (
@@ -141,3 +147,306 @@ func TestAvailableLanguages(t *testing.T) {
 		t.Fatalf("expected %d and not %d available languages", expected, l)
 	}
 }
+
+func TestAvailableLanguagesOrder(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fr.json": &fstest.MapFile{Data: []byte(`{"a": "bonjour"}`)},
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+		"nl.json": &fstest.MapFile{Data: []byte(`{"a": "hallo"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("nl")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"nl", "de", "en", "fr"}
+	for i := 0; i < 10; i++ {
+		if got := translations.AvailableLanguages(); !reflect.DeepEqual(got, expected) {
+			t.Fatalf("expected %v, got %v", expected, got)
+		}
+	}
+}
+
+func TestHas(t *testing.T) {
+	translations, err := NewTranslations(Validity+"valid", "en").Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang Language, key Key, expected bool) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := translations.Has(lang, key); got != expected {
+				t.Fatalf("expected %v for %q in %q, got %v", expected, key, lang, got)
+			}
+		}
+	}
+
+	t.Run("existing", fn("en", "hello.world", true))
+	t.Run("nested existing", fn("en", "os.win.xp", true))
+	t.Run("intermediate level", fn("en", "os.win", false))
+	t.Run("unknown key", fn("en", "hello.moon", false))
+	t.Run("unknown language", fn("de", "hello.world", false))
+	t.Run("regional language", fn("en-GB", "hello.world", true))
+	t.Run("unnormalized regional language", fn("en_gb", "os.win.xp", true))
+	t.Run("regional unknown key", fn("en-GB", "hello.moon", false))
+}
+
+func TestTree(t *testing.T) {
+	translations, err := NewTranslations(Validity+"valid", "en").Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(prefix Key, expected map[string]interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			tree := translations.Tree("en", prefix)
+			if !reflect.DeepEqual(tree, expected) {
+				t.Fatalf("expected %v, got %v", expected, tree)
+			}
+		}
+	}
+
+	t.Run("leaf level", fn("c", map[string]interface{}{"sharp": "good", "hashtag": "bad"}))
+	t.Run("nested level", fn("os", map[string]interface{}{
+		"win": map[string]interface{}{
+			"xp":    "obsolete",
+			"vista": "don't go there",
+			"7":     "better",
+			"8":     "oops, i failed it again",
+			"10":    "at least stable (except for updates)",
+		},
+	}))
+	t.Run("unknown prefix", fn("linux", map[string]interface{}{}))
+	t.Run("partial fragment", fn("o", map[string]interface{}{}))
+
+	if l := len(translations.Tree("en", "")); l != 4 {
+		t.Fatalf("expected 4 root entries, got %d", l)
+	}
+}
+
+func BenchmarkTranslate(b *testing.B) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"static": "welcome back to the dashboard",
+			"dynamic": "{{user}} ordered {{count}} items of {{product}} on {{date}} at {{time}} for {{price}}"
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		b.Fatal(err)
+	}
+	translate := translations.GenerateDefaultTranslate()
+
+	b.Run("static", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := translate("static"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("dynamic", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, err := translate("dynamic",
+				"user", "alice", "count", 3, "product", "apples",
+				"date", "today", "time", "noon", "price", "5 €")
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestInterpolation(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{
+			"static": "hello, world",
+			"empty": "",
+			"single": "{{name}}",
+			"spaced": "hello, {{ name }}!",
+			"multiple": "{{a}}{{b}} and {{a}} again"
+		}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	translate := translations.GenerateDefaultTranslate()
+
+	fn := func(key string, params []interface{}, expected template.HTML) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translate(key, params...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("static", fn("static", nil, "hello, world"))
+	t.Run("empty", fn("empty", nil, ""))
+	t.Run("single", fn("single", []interface{}{"name", "bob"}, "bob"))
+	t.Run("spaced", fn("spaced", []interface{}{"name", "bob"}, "hello, bob!"))
+	t.Run("multiple", fn("multiple", []interface{}{"a", 1, "b", 2}, "12 and 1 again"))
+	t.Run("no reinterpolation", fn("multiple", []interface{}{"a", "{{b}}", "b", 2}, "{{b}}2 and {{b}} again"))
+
+	if _, err := translate("single"); err == nil {
+		t.Fatal("expected error for missing parameter")
+	}
+}
+
+func TestStaticTranslationAllocations(t *testing.T) {
+	translations, err := NewTranslations(Validity+"valid", "en").Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	translate := translations.GenerateDefaultTranslate()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := translate("os.win.xp"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestChannelVariants(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hello {{name}}, welcome back", "greeting@sms": "Hi {{name}}", "bye": "Goodbye"}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"greeting": "Hallo {{name}}, willkommen zurück"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en"), WithFallbackChain("en"), WithCache(8), WithPrerender()).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, channel string, key string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			for i := 0; i < 2; i++ {
+				got, err := translations.GenerateChannelTranslate(lang, channel)(key, "name", "Bob")
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != expected {
+					t.Fatalf("expected %q, got %q", expected, got)
+				}
+			}
+		}
+	}
+
+	t.Run("variant", fn("en", "sms", "greeting", "Hi Bob"))
+	t.Run("base key", fn("en", "push", "greeting", "Hello Bob, welcome back"))
+	t.Run("no channel", fn("en", "", "greeting", "Hello Bob, welcome back"))
+	t.Run("base key precedes variant of fallback", fn("de", "sms", "greeting", "Hallo Bob, willkommen zurück"))
+	t.Run("fallback", fn("de", "sms", "bye", "Goodbye"))
+}
+
+func TestInterning(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"brand": {"name": "nimbusec"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"brand": {"name": "nimbusec"}}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// string headers are compared instead of unsafe.StringData, which requires Go 1.20
+	pointer := func(s string) uintptr {
+		return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+	}
+	data := func(lang Language) (key uintptr, message uintptr) {
+		for k, translation := range translations.translations[lang] {
+			return pointer(string(k)), pointer(translation.Message)
+		}
+		t.Fatalf("no translations for %q", lang)
+		return 0, 0
+	}
+
+	enKey, enMessage := data("en")
+	deKey, deMessage := data("de")
+	if enKey != deKey {
+		t.Fatal("expected keys to share memory")
+	}
+	if enMessage != deMessage {
+		t.Fatal("expected messages to share memory")
+	}
+}
+
+func TestIntermediateLookup(t *testing.T) {
+	fn := func(parameter []interface{}, intermediate Intermediate, expected interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			lookup, err := createIntermediateLookup(parameter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			value, ok := lookup.get(intermediate)
+			if expected == nil && ok {
+				t.Fatalf("expected no value for %q, got %v", intermediate, value)
+			}
+			if value != expected {
+				t.Fatalf("expected %v for %q, got %v", expected, intermediate, value)
+			}
+		}
+	}
+
+	many := []interface{}{}
+	for i := 0; i < 2*maxScannedParameters; i++ {
+		many = append(many, fmt.Sprintf("p%d", i), i)
+	}
+
+	t.Run("scanned", fn([]interface{}{"a", 1, "b", 2}, "b", 2))
+	t.Run("scanned missing", fn([]interface{}{"a", 1, "b", 2}, "c", nil))
+	t.Run("scanned duplicate", fn([]interface{}{"a", 1, "a", 2}, "a", 2))
+	t.Run("mapped", fn(many, "p11", 11))
+	t.Run("mapped missing", fn(many, "c", nil))
+
+	if _, err := createIntermediateLookup([]interface{}{"a"}); err == nil {
+		t.Fatal("expected error for odd number of parameters")
+	}
+	if _, err := createIntermediateLookup([]interface{}{1, "a"}); err == nil {
+		t.Fatal("expected error for non-string key")
+	}
+}
+
+type textID struct {
+	id int
+}
+
+func (i textID) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("ID-%d", i.id)), nil
+}
+
+func TestFormatParameter(t *testing.T) {
+	fn := func(lang Language, value interface{}, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := formatParameter(lang, value); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	ts := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	t.Run("string", fn("de", "Bob", "Bob"))
+	t.Run("integer", fn("de", 2024, "2024"))
+	t.Run("float", fn("de", 1234.5, "1.234,5"))
+	t.Run("float32", fn("en", float32(0.25), "0.25"))
+	t.Run("time", fn("en", ts, FormatDateTime("en", ts, Short)))
+	t.Run("stringer", fn("en", time.Minute, "1m0s"))
+	t.Run("text marshaler", fn("en", textID{id: 7}, "ID-7"))
+	t.Run("error", fn("en", fmt.Errorf("failed"), "failed"))
+	t.Run("nil", fn("en", nil, "<nil>"))
+}
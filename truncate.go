@@ -0,0 +1,108 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// zeroWidthJoiner joins emoji into a single character, e.g. "👩\u200d💻"
+const zeroWidthJoiner = '\u200d'
+
+// ellipses are the ellipses of languages not appending a single "…" to truncated text
+var ellipses = map[Language]string{
+	"zh": "……",
+}
+
+// ellipsis returns the ellipsis appended to truncated text in the language
+func ellipsis(lang Language) string {
+	if e, ok := ellipses[lang.Base()]; ok {
+		return e
+	}
+	return "…"
+}
+
+// Truncate shortens s to at most n characters including the ellipsis of the language,
+// e.g. "Hello wo…". Characters are counted as grapheme clusters, such that emoji and
+// letters followed by combining marks are never cut in half. Whitespace preceding the
+// ellipsis is removed. s is returned as is if it does not exceed n characters.
+func Truncate(lang Language, s string, n int) string {
+	if n <= 0 {
+		return ""
+	}
+
+	end, count := 0, 0
+	e := ellipsis(lang)
+	keep := n - utf8.RuneCountInString(e)
+	for i := 0; i < len(s); count++ {
+		if count == n {
+			truncated := strings.TrimRightFunc(s[:end], unicode.IsSpace)
+			return truncated + e
+		}
+		if count == keep {
+			end = i
+		}
+		i += graphemeLength(s[i:])
+	}
+	return s
+}
+
+// graphemeLength returns the length in bytes of the grapheme cluster starting s.
+// It follows the rules of extended grapheme clusters except for the rare prepending
+// characters, joining combining marks, emoji modifiers, variation selectors,
+// zero width joiner sequences and regional indicator pairs forming flags.
+func graphemeLength(s string) int {
+	if strings.HasPrefix(s, "\r\n") {
+		return 2
+	}
+
+	r, length := utf8.DecodeRuneInString(s)
+	if isRegionalIndicator(r) {
+		if next, size := utf8.DecodeRuneInString(s[length:]); isRegionalIndicator(next) {
+			length += size
+		}
+	}
+
+	for length < len(s) {
+		next, size := utf8.DecodeRuneInString(s[length:])
+		switch {
+		case next == zeroWidthJoiner:
+			length += size
+			if length < len(s) {
+				_, size = utf8.DecodeRuneInString(s[length:])
+				length += size
+			}
+		case extendsGrapheme(next):
+			length += size
+		default:
+			return length
+		}
+	}
+	return length
+}
+
+// extendsGrapheme reports whether r belongs to the grapheme cluster of the preceding character
+func extendsGrapheme(r rune) bool {
+	switch {
+	case unicode.Is(unicode.M, r):
+		return true
+	case r >= 0xfe00 && r <= 0xfe0f, r >= 0xe0100 && r <= 0xe01ef:
+		// variation selectors, e.g. requesting the emoji presentation
+		return true
+	case r >= 0x1f3fb && r <= 0x1f3ff:
+		// emoji skin tone modifiers
+		return true
+	case r >= 0xe0020 && r <= 0xe007f:
+		// tags forming subdivision flags, e.g. of England
+		return true
+	case r >= 0x1160 && r <= 0x11ff:
+		// hangul vowel and trailing consonant jamo
+		return true
+	}
+	return false
+}
+
+// isRegionalIndicator reports whether r is a regional indicator, a pair of them forming a flag
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1f1e6 && r <= 0x1f1ff
+}
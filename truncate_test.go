@@ -0,0 +1,25 @@
+package i18n
+
+import "testing"
+
+func TestTruncate(t *testing.T) {
+	fn := func(lang Language, s string, n int, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Truncate(lang, s, n); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("short", fn("en", "Hello", 5, "Hello"))
+	t.Run("truncated", fn("en", "Hello world", 9, "Hello wo…"))
+	t.Run("trailing space", fn("en", "Hello world", 7, "Hello…"))
+	t.Run("chinese", fn("zh-hant", "你好，世界和平", 5, "你好，……"))
+	t.Run("combining marks", fn("de", "Gru\u0308ße", 4, "Gru\u0308…"))
+	t.Run("emoji sequence", fn("en", "👩\u200d💻👩\u200d💻👩\u200d💻", 2, "👩\u200d💻…"))
+	t.Run("skin tone", fn("en", "👍🏽👍🏽👍🏽", 2, "👍🏽…"))
+	t.Run("flags", fn("en", "🇦🇹🇩🇪🇨🇭", 2, "🇦🇹…"))
+	t.Run("exact graphemes", fn("en", "🇦🇹🇩🇪", 2, "🇦🇹🇩🇪"))
+	t.Run("ellipsis only", fn("en", "Hello", 1, "…"))
+	t.Run("zero", fn("en", "Hello", 0, ""))
+}
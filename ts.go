@@ -0,0 +1,157 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// tsMessage is a message of a Qt Linguist .ts file
+type tsMessage struct {
+	ID           string `xml:"id,attr"`
+	Numerus      string `xml:"numerus,attr"`
+	Source       string `xml:"source"`
+	ExtraComment string `xml:"extracomment"`
+	Translation  struct {
+		Type  string   `xml:"type,attr"`
+		Text  string   `xml:",chardata"`
+		Forms []string `xml:"numerusform"`
+	} `xml:"translation"`
+}
+
+// tsLanguage derives the language of a translation file named by the convention of Qt,
+// e.g. "pt_BR" for "app_pt_BR.ts". The language declared by the file takes precedence.
+func tsLanguage(name string, defaultLanguage Language) string {
+	i := strings.Index(name, "_")
+	if i == -1 {
+		return name
+	}
+	return strings.Replace(name[i+1:], "_", "-", -1)
+}
+
+// decodeTS decodes a Qt Linguist translation file, e.g. "app_de.ts". Messages are keyed by
+// their id if given, else by their source text within their context, e.g. "MainWindow.Open".
+// Plural messages are loaded as one key per plural category suffixed as in i18next, e.g.
+// "MainWindow.%n files_one", replacing %n by the intermediate count. Untranslated, obsolete
+// and vanished messages are skipped, unfinished translations are loaded as by lrelease.
+func (d *decoder) decodeTS() (Store, error) {
+	tokens := xml.NewDecoder(d.r)
+	tokens.CharsetReader = utf8CharsetReader
+
+	root := true
+	context := ""
+	for {
+		token, err := tokens.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if end, ok := token.(xml.EndElement); ok && end.Name.Local == "context" {
+			context = ""
+			continue
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if root {
+			if start.Name.Local != "TS" {
+				return nil, errors.New("invalid ts file, must consist of a TS element")
+			}
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "language" && attr.Value != "" {
+					d.lang = normalizeLanguage(attr.Value)
+				}
+			}
+			root = false
+			continue
+		}
+
+		switch start.Name.Local {
+		case "context":
+			continue
+		case "name":
+			if err := tokens.DecodeElement(&context, &start); err != nil {
+				return nil, err
+			}
+			continue
+		case "message":
+		default:
+			if err := tokens.Skip(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var message tsMessage
+		if err := tokens.DecodeElement(&message, &start); err != nil {
+			return nil, err
+		}
+		d.tsMessage(context, message)
+	}
+
+	if root {
+		return nil, errors.New("invalid ts file, must consist of a TS element")
+	}
+	return d.store, nil
+}
+
+// tsMessage adds the translation of the message, a key per plural category for plural messages
+func (d *decoder) tsMessage(context string, message tsMessage) {
+	switch message.Translation.Type {
+	case "obsolete", "vanished":
+		return
+	}
+
+	name := message.Source
+	if message.ID != "" {
+		context, name = "", message.ID
+	}
+
+	if message.Numerus != "yes" {
+		if message.Translation.Text != "" {
+			d.resource(context, name, message.Translation.Text, message.ExtraComment)
+		}
+		return
+	}
+
+	categories := numerusCategories(d.lang)
+	if len(message.Translation.Forms) > len(categories) {
+		d.report(d.key(Key(context), name), fmt.Sprintf("invalid plural, %d numerus forms exceed the %d plural categories of %q", len(message.Translation.Forms), len(categories), d.lang))
+		return
+	}
+	for i, form := range message.Translation.Forms {
+		if form == "" {
+			continue
+		}
+		form = strings.Replace(strings.Replace(form, "%Ln", "%n", -1), "%n", Prefix+"count"+Suffix, -1)
+		d.resource(context, name+"_"+categories[i], form, message.ExtraComment)
+	}
+}
+
+// numerusCategories lists the plural categories of a language in the order of the numerus
+// forms of Qt Linguist. Languages not listed distinguish one and other as in English.
+func numerusCategories(lang Language) []string {
+	switch lang.Base() {
+	case "fa", "hu", "id", "ja", "ko", "ms", "th", "tr", "vi", "zh":
+		return []string{"other"}
+	case "be", "bs", "hr", "pl", "ru", "sr", "uk":
+		return []string{"one", "few", "many"}
+	case "cs", "lt", "ro", "sk":
+		return []string{"one", "few", "other"}
+	case "ga":
+		return []string{"one", "two", "other"}
+	case "lv":
+		return []string{"one", "other", "zero"}
+	case "sl":
+		return []string{"one", "two", "few", "other"}
+	case "ar":
+		return []string{"zero", "one", "two", "few", "many", "other"}
+	}
+	return []string{"one", "other"}
+}
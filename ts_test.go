@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+const tsMessages = `<?xml version="1.0" encoding="utf-8"?>
+<!DOCTYPE TS>
+<TS version="2.1" language="pl_PL" sourcelanguage="en">
+<context>
+	<name>MainWindow</name>
+	<message>
+		<location filename="../src/mainwindow.cpp" line="42"/>
+		<source>Open</source>
+		<extracomment>Opens a file of the disk</extracomment>
+		<translation>Otwórz</translation>
+	</message>
+	<message numerus="yes">
+		<source>%n file(s)</source>
+		<translation>
+			<numerusform>%n plik</numerusform>
+			<numerusform>%n pliki</numerusform>
+			<numerusform>%Ln plików</numerusform>
+		</translation>
+	</message>
+	<message>
+		<source>Close</source>
+		<translation type="unfinished"></translation>
+	</message>
+	<message>
+		<source>Print</source>
+		<translation type="vanished">Drukuj</translation>
+	</message>
+</context>
+<context>
+	<name>Dialog</name>
+	<message id="dialog.cancel">
+		<source>Cancel</source>
+		<translation type="unfinished">Anuluj</translation>
+	</message>
+</context>
+</TS>`
+
+func TestDecodeTS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json":             &fstest.MapFile{Data: []byte(`{"title": "Editor"}`)},
+		"translations/app.ts": &fstest.MapFile{Data: []byte(tsMessages)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("pl-PL")(key, params...)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("context", fn("MainWindow.Open", "Otwórz"))
+	t.Run("id", fn("dialog.cancel", "Anuluj"))
+	t.Run("plural one", fn("MainWindow.%n file(s)_one", "1 plik", "count", 1))
+	t.Run("plural few", fn("MainWindow.%n file(s)_few", "3 pliki", "count", 3))
+	t.Run("plural many", fn("MainWindow.%n file(s)_many", "5 plików", "count", 5))
+
+	for _, key := range []string{"MainWindow.Close", "MainWindow.Print"} {
+		if _, err := translations.GenerateTranslate("pl-PL")(key); err == nil {
+			t.Fatalf("expected message %q to be skipped", key)
+		}
+	}
+	if metadata, ok := translations.Metadata("MainWindow.Open"); !ok || metadata.Description != "Opens a file of the disk" {
+		t.Fatalf("expected extra comment as description, got %+v", metadata)
+	}
+
+	validate := func(name string, data string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fstest.MapFS{
+				"en.json": &fstest.MapFile{Data: []byte(`{"title": "Editor"}`)},
+				name:      &fstest.MapFile{Data: []byte(data)},
+			}, "en")
+			if len(issues) == 0 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("invalid root", validate("app_de.ts", `<resources/>`, "invalid ts file, must consist of a TS element"))
+	t.Run("invalid language", validate("app.ts", `<TS language="german"></TS>`, `invalid language "german", allowed are only two letter codes and private use tags`))
+	t.Run("undeclared language", validate("app.ts", `<TS></TS>`, `invalid language "app", allowed are only two letter codes and private use tags`))
+	t.Run("numerus forms", validate("app_de.ts", `<TS><context><name>A</name><message numerus="yes"><source>%n</source><translation><numerusform>a</numerusform><numerusform>b</numerusform><numerusform>c</numerusform></translation></message></context></TS>`, `invalid plural, 3 numerus forms exceed the 2 plural categories of "de"`))
+}
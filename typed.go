@@ -0,0 +1,88 @@
+//go:build go1.18
+// +build go1.18
+
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// TranslateParams translates the key into the language as the function returned by
+// GenerateTranslate, passing the exported fields of the parameter struct as parameters, e.g.
+//
+//	type OrderParams struct {
+//		ID    string
+//		Total float64 `i18n:"amount"`
+//	}
+//	i18n.TranslateParams(trl, "de", "order.placed", OrderParams{ID: "42", Total: 9.5})
+//
+// Fields are named by their "i18n" tag if given, else by their name with the leading upper
+// case letters lowered, e.g. "id" for ID and "orderID" for OrderID. Fields tagged "-" are
+// skipped, embedded structs contribute their fields.
+func TranslateParams[P interface{}](trl Translations, lang string, key string, params P) (template.HTML, error) {
+	values, err := structParams(reflect.ValueOf(params))
+	if err != nil {
+		return "", err
+	}
+	return trl.GenerateTranslate(lang)(key, values...)
+}
+
+// structParams lists the names and values of the fields of the struct as parameters
+func structParams(v reflect.Value) ([]interface{}, error) {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("parameters must be a struct, got %s", v.Kind())
+	}
+
+	var params []interface{}
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("i18n")
+		if tag == "-" {
+			continue
+		}
+
+		if field.Anonymous && tag == "" && reflect.Indirect(v.Field(i)).Kind() == reflect.Struct {
+			embedded, err := structParams(v.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, embedded...)
+			continue
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = paramName(field.Name)
+		}
+		params = append(params, name, v.Field(i).Interface())
+	}
+	return params, nil
+}
+
+// paramName lowers the leading upper case letters of the field name, keeping the last
+// one if followed by a lower case letter, e.g. "urlPath" for URLPath
+func paramName(field string) string {
+	runes := []rune(field)
+	n := 0
+	for n < len(runes) && unicode.IsUpper(runes[n]) {
+		n++
+	}
+	if n > 1 && n < len(runes) && unicode.IsLower(runes[n]) {
+		n--
+	}
+	return strings.ToLower(string(runes[:n])) + string(runes[n:])
+}
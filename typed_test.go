@@ -0,0 +1,66 @@
+//go:build go1.18
+// +build go1.18
+
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+type orderParams struct {
+	ID       string
+	Total    float64 `i18n:"amount"`
+	Internal string  `i18n:"-"`
+	customer
+}
+
+type customer struct {
+	CustomerName string
+}
+
+func TestTranslateParams(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"placed": "order {{id}} of {{customerName}} over {{amount}}", "missing": "{{unknown}}"}`)},
+	}
+
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params := orderParams{ID: "42", Total: 9.5, Internal: "secret", customer: customer{CustomerName: "Bob"}}
+	got, err := TranslateParams(translations, "en", "placed", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "order 42 of Bob over 9.5"; string(got) != expected {
+		t.Fatalf("expected %q, got %q", expected, got)
+	}
+
+	if _, err := TranslateParams(translations, "en", "placed", &params); err != nil {
+		t.Fatalf("expected pointers to structs to be accepted, got %v", err)
+	}
+	if _, err := TranslateParams(translations, "en", "missing", params); err == nil {
+		t.Fatal("expected error for intermediate without field")
+	}
+	if _, err := TranslateParams(translations, "en", "placed", "42"); err == nil {
+		t.Fatal("expected error for parameters not being a struct")
+	}
+}
+
+func TestParamName(t *testing.T) {
+	fn := func(field string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := paramName(field); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("capitalized", fn("Name", "name"))
+	t.Run("initialism", fn("ID", "id"))
+	t.Run("trailing initialism", fn("OrderID", "orderID"))
+	t.Run("leading initialism", fn("URLPath", "urlPath"))
+	t.Run("lower case", fn("count", "count"))
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+)
+
+// catalogVersion hashes the languages, keys and messages of the stores
+func catalogVersion(translations map[Language]Store) string {
+	h := sha256.New()
+	for _, lang := range sortedLanguages(translations) {
+		store := translations[lang]
+		h.Write([]byte(lang))
+		h.Write([]byte{0})
+		for _, key := range sortedKeys(store) {
+			h.Write([]byte(key))
+			h.Write([]byte{0})
+			h.Write([]byte(store[key].Message))
+			h.Write([]byte{0})
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// Version returns a hash of the loaded translations, changing whenever any language, key
+// or message changes. It may be used for cache busting of client side translations.
+func (trl Translations) Version() string {
+	return trl.version
+}
+
+// BundleHandler serves the translations of a language as nested JSON object for frontends,
+// in the language of the query parameter "lang" or as negotiated by RequestLanguage. The
+// version is exposed as ETag for revalidation. Requests denoting the current version by the
+// query parameter "v" are cached as immutable.
+func (trl Translations) BundleHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trl.serveBundle(w, r)
+	})
+}
+
+// BundleHandler serves the bundle of the current translations, see Translations.BundleHandler
+func (c *Catalog) BundleHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Translations().serveBundle(w, r)
+	})
+}
+
+// serveBundle writes the translations of the requested language
+func (trl Translations) serveBundle(w http.ResponseWriter, r *http.Request) {
+	lang := trl.RequestLanguage(r)
+	if requested := r.URL.Query().Get("lang"); requested != "" {
+		lang = trl.servedLanguage(requested)
+	}
+
+	etag := `"` + trl.version + "-" + string(lang) + `"`
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Vary", "Accept-Language")
+	if r.URL.Query().Get("v") == trl.version {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Language", lang.Tag())
+	json.NewEncoder(w).Encode(trl.Tree(lang, ""))
+}
@@ -0,0 +1,75 @@
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestVersion(t *testing.T) {
+	load := func(de string) Translations {
+		fsys := fstest.MapFS{
+			"en.json": &fstest.MapFile{Data: []byte(`{"a": "hello"}`)},
+			"de.json": &fstest.MapFile{Data: []byte(de)},
+		}
+		translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return translations
+	}
+
+	version := load(`{"a": "hallo"}`).Version()
+	if version == "" || len(version) != 32 {
+		t.Fatalf("unexpected version %q", version)
+	}
+	if other := load(`{"a": "hallo"}`).Version(); other != version {
+		t.Fatalf("expected stable version, got %q and %q", version, other)
+	}
+	if other := load(`{"a": "servus"}`).Version(); other == version {
+		t.Fatal("expected version to change with a message")
+	}
+	if other := load(`{"b": "hallo"}`).Version(); other == version {
+		t.Fatal("expected version to change with a key")
+	}
+}
+
+func TestBundleHandler(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": &fstest.MapFile{Data: []byte(`{"a": {"b": "hello"}}`)},
+		"de.json": &fstest.MapFile{Data: []byte(`{"a": {"b": "hallo"}}`)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := NewCatalog(translations).BundleHandler()
+
+	serve := func(target string, header http.Header) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for name, values := range header {
+			r.Header[name] = values
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve("/bundle", http.Header{"Accept-Language": {"de-DE"}})
+	if w.Code != 200 || strings.TrimSpace(w.Body.String()) != `{"a":{"b":"hallo"}}` || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("unexpected response %d %q %v", w.Code, w.Body.String(), w.Header())
+	}
+
+	etag := w.Header().Get("ETag")
+	if w := serve("/bundle", http.Header{"Accept-Language": {"de"}, "If-None-Match": {etag}}); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Fatalf("expected not modified, got %d", w.Code)
+	}
+	if w := serve("/bundle?lang=en", http.Header{"If-None-Match": {etag}}); w.Code != 200 || strings.TrimSpace(w.Body.String()) != `{"a":{"b":"hello"}}` {
+		t.Fatalf("unexpected response of other language %d %q", w.Code, w.Body.String())
+	}
+	if w := serve("/bundle?v="+translations.Version(), nil); !strings.Contains(w.Header().Get("Cache-Control"), "immutable") {
+		t.Fatalf("expected immutable response of current version, got %v", w.Header())
+	}
+}
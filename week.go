@@ -0,0 +1,207 @@
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// likelyRegions are the regions assumed for languages not denoting a region when
+// looking up regional data. Languages spoken mostly in regions sharing the defaults
+// of the regional data are not listed.
+var likelyRegions = map[Language]string{
+	"ar": "eg",
+	"bg": "bg",
+	"bn": "bd",
+	"cs": "cz",
+	"da": "dk",
+	"de": "de",
+	"el": "gr",
+	"en": "us",
+	"es": "es",
+	"et": "ee",
+	"fa": "ir",
+	"fi": "fi",
+	"fr": "fr",
+	"he": "il",
+	"hi": "in",
+	"hu": "hu",
+	"id": "id",
+	"is": "is",
+	"it": "it",
+	"ja": "jp",
+	"km": "kh",
+	"ko": "kr",
+	"lo": "la",
+	"lt": "lt",
+	"mr": "in",
+	"my": "mm",
+	"nb": "no",
+	"ne": "np",
+	"nl": "nl",
+	"nn": "no",
+	"no": "no",
+	"pl": "pl",
+	"ps": "af",
+	"pt": "br",
+	"ru": "ru",
+	"sk": "sk",
+	"sv": "se",
+	"ta": "in",
+	"te": "in",
+	"th": "th",
+	"ur": "pk",
+	"zh": "cn",
+}
+
+// firstDays are the first days of the week of regions not starting weeks on Monday following CLDR
+var firstDays = byRegion(map[time.Weekday][]string{
+	time.Sunday: {
+		"ag", "as", "bd", "br", "bs", "bt", "bw", "bz", "ca", "cn", "co", "dm", "do", "et", "gt", "gu",
+		"hk", "hn", "id", "il", "in", "jm", "jp", "ke", "kh", "kr", "la", "mh", "mm", "mo", "mt", "mx",
+		"mz", "ni", "np", "pa", "pe", "ph", "pk", "pr", "pt", "py", "sa", "sg", "sv", "th", "tt", "tw",
+		"um", "us", "ve", "vi", "ws", "ye", "za", "zw",
+	},
+	time.Saturday: {"ae", "af", "bh", "dj", "dz", "eg", "iq", "ir", "jo", "kw", "ly", "om", "qa", "sd", "sy"},
+	time.Friday:   {"mv"},
+})
+
+// byRegion inverts the regions listed per weekday
+func byRegion(regions map[time.Weekday][]string) map[string]time.Weekday {
+	days := make(map[string]time.Weekday)
+	for day, listed := range regions {
+		for _, region := range listed {
+			days[region] = day
+		}
+	}
+	return days
+}
+
+// weekends are the weekend days of regions not resting on Saturday and Sunday following CLDR
+var weekends = map[string][]time.Weekday{
+	"ae": {time.Friday, time.Saturday},
+	"af": {time.Thursday, time.Friday},
+	"bh": {time.Friday, time.Saturday},
+	"dz": {time.Friday, time.Saturday},
+	"eg": {time.Friday, time.Saturday},
+	"il": {time.Friday, time.Saturday},
+	"in": {time.Sunday},
+	"iq": {time.Friday, time.Saturday},
+	"ir": {time.Friday},
+	"jo": {time.Friday, time.Saturday},
+	"kw": {time.Friday, time.Saturday},
+	"ly": {time.Friday, time.Saturday},
+	"om": {time.Friday, time.Saturday},
+	"qa": {time.Friday, time.Saturday},
+	"sa": {time.Friday, time.Saturday},
+	"sd": {time.Friday, time.Saturday},
+	"sy": {time.Friday, time.Saturday},
+	"ug": {time.Sunday},
+	"ye": {time.Friday, time.Saturday},
+}
+
+// region returns the region denoted by the language or the region the language is mostly spoken in
+func region(lang Language) string {
+	if region := lang.Region(); region != "" {
+		return strings.ToLower(region)
+	}
+	return likelyRegions[lang.Base()]
+}
+
+// FirstDayOfWeek returns the day weeks start on in the region of the language, e.g. Sunday
+// for "en-us" and Monday for "en-gb". Languages not denoting a region are assumed to be
+// spoken in their most populous region, e.g. the United States for "en".
+func FirstDayOfWeek(lang Language) time.Weekday {
+	if day, ok := firstDays[region(lang)]; ok {
+		return day
+	}
+	return time.Monday
+}
+
+// WeekendDays returns the days of the weekend in the region of the language in the order of
+// the week, e.g. Saturday and Sunday for "de" and Friday and Saturday for "he".
+// Regions are assumed like FirstDayOfWeek does.
+func WeekendDays(lang Language) []time.Weekday {
+	if days, ok := weekends[region(lang)]; ok {
+		return append([]time.Weekday(nil), days...)
+	}
+	return []time.Weekday{time.Saturday, time.Sunday}
+}
+
+// minimalDaysRegions require the first week of the year to have four days in the year
+// following ISO 8601, other regions count the week containing January 1 as first week
+var minimalDaysRegions = map[string]bool{
+	"ad": true, "an": true, "at": true, "ax": true, "be": true, "bg": true, "ch": true, "cz": true,
+	"de": true, "dk": true, "ee": true, "es": true, "fi": true, "fj": true, "fo": true, "fr": true,
+	"gb": true, "gf": true, "gg": true, "gi": true, "gp": true, "gr": true, "hu": true, "ie": true,
+	"im": true, "is": true, "it": true, "je": true, "li": true, "lt": true, "lu": true, "mc": true,
+	"mq": true, "nl": true, "no": true, "pl": true, "pt": true, "re": true, "ru": true, "se": true,
+	"sj": true, "sk": true, "sm": true, "va": true,
+}
+
+// weekLabels are the patterns of week labels per language, {w} denoting the week
+var weekLabels = map[Language]string{
+	"de": "KW {w}",
+	"en": "Week {w}",
+	"es": "semana {w}",
+	"fr": "semaine {w}",
+	"it": "settimana {w}",
+	"ja": "第{w}週",
+	"nl": "week {w}",
+	"pt": "semana {w}",
+	"zh": "第{w}周",
+}
+
+// WeekOfYear returns the week of the date of t and the year the week belongs to following
+// the rules of the region of the language. Weeks start on FirstDayOfWeek. In regions following
+// ISO 8601 the first week is the first having four days in the year, e.g. in Germany, elsewhere
+// it is the week containing January 1, e.g. in the United States. Regions are assumed like
+// FirstDayOfWeek does.
+func WeekOfYear(lang Language, t time.Time) (year, week int) {
+	first, minimalDays := FirstDayOfWeek(lang), 1
+	if minimalDaysRegions[region(lang)] {
+		minimalDays = 4
+	}
+
+	y, m, d := t.Date()
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	year = y
+	start := firstWeekStart(year, first, minimalDays)
+	if date.Before(start) {
+		year--
+		start = firstWeekStart(year, first, minimalDays)
+	} else if next := firstWeekStart(year+1, first, minimalDays); !date.Before(next) {
+		year++
+		start = next
+	}
+	return year, int(date.Sub(start).Hours())/(24*7) + 1
+}
+
+// firstWeekStart returns the first day of the first week of the year
+func firstWeekStart(year int, first time.Weekday, minimalDays int) time.Time {
+	january := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(january.Weekday()) - int(first) + 7) % 7
+	if 7-offset < minimalDays {
+		offset -= 7
+	}
+	return january.AddDate(0, 0, -offset)
+}
+
+// FormatWeek formats the week of the date of t in the language, e.g. "KW 7" in German or
+// "Week 7" in English, see WeekOfYear. Languages lacking labels are formatted in ISO 8601,
+// e.g. "2025-W07".
+func FormatWeek(lang Language, t time.Time) string {
+	year, week := WeekOfYear(lang, t)
+	for _, candidate := range append(languageCandidates(lang), lang.Base()) {
+		if label, ok := weekLabels[candidate]; ok {
+			return strings.Replace(label, "{w}", strconv.Itoa(week), 1)
+		}
+	}
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// formatWeek formats the week of t, weeks being independent of the time settings
+func formatWeek(lang Language, t time.Time, _ timeSettings) string {
+	return FormatWeek(lang, t)
+}
@@ -0,0 +1,92 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFirstDayOfWeek(t *testing.T) {
+	fn := func(lang Language, expected time.Weekday) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FirstDayOfWeek(lang); got != expected {
+				t.Fatalf("expected %s, got %s", expected, got)
+			}
+		}
+	}
+
+	t.Run("united states", fn("en-us", time.Sunday))
+	t.Run("united kingdom", fn("en-gb", time.Monday))
+	t.Run("likely region", fn("en", time.Sunday))
+	t.Run("german", fn("de", time.Monday))
+	t.Run("portuguese", fn("pt-pt", time.Sunday))
+	t.Run("egypt", fn("ar", time.Saturday))
+	t.Run("maldives", fn("dv-mv", time.Friday))
+	t.Run("upper case region", fn("en-US", time.Sunday))
+}
+
+func TestWeekendDays(t *testing.T) {
+	fn := func(lang Language, expected ...time.Weekday) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := WeekendDays(lang); !reflect.DeepEqual(got, expected) {
+				t.Fatalf("expected %v, got %v", expected, got)
+			}
+		}
+	}
+
+	t.Run("default", fn("de", time.Saturday, time.Sunday))
+	t.Run("hebrew", fn("he", time.Friday, time.Saturday))
+	t.Run("afghanistan", fn("ps", time.Thursday, time.Friday))
+	t.Run("iran", fn("fa", time.Friday))
+	t.Run("india", fn("hi", time.Sunday))
+	t.Run("saudi arabia", fn("ar-sa", time.Friday, time.Saturday))
+}
+
+func TestWeekOfYear(t *testing.T) {
+	fn := func(lang Language, date string, expectedYear, expectedWeek int) func(t *testing.T) {
+		return func(t *testing.T) {
+			ts, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if year, week := WeekOfYear(lang, ts); year != expectedYear || week != expectedWeek {
+				t.Fatalf("expected week %d of %d, got week %d of %d", expectedWeek, expectedYear, week, year)
+			}
+		}
+	}
+
+	t.Run("iso", fn("de", "2025-02-12", 2025, 7))
+	t.Run("iso previous year", fn("de", "2021-01-01", 2020, 53))
+	t.Run("iso next year", fn("en-gb", "2024-12-30", 2025, 1))
+	t.Run("us", fn("en-us", "2025-02-12", 2025, 7))
+	t.Run("us january 1", fn("en", "2021-01-01", 2021, 1))
+	t.Run("us next year", fn("en", "2020-12-27", 2021, 1))
+	t.Run("us last week", fn("en", "2020-12-26", 2020, 52))
+}
+
+func TestFormatWeek(t *testing.T) {
+	ts := time.Date(2025, time.February, 12, 12, 0, 0, 0, time.UTC)
+
+	fn := func(lang Language, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := FormatWeek(lang, ts); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("german", fn("de", "KW 7"))
+	t.Run("english", fn("en", "Week 7"))
+	t.Run("japanese", fn("ja", "第7週"))
+	t.Run("iso", fn("xx", "2025-W07"))
+
+	fsys := fstest.MapFS{"de.json": &fstest.MapFile{Data: []byte(`{"due": "Fällig in {{ts, week(zone=UTC)}}"}`)}}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("de")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := translations.GenerateTranslate("de")("due", "ts", ts); err != nil || got != "Fällig in KW 7" {
+		t.Fatalf("expected week format, got %q: %v", got, err)
+	}
+}
@@ -0,0 +1,175 @@
+package i18n
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// noLineStart lists the characters a line must not start with, e.g. closing
+// punctuation and small kana following the Japanese line breaking rules (kinsoku)
+const noLineStart = ")]}〕〉》」』】〙〗〟｠»、。，．・：；？！ー…‥ぁぃぅぇぉっゃゅょゎァィゥェォッャュョヮヵヶ々〻）］｝｣」"
+
+// noLineEnd lists the characters a line must not end with, e.g. opening punctuation
+const noLineEnd = "([{〔〈《「『【〘〖〝｟«（［｛｢"
+
+// Wrap breaks s into lines of at most width columns for fixed-width outputs, e.g. plain
+// text emails or terminals, following the line breaking rules of the language. Lines are
+// broken at spaces and after hyphens but never at no-break spaces, e.g. in "10 km" or
+// before "!" in French. Chinese and Japanese text is broken between any characters except
+// before closing and after opening punctuation, Korean text is broken at spaces only.
+// East asian wide characters occupy two columns. Words exceeding the width are split.
+// Existing line breaks are retained.
+func Wrap(lang Language, s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+
+	keepHangul := lang.Base() == "ko"
+	paragraphs := strings.Split(s, "\n")
+	for i, paragraph := range paragraphs {
+		paragraphs[i] = wrapParagraph(paragraph, width, keepHangul)
+	}
+	return strings.Join(paragraphs, "\n")
+}
+
+// wrapParagraph wraps a paragraph not containing line breaks
+func wrapParagraph(paragraph string, width int, keepHangul bool) string {
+	var lines []string
+	var line strings.Builder
+	lineWidth := 0
+	pending := "" // breakable spaces following the line, dropped if the line is broken
+
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		lineWidth, pending = 0, ""
+	}
+
+	for _, word := range splitWords(paragraph, keepHangul) {
+		text := strings.TrimRight(word, " \t")
+		spaces := word[len(text):]
+		textWidth := columns(text)
+
+		if lineWidth > 0 && lineWidth+columns(pending)+textWidth > width {
+			flush()
+		}
+		if lineWidth > 0 {
+			line.WriteString(pending)
+			lineWidth += columns(pending)
+		}
+
+		// split words exceeding the width
+		for lineWidth+textWidth > width {
+			end, w := 0, 0
+			for end < len(text) {
+				size := graphemeLength(text[end:])
+				gw := columns(text[end : end+size])
+				if lineWidth+w+gw > width && lineWidth+w > 0 {
+					break
+				}
+				end, w = end+size, w+gw
+			}
+			line.WriteString(text[:end])
+			text, textWidth = text[end:], textWidth-w
+			flush()
+		}
+
+		line.WriteString(text)
+		lineWidth += textWidth
+		pending = spaces
+	}
+	if line.Len() > 0 || len(lines) == 0 {
+		lines = append(lines, line.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitWords splits the paragraph at the line break opportunities. Every word includes
+// the breakable spaces following it.
+func splitWords(paragraph string, keepHangul bool) []string {
+	var words []string
+	start := 0
+	var previous rune
+	for i := 0; i < len(paragraph); {
+		size := graphemeLength(paragraph[i:])
+		r, _ := utf8.DecodeRuneInString(paragraph[i:])
+
+		if i > start && breaksBefore(previous, r, keepHangul) {
+			words = append(words, paragraph[start:i])
+			start = i
+		}
+		previous, _ = utf8.DecodeLastRuneInString(paragraph[i : i+size])
+		if previous == zeroWidthJoiner || extendsGrapheme(previous) {
+			previous = r
+		}
+		i += size
+	}
+	if start < len(paragraph) {
+		words = append(words, paragraph[start:])
+	}
+	return words
+}
+
+// breaksBefore reports whether a line may be broken between previous and r
+func breaksBefore(previous rune, r rune, keepHangul bool) bool {
+	switch {
+	case r == ' ' || r == '\t':
+		return false
+	case strings.ContainsRune(noLineStart, r) || strings.ContainsRune(noLineEnd, previous):
+		return false
+	case previous == ' ' || previous == '\t':
+		return true
+	case previous == '-' && unicode.IsLetter(r):
+		return true
+	}
+	return breaksWithin(previous, keepHangul) || breaksWithin(r, keepHangul)
+}
+
+// breaksWithin reports whether a line may be broken before and after r without spaces,
+// as in text written in Chinese or Japanese
+func breaksWithin(r rune, keepHangul bool) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) {
+		return true
+	}
+	if unicode.Is(unicode.Hangul, r) {
+		return !keepHangul
+	}
+	// ideographic punctuation, e.g. "。"
+	return r >= 0x3000 && r <= 0x303f || r >= 0xff01 && r <= 0xff60
+}
+
+// columns returns the number of columns s occupies in fixed-width outputs
+func columns(s string) int {
+	n := 0
+	for i := 0; i < len(s); {
+		size := graphemeLength(s[i:])
+		r, _ := utf8.DecodeRuneInString(s[i:])
+		if wide(r) {
+			n += 2
+		} else {
+			n++
+		}
+		i += size
+	}
+	return n
+}
+
+// wide reports whether r is an east asian wide or fullwidth character
+func wide(r rune) bool {
+	switch {
+	case r >= 0x1100 && r <= 0x115f,
+		r >= 0x2e80 && r <= 0x303e,
+		r >= 0x3041 && r <= 0xa4cf,
+		r >= 0xac00 && r <= 0xd7a3,
+		r >= 0xf900 && r <= 0xfaff,
+		r >= 0xfe30 && r <= 0xfe4f,
+		r >= 0xff00 && r <= 0xff60,
+		r >= 0xffe0 && r <= 0xffe6,
+		r >= 0x1f300 && r <= 0x1f64f,
+		r >= 0x1f900 && r <= 0x1f9ff,
+		r >= 0x20000 && r <= 0x3fffd:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package i18n
+
+import "testing"
+
+func TestWrap(t *testing.T) {
+	fn := func(lang Language, s string, width int, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			if got := Wrap(lang, s, width); got != expected {
+				t.Fatalf("expected %q, got %q", expected, got)
+			}
+		}
+	}
+
+	t.Run("short", fn("en", "Hello world", 20, "Hello world"))
+	t.Run("spaces", fn("en", "The quick brown fox jumps", 10, "The quick\nbrown fox\njumps"))
+	t.Run("hyphen", fn("en", "state-of-the-art", 10, "state-of-\nthe-art"))
+	t.Run("no-break space", fn("fr", "Bienvenue à Paris\u00a0!", 16, "Bienvenue à\nParis\u00a0!"))
+	t.Run("long word", fn("de", "Donaudampfschifffahrt", 8, "Donaudam\npfschiff\nfahrt"))
+	t.Run("paragraphs", fn("en", "one two\nthree four", 7, "one two\nthree\nfour"))
+	t.Run("japanese", fn("ja", "今日は良い天気です。", 8, "今日は良\nい天気で\nす。"))
+	t.Run("kinsoku", fn("ja", "「東京」です", 6, "「東\n京」で\nす"))
+	t.Run("korean", fn("ko", "안녕하세요 여러분", 10, "안녕하세요\n여러분"))
+	t.Run("emoji", fn("en", "👩\u200d💻👩\u200d💻👩\u200d💻", 4, "👩\u200d💻👩\u200d💻\n👩\u200d💻"))
+	t.Run("unlimited", fn("en", "Hello world", 0, "Hello world"))
+}
@@ -0,0 +1,557 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// yamlKind is the kind of a YAML value
+type yamlKind int
+
+const (
+	yamlNull yamlKind = iota
+	yamlScalar
+	yamlMapping
+	yamlSequence
+)
+
+// yamlValue is a value of a YAML document
+type yamlValue struct {
+	kind    yamlKind
+	scalar  string
+	entries []yamlEntry
+	// items are the scalar items of a sequence, other items are skipped
+	items []string
+}
+
+// yamlEntry is an entry of a YAML mapping
+type yamlEntry struct {
+	key   string
+	value yamlValue
+}
+
+// pluralCategories are the keys of mappings holding the plural forms of a message as in Rails
+var pluralCategories = map[string]bool{"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true}
+
+// yamlLanguage derives the language of a YAML file named by the convention of Rails, e.g. "de"
+// for "devise.de.yml". The language of the root key takes precedence.
+func yamlLanguage(name string, defaultLanguage Language) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// decodeYAML decodes a YAML language file, nested mappings being combined into complete keys
+// as nested objects of JSON. Following Rails, a single root key denoting a language declares
+// the language of the file, e.g. "de:", interpolations like %{name} are converted into
+// intermediates and mappings of plural categories are suffixed as in i18next, e.g.
+// "inbox.messages_one". Sequences and null values are skipped, such that locale files of
+// Rails load unmodified. Only block mappings and scalars are supported, anchors and flow
+// mappings are not.
+func (d *decoder) decodeYAML() (Store, error) {
+	entries, err := d.parseYAML()
+	if err != nil {
+		return nil, err
+	}
+
+	// the root key declares the language unless the file is named by a different one
+	if len(entries) == 1 && entries[0].value.kind == yamlMapping {
+		lang := normalizeLanguage(entries[0].key)
+		if d.rules.valid(lang) && (!d.rules.valid(d.lang) || lang == d.lang) {
+			d.lang, entries = lang, entries[0].value.entries
+		}
+	}
+
+	var k Key
+	if err := d.mapping(k, "", entries, 1); err != nil {
+		return nil, err
+	}
+	d.inherit()
+	return d.store, nil
+}
+
+// yamlDocument flattens the mapping of a file below the root key
+func (d *decoder) yamlDocument(rootKey Key, source string, depth int) error {
+	entries, err := d.parseYAML()
+	if err != nil {
+		return err
+	}
+	return d.mapping(rootKey, source, entries, depth)
+}
+
+// parseYAML parses the file which must consist of a mapping
+func (d *decoder) parseYAML() ([]yamlEntry, error) {
+	data, err := io.ReadAll(d.r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := yamlParser{lines: strings.Split(string(data), "\n")}
+	entries, err := p.mapping(0)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("invalid translation file, must be a mapping")
+	}
+	return entries, nil
+}
+
+// mapping flattens the entries of a YAML mapping below the root key as object does for JSON
+func (d *decoder) mapping(rootKey Key, source string, entries []yamlEntry, depth int) error {
+	if d.limits.MaxDepth > 0 && depth > d.limits.MaxDepth {
+		return fmt.Errorf("nesting exceeds maximum depth of %d with key %q", d.limits.MaxDepth, rootKey)
+	}
+
+	for _, entry := range entries {
+		// includes merge the mapping of another file into the mapping
+		if entry.key == IncludeKey {
+			if entry.value.kind != yamlScalar {
+				d.report(rootKey, "invalid include, must be the path of a file")
+				continue
+			}
+			if err := d.include(rootKey, source, entry.value.scalar, depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if entry.key == ExtendsKey {
+			if entry.value.kind != yamlScalar {
+				d.report(rootKey, "invalid extension, must be the path of a file")
+				continue
+			}
+			if err := d.extend(rootKey, entry.value.scalar, depth); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if entry.key == ConstantsKey {
+			d.yamlConstants(rootKey, entry.value, depth)
+			continue
+		}
+
+		key := d.key(rootKey, entry.key)
+		source := source + "\x00" + entry.key
+
+		if entry.key == "" {
+			d.report(rootKey, "invalid key, should not be empty")
+			continue
+		}
+		if d.limits.MaxKeyLength > 0 && len(key) > d.limits.MaxKeyLength {
+			d.report(rootKey, fmt.Sprintf("key exceeds maximum length of %d bytes", d.limits.MaxKeyLength))
+			continue
+		}
+
+		switch entry.value.kind {
+		case yamlScalar:
+			d.message(key, source, railsInterpolations(entry.value.scalar))
+
+		case yamlMapping:
+			if plurals := pluralEntries(entry); plurals != nil {
+				if err := d.mapping(rootKey, source, plurals, depth); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.mapping(key, source, entry.value.entries, depth+1); err != nil {
+				return err
+			}
+
+		default:
+			// sequences and null values are skipped, as Rails locale files contain them for
+			// formatting, e.g. "date.day_names: [...]" and "date.order: [:year, :month, :day]"
+		}
+	}
+	return nil
+}
+
+// yamlConstants decodes the constants declared by the top-level mapping
+func (d *decoder) yamlConstants(rootKey Key, value yamlValue, depth int) {
+	constants := make(map[string]string, len(value.entries))
+	for _, entry := range value.entries {
+		if entry.value.kind != yamlScalar {
+			constants = nil
+			break
+		}
+		constants[entry.key] = entry.value.scalar
+	}
+
+	if value.kind != yamlMapping || constants == nil {
+		d.report(rootKey, "invalid constants, must be an object of strings")
+		return
+	}
+	if depth != 1 {
+		d.report(rootKey, "invalid constants, must be declared at the top level")
+		return
+	}
+	d.declareConstants(constants)
+}
+
+// pluralEntries renames the plural forms of the entry by suffixing its key with their
+// category, returning nil if the entry does not map plural categories to messages
+func pluralEntries(entry yamlEntry) []yamlEntry {
+	other := false
+	for _, form := range entry.value.entries {
+		if !pluralCategories[form.key] || form.value.kind != yamlScalar {
+			return nil
+		}
+		other = other || form.key == "other"
+	}
+	if !other {
+		return nil
+	}
+
+	plurals := make([]yamlEntry, len(entry.value.entries))
+	for i, form := range entry.value.entries {
+		plurals[i] = yamlEntry{key: entry.key + "_" + form.key, value: form.value}
+	}
+	return plurals
+}
+
+// railsInterpolations converts interpolations of Rails like %{name} into intermediates.
+// Interpolations escaped as %%{name} are kept.
+func railsInterpolations(message string) string {
+	if !strings.Contains(message, "%{") {
+		return message
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.Index(message, "%{")
+		if i == -1 {
+			break
+		}
+		end := strings.Index(message[i:], "}")
+		if end == -1 {
+			break
+		}
+		if i > 0 && message[i-1] == '%' {
+			b.WriteString(message[:i-1] + message[i:i+end+1])
+		} else {
+			b.WriteString(message[:i] + Prefix + message[i+2:i+end] + Suffix)
+		}
+		message = message[i+end+1:]
+	}
+	b.WriteString(message)
+	return b.String()
+}
+
+// yamlParser parses the block mappings of a YAML document line by line
+type yamlParser struct {
+	lines []string
+	// i is the index of the next line, line the index of the entry being parsed
+	i, line int
+}
+
+// errorf reports a syntax error in the entry being parsed
+func (p *yamlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("invalid yaml in line %d, %s", p.line+1, fmt.Sprintf(format, args...))
+}
+
+// skipBlank skips blank lines, comments and document markers
+func (p *yamlParser) skipBlank() {
+	for ; p.i < len(p.lines); p.i++ {
+		line := strings.TrimSpace(p.lines[p.i])
+		if line != "" && line[0] != '#' && line != "---" && line != "..." && line[0] != '%' {
+			return
+		}
+	}
+}
+
+// indentation counts the leading spaces of the line
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// mapping parses the entries of a block mapping indented by indent
+func (p *yamlParser) mapping(indent int) ([]yamlEntry, error) {
+	var entries []yamlEntry
+	for {
+		p.skipBlank()
+		if p.i >= len(p.lines) {
+			return entries, nil
+		}
+
+		line := strings.TrimRight(p.lines[p.i], "\r")
+		n := indentation(line)
+		if n < indent {
+			return entries, nil
+		}
+		p.line = p.i
+		if n > indent {
+			return nil, p.errorf("unexpected indentation")
+		}
+		if line[n] == '\t' {
+			return nil, p.errorf("tabs must not be used for indentation")
+		}
+
+		key, rest, err := p.key(line[n:])
+		if err != nil {
+			return nil, err
+		}
+		p.i++
+
+		value, err := p.value(indent, rest)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, yamlEntry{key: key, value: value})
+	}
+}
+
+// key splits a mapping entry into its key and the remainder following the colon
+func (p *yamlParser) key(entry string) (string, string, error) {
+	if entry[0] == '-' && (len(entry) == 1 || entry[1] == ' ') {
+		return "", "", p.errorf("unexpected sequence item, expected key: value")
+	}
+
+	if entry[0] == '"' || entry[0] == '\'' {
+		key, rest, err := p.quoted(entry)
+		if err != nil {
+			return "", "", err
+		}
+		rest = strings.TrimLeft(rest, " ")
+		if rest == "" || rest[0] != ':' {
+			return "", "", p.errorf("expected colon after key %q", key)
+		}
+		return key, rest[1:], nil
+	}
+
+	for i := 0; i < len(entry); i++ {
+		if entry[i] == ':' && (i+1 == len(entry) || entry[i+1] == ' ' || entry[i+1] == '\t') {
+			return strings.TrimSpace(entry[:i]), entry[i+1:], nil
+		}
+	}
+	return "", "", p.errorf("expected key: value")
+}
+
+// value parses the value following the colon of a mapping entry indented by indent
+func (p *yamlParser) value(indent int, rest string) (yamlValue, error) {
+	rest = strings.TrimSpace(rest)
+	if i := strings.Index(rest, " #"); i != -1 && rest[0] != '"' && rest[0] != '\'' {
+		rest = strings.TrimSpace(rest[:i])
+	}
+	if strings.HasPrefix(rest, "#") {
+		rest = ""
+	}
+
+	switch {
+	case rest == "":
+		p.skipBlank()
+		if p.i >= len(p.lines) {
+			return yamlValue{}, nil
+		}
+		next := strings.TrimRight(p.lines[p.i], "\r")
+		n := indentation(next)
+		if n >= indent && next[n] == '-' && (len(next) == n+1 || next[n+1] == ' ') {
+			items, err := p.sequence(indent)
+			return yamlValue{kind: yamlSequence, items: items}, err
+		}
+		if n > indent {
+			entries, err := p.mapping(n)
+			return yamlValue{kind: yamlMapping, entries: entries}, err
+		}
+		return yamlValue{}, nil
+
+	case rest[0] == '|' || rest[0] == '>':
+		return yamlValue{kind: yamlScalar, scalar: p.block(indent, rest)}, nil
+
+	case rest[0] == '"' || rest[0] == '\'':
+		scalar, remainder, err := p.quoted(rest)
+		if err != nil {
+			return yamlValue{}, err
+		}
+		if remainder = strings.TrimSpace(remainder); remainder != "" && remainder[0] != '#' {
+			return yamlValue{}, p.errorf("unexpected %q after quoted value", remainder)
+		}
+		return yamlValue{kind: yamlScalar, scalar: scalar}, nil
+
+	case rest[0] == '[':
+		if !strings.HasSuffix(rest, "]") {
+			return yamlValue{}, p.errorf("flow sequences must end in the same line")
+		}
+		items, err := p.flowSequence(rest[1 : len(rest)-1])
+		return yamlValue{kind: yamlSequence, items: items}, err
+
+	case rest[0] == '{':
+		return yamlValue{}, p.errorf("flow mappings are not supported")
+
+	case rest[0] == '&' || rest[0] == '*':
+		return yamlValue{}, p.errorf("anchors and aliases are not supported")
+
+	case rest == "~" || rest == "null" || rest == "Null" || rest == "NULL":
+		return yamlValue{}, nil
+	}
+	return yamlValue{kind: yamlScalar, scalar: rest}, nil
+}
+
+// quoted parses the quoted scalar s starts with, returning the remainder following it
+func (p *yamlParser) quoted(s string) (string, string, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote == '\'' && s[i] == '\'' && i+1 < len(s) && s[i+1] == '\'':
+			i++
+		case s[i] == quote:
+			if quote == '\'' {
+				return strings.Replace(s[1:i], "''", "'", -1), s[i+1:], nil
+			}
+			unquoted, err := strconv.Unquote(strings.Replace(s[:i+1], `\/`, "/", -1))
+			if err != nil {
+				return "", "", p.errorf("invalid escape sequence in %s", s[:i+1])
+			}
+			return unquoted, s[i+1:], nil
+		}
+	}
+	return "", "", p.errorf("quoted scalars must end in the same line")
+}
+
+// block parses a literal (|) or folded (>) block scalar of an entry indented by indent.
+// The header may denote the chomping of trailing line breaks, - stripping and + keeping them.
+func (p *yamlParser) block(indent int, header string) string {
+	var lines []string
+	blockIndent := -1
+	for ; p.i < len(p.lines); p.i++ {
+		line := strings.TrimRight(p.lines[p.i], "\r")
+		if strings.TrimSpace(line) == "" {
+			lines = append(lines, "")
+			continue
+		}
+		n := indentation(line)
+		if n <= indent {
+			break
+		}
+		if blockIndent == -1 {
+			blockIndent = n
+		}
+		if n < blockIndent {
+			n = blockIndent
+			line = strings.Repeat(" ", n-indentation(line)) + line
+		}
+		lines = append(lines, line[blockIndent:])
+	}
+
+	trailing := 0
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines, trailing = lines[:len(lines)-1], trailing+1
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	for i, line := range lines {
+		switch {
+		case header[0] == '|':
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+		case line == "":
+			b.WriteByte('\n')
+		case i > 0 && lines[i-1] != "":
+			if line[0] == ' ' || lines[i-1][0] == ' ' {
+				b.WriteByte('\n')
+			} else {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(line)
+	}
+
+	switch {
+	case strings.Contains(header, "-"):
+	case strings.Contains(header, "+"):
+		b.WriteString(strings.Repeat("\n", trailing+1))
+	default:
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// sequence parses the scalar items of a block sequence of an entry indented by indent,
+// skipping nested items
+func (p *yamlParser) sequence(indent int) ([]string, error) {
+	var items []string
+	itemIndent := -1
+	for ; p.i < len(p.lines); p.i++ {
+		line := strings.TrimRight(p.lines[p.i], "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || trimmed[0] == '#' {
+			continue
+		}
+		n := indentation(line)
+		if n < indent || n == indent && line[n] != '-' {
+			return items, nil
+		}
+		if itemIndent == -1 {
+			itemIndent = n
+		}
+		if n != itemIndent || line[n] != '-' {
+			continue
+		}
+
+		p.line = p.i
+		item, err := p.scalar(strings.TrimSpace(line[n+1:]))
+		if err != nil {
+			return nil, err
+		}
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// flowSequence parses the scalar items of a flow sequence within the brackets, e.g. "en, de"
+func (p *yamlParser) flowSequence(s string) ([]string, error) {
+	var items []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		var item string
+		if s[0] == '"' || s[0] == '\'' {
+			var err error
+			if item, s, err = p.quoted(s); err != nil {
+				return nil, err
+			}
+			s = strings.TrimSpace(s)
+			if s != "" && s[0] != ',' {
+				return nil, p.errorf("unexpected %q after quoted item", s)
+			}
+		} else {
+			end := strings.IndexByte(s, ',')
+			if end == -1 {
+				end = len(s)
+			}
+			item, s = strings.TrimSpace(s[:end]), s[end:]
+		}
+		items = append(items, item)
+		s = strings.TrimPrefix(s, ",")
+	}
+	return items, nil
+}
+
+// scalar parses the item of a sequence, being empty for items other than scalars
+func (p *yamlParser) scalar(s string) (string, error) {
+	switch {
+	case s == "" || s[0] == '#' || s[0] == '{' || s[0] == '[' || s[0] == '|' || s[0] == '>':
+		return "", nil
+	case s[0] == '-' && (len(s) == 1 || s[1] == ' '):
+		// nested sequences are skipped
+		return "", nil
+	case s[0] == '"' || s[0] == '\'':
+		item, _, err := p.quoted(s)
+		return item, err
+	}
+	if i := strings.Index(s, " #"); i != -1 {
+		s = strings.TrimSpace(s[:i])
+	}
+	if _, _, err := p.key(s); err == nil {
+		// items being mappings are skipped
+		return "", nil
+	}
+	return s, nil
+}
@@ -0,0 +1,182 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+const railsLocale = `# German translations
+---
+de:
+  greeting: "Hallo %{name}!"
+  farewell: 'Tschüss, ''%{name}'''
+  escaped: 100%%{percent}
+  nav:
+    home: Startseite # the landing page
+    "about.us": Über uns
+  inbox:
+    messages:
+      one: Eine Nachricht
+      other: "%{count} Nachrichten"
+  terms: |
+    Erste Zeile
+    Zweite Zeile
+  notice: >-
+    Ein langer
+    Hinweis
+
+    in Absätzen
+`
+
+func TestDecodeYAML(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.yml":                 &fstest.MapFile{Data: []byte("greeting: Hello %{name}!\n")},
+		"config/locales/app.yml": &fstest.MapFile{Data: []byte(railsLocale)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(lang string, key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate(lang)(key, params...)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("file name", fn("en", "greeting", "Hello Anna!", "name", "Anna"))
+	t.Run("root key", fn("de", "greeting", "Hallo Anna!", "name", "Anna"))
+	t.Run("single quoted", fn("de", "farewell", "Tschüss, 'Anna'", "name", "Anna"))
+	t.Run("escaped interpolation", fn("de", "escaped", "100%{percent}"))
+	t.Run("nested", fn("de", "nav.home", "Startseite"))
+	t.Run("quoted key", fn("de", "nav.about.us", "Über uns"))
+	t.Run("plural one", fn("de", "inbox.messages_one", "Eine Nachricht"))
+	t.Run("plural other", fn("de", "inbox.messages_other", "3 Nachrichten", "count", 3))
+	t.Run("literal block", fn("de", "terms", "Erste Zeile\nZweite Zeile\n"))
+	t.Run("folded block", fn("de", "notice", "Ein langer Hinweis\nin Absätzen"))
+
+	// sequences and null values of Rails locale files are skipped
+	if issues := Validate(fstest.MapFS{
+		"en.yml": &fstest.MapFile{Data: []byte("greeting: Hello\n")},
+		"de.yml": &fstest.MapFile{Data: []byte("de:\n  greeting: Hallo\n  date:\n    day_names: [Sonntag, Montag]\n    month_names:\n    - ~\n    - Januar\n  empty:\n")},
+	}, "en"); len(issues) != 0 {
+		t.Fatalf("expected sequences and null values to be skipped, got %v", issues)
+	}
+
+	validate := func(data string, expected string) func(t *testing.T) {
+		return func(t *testing.T) {
+			issues := Validate(fstest.MapFS{"en.yaml": &fstest.MapFile{Data: []byte(data)}}, "en")
+			if len(issues) == 0 || issues[0].Message != expected {
+				t.Fatalf("expected issue %q, got %v", expected, issues)
+			}
+		}
+	}
+
+	t.Run("indentation", validate("a: b\n  c: d\n", "invalid yaml in line 2, unexpected indentation"))
+	t.Run("missing colon", validate("a: b\nc\n", "invalid yaml in line 2, expected key: value"))
+	t.Run("unterminated quote", validate(`a: "b`, "invalid yaml in line 1, quoted scalars must end in the same line"))
+	t.Run("anchor", validate("a: &b c\n", "invalid yaml in line 1, anchors and aliases are not supported"))
+}
+
+func TestRailsLocale(t *testing.T) {
+	// excerpt of de.yml of rails-i18n
+	de := `---
+de:
+  activerecord:
+    errors:
+      messages:
+        record_invalid: 'Gültigkeitsprüfung ist fehlgeschlagen: %{errors}'
+        restrict_dependent_destroy:
+          has_many: Datensatz kann nicht gelöscht werden, da abhängige %{record} existieren.
+          has_one: Datensatz kann nicht gelöscht werden, da ein abhängiger %{record}-Datensatz existiert.
+  date:
+    abbr_day_names:
+    - So
+    - Mo
+    - Di
+    - Mi
+    - Do
+    - Fr
+    - Sa
+    abbr_month_names:
+    -
+    - Jan
+    - Feb
+    - Mär
+    day_names:
+    - Sonntag
+    - Montag
+    formats:
+      default: "%d.%m.%Y"
+      long: "%e. %B %Y"
+      short: "%e. %b"
+    order:
+    - :day
+    - :month
+    - :year
+  datetime:
+    distance_in_words:
+      about_x_hours:
+        one: etwa eine Stunde
+        other: etwa %{count} Stunden
+  helpers:
+    submit:
+      create: "%{model} erstellen"
+  number:
+    currency:
+      format:
+        delimiter: "."
+        format: "%n %u"
+        precision: 2
+        separator: ","
+        significant: false
+        strip_insignificant_zeros: false
+        unit: "€"
+    human:
+      decimal_units:
+        format: "%n %u"
+        units:
+          billion:
+            one: Milliarde
+            other: Milliarden
+          quadrillion: ~
+          unit: ''
+  support:
+    array:
+      last_word_connector: " und "
+      two_words_connector: " und "
+      words_connector: ", "
+  time:
+    am: vormittags
+    formats:
+      default: "%A, %d. %B %Y, %H:%M Uhr"
+    pm: nachmittags
+`
+	fsys := fstest.MapFS{
+		"config/locales/en.yml": &fstest.MapFile{Data: []byte("en:\n  helpers:\n    submit:\n      create: Create %{model}\n")},
+		"config/locales/de.yml": &fstest.MapFile{Data: []byte(de)},
+	}
+	translations, err := New(WithFS(fsys), WithDefaultLanguage("en")).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fn := func(key string, expected string, params ...interface{}) func(t *testing.T) {
+		return func(t *testing.T) {
+			got, err := translations.GenerateTranslate("de")(key, params...)
+			if err != nil || string(got) != expected {
+				t.Fatalf("expected %q, got %q: %v", expected, got, err)
+			}
+		}
+	}
+
+	t.Run("interpolation", fn("helpers.submit.create", "Benutzer erstellen", "model", "Benutzer"))
+	t.Run("plural", fn("datetime.distance_in_words.about_x_hours_other", "etwa 3 Stunden", "count", 3))
+	t.Run("format", fn("date.formats.default", "%d.%m.%Y"))
+	if translations.Has("de", "date.order") || translations.Has("de", "number.human.decimal_units.units.quadrillion") {
+		t.Fatal("expected sequences and null values to be skipped")
+	}
+}